@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/burakmike/report-export-service/pkg/schema"
+	"github.com/burakmike/report-export-service/pkg/signing"
+)
+
+// command servis dışında çalıştırılabilen bir alt komut
+type command struct {
+	usage string
+	run   func(args []string) int
+}
+
+// Kendi kullanım metnini yazdıran komutların kullanımları
+const (
+	verifyUsage   = "verify -pubkey report.pub [-sig file.sig] file"
+	schemaUsage   = "schema [-payload] [event_type]"
+	validateUsage = "validate [-type event_type] message.json"
+)
+
+// commands desteklenen alt komutlar
+var commands = map[string]command{
+	"keygen":   {"keygen -private report.key -public report.pub", runKeygen},
+	"verify":   {verifyUsage, runVerify},
+	"schema":   {schemaUsage, runSchema},
+	"validate": {validateUsage, runValidate},
+}
+
+// runCommand adı verilen alt komutu çalıştırır ve çıkış kodunu döndürür
+func runCommand(name string, args []string) int {
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+		printUsage()
+		return 2
+	}
+	return cmd.run(args)
+}
+
+// printUsage tüm alt komutların kullanımını yazdırır
+func printUsage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "Usage:")
+	fmt.Fprintln(os.Stderr, "  report-export-service            start the service")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  report-export-service %s\n", commands[name].usage)
+	}
+}
+
+// runKeygen rapor imzalama için yeni bir Ed25519 anahtar çifti oluşturur
+func runKeygen(args []string) int {
+	fs := flag.NewFlagSet("keygen", flag.ContinueOnError)
+	privPath := fs.String("private", "report_signing.key", "path of the private key to write")
+	pubPath := fs.String("public", "report_signing.pub", "path of the public key to write")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if err := signing.GenerateKeyPair(*privPath, *pubPath); err != nil {
+		fmt.Fprintf(os.Stderr, "keygen failed: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Private key written to %s\nPublic key written to %s\n", *privPath, *pubPath)
+	return 0
+}
+
+// runVerify bir rapor dosyasını ayrık imzası ve açık anahtar ile çevrimdışı doğrular
+func runVerify(args []string) int {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	pubPath := fs.String("pubkey", "", "path of the PEM public key (required)")
+	sigPath := fs.String("sig", "", "path of the detached signature (default: <file>.sig)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *pubPath == "" || fs.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "usage: report-export-service %s\n", verifyUsage)
+		return 2
+	}
+
+	filePath := fs.Arg(0)
+	if *sigPath == "" {
+		*sigPath = signing.SignaturePath(filePath)
+	}
+
+	publicKey, err := signing.LoadPublicKey(*pubPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "verify failed: %v\n", err)
+		return 1
+	}
+
+	sig, err := signing.VerifyFile(filePath, *sigPath, publicKey)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "verify failed: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("OK: %s was signed with key %s at %s\n", filePath, sig.KeyID, sig.SignedAt)
+	return 0
+}
+
+// runSchema kayıtlı event tiplerinin JSON Schema'larını yazdırır
+func runSchema(args []string) int {
+	fs := flag.NewFlagSet("schema", flag.ContinueOnError)
+	payloadOnly := fs.Bool("payload", false, "print only the payload schema")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 1 {
+		fmt.Fprintf(os.Stderr, "usage: report-export-service %s\n", schemaUsage)
+		return 2
+	}
+
+	var (
+		out interface{}
+		err error
+	)
+	switch {
+	case fs.NArg() == 1 && *payloadOnly:
+		out, err = schema.ForPayload(event.EventType(fs.Arg(0)))
+	case fs.NArg() == 1:
+		out, err = schema.ForEvent(event.EventType(fs.Arg(0)))
+	default:
+		// Event tipi verilmezse tüm şemalar event tipine göre yazdırılır
+		all := make(map[event.EventType]*schema.Schema)
+		for _, t := range event.EventTypes() {
+			if *payloadOnly {
+				all[t], err = schema.ForPayload(t)
+			} else {
+				all[t], err = schema.ForEvent(t)
+			}
+			if err != nil {
+				break
+			}
+		}
+		out = all
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "schema failed: %v\n", err)
+		return 1
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		fmt.Fprintf(os.Stderr, "schema failed: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// runValidate örnek bir mesaj dosyasını event tipinin şemasına göre doğrular
+func runValidate(args []string) int {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	eventType := fs.String("type", "", "event type to validate against (default: the message's event_type)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "usage: report-export-service %s\n", validateUsage)
+		return 2
+	}
+
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "validate failed: %v\n", err)
+		return 1
+	}
+
+	if err := schema.ValidateMessage(data, event.EventType(*eventType)); err != nil {
+		var verrs event.ValidationErrors
+		if errors.As(err, &verrs) {
+			fmt.Fprintf(os.Stderr, "INVALID: %s has %d error(s)\n", fs.Arg(0), len(verrs))
+			for _, fe := range verrs {
+				fmt.Fprintf(os.Stderr, "  %s [%s]: %s\n", fe.Path, fe.Rule, fe.Message)
+			}
+		} else {
+			fmt.Fprintf(os.Stderr, "INVALID: %s: %v\n", fs.Arg(0), err)
+		}
+		return 1
+	}
+
+	fmt.Printf("OK: %s is a valid message\n", fs.Arg(0))
+	return 0
+}
@@ -18,22 +18,22 @@ func main() {
 			PortID:     1,
 			Name:       "My Tech Portfolio",
 			UserID:     "user123",
-			CreatedAt:  "2023-01-01 10:00:00",
-			LastUpdate: "2023-06-15 14:30:00",
+			CreatedAt:  event.MustParseTimestamp("2023-01-01 10:00:00"),
+			LastUpdate: event.MustParseTimestamp("2023-06-15 14:30:00"),
 		},
 		{
 			PortID:     2,
 			Name:       "Retirement Fund",
 			UserID:     "user456",
-			CreatedAt:  "2023-02-10 09:00:00",
-			LastUpdate: "2023-07-20 11:00:00",
+			CreatedAt:  event.MustParseTimestamp("2023-02-10 09:00:00"),
+			LastUpdate: event.MustParseTimestamp("2023-07-20 11:00:00"),
 		},
 		{
 			PortID:     3,
 			Name:       "Growth Portfolio",
 			UserID:     "user123",
-			CreatedAt:  "2023-03-05 12:30:00",
-			LastUpdate: "2023-08-01 09:15:00",
+			CreatedAt:  event.MustParseTimestamp("2023-03-05 12:30:00"),
+			LastUpdate: event.MustParseTimestamp("2023-08-01 09:15:00"),
 		},
 	}
 
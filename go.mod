@@ -2,10 +2,10 @@ module github.com/burakmike/report-export-service
 
 go 1.21
 
-require github.com/streadway/amqp v1.1.0
-
 require (
-	github.com/DATA-DOG/go-sqlmock v1.5.2 // indirect
-	github.com/jung-kurt/gofpdf v1.16.2 // indirect
-	github.com/lib/pq v1.10.9 // indirect
+	github.com/jung-kurt/gofpdf v1.16.2
+	github.com/lib/pq v1.10.9
+	github.com/streadway/amqp v1.1.0
 )
+
+require github.com/DATA-DOG/go-sqlmock v1.5.2
@@ -2,11 +2,17 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"github.com/burakmike/report-export-service/pkg/service"
 )
 
 func main() {
+	// Alt komutlar (ör. verify) servisi başlatmadan çalışır
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(os.Args[1], os.Args[2:]))
+	}
+
 	log.Println("Starting Report Export Service...")
 	svc := service.NewService()
 	err := svc.Start()
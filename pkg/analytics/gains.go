@@ -0,0 +1,251 @@
+package analytics
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+// HoldingTerm gerçekleşen kazancın elde tutma süresine göre sınıfı
+type HoldingTerm string
+
+// Elde tutma süresi sınıfları
+const (
+	ShortTerm HoldingTerm = "short"
+	LongTerm  HoldingTerm = "long"
+)
+
+// RealizedLot bir satışın tek bir alım lotuyla eşleşen kısmı
+type RealizedLot struct {
+	Instrument string
+	Acquired   time.Time
+	Sold       time.Time
+	Quantity   event.Decimal
+	Proceeds   event.Money
+	CostBasis  event.Money
+	Gain       event.Money // Proceeds - CostBasis; zarar negatiftir
+	Term       HoldingTerm
+}
+
+// YearSummary bir vergi yılında gerçekleşen kazanç ve zararların özeti
+type YearSummary struct {
+	Year      int
+	Proceeds  event.Money
+	CostBasis event.Money
+	ShortTerm event.Money
+	LongTerm  event.Money
+}
+
+// Total yılın toplam gerçekleşen kazancı
+func (y YearSummary) Total() event.Money {
+	return event.NewMoney(y.ShortTerm.Amount.Add(y.LongTerm.Amount), y.ShortTerm.Currency)
+}
+
+// RealizedGains bir portföyün eşleştirilmiş satış lotları ve yıllık özetleri
+type RealizedGains struct {
+	PortID   int
+	Name     string
+	UserID   string
+	Currency event.Currency
+	Method   event.LotMethod
+	Lots     []RealizedLot // satış tarihine göre sıralı
+	Years    []YearSummary // yıla göre sıralı
+}
+
+// Total tüm yılların toplamını döndürür; Year alanı 0'dır
+func (g RealizedGains) Total() YearSummary {
+	var proceeds, basis, short, long event.Decimal
+	for _, y := range g.Years {
+		proceeds = proceeds.Add(y.Proceeds.Amount)
+		basis = basis.Add(y.CostBasis.Amount)
+		short = short.Add(y.ShortTerm.Amount)
+		long = long.Add(y.LongTerm.Amount)
+	}
+	return YearSummary{
+		Proceeds:  event.NewMoney(proceeds, g.Currency),
+		CostBasis: event.NewMoney(basis, g.Currency),
+		ShortTerm: event.NewMoney(short, g.Currency),
+		LongTerm:  event.NewMoney(long, g.Currency),
+	}
+}
+
+// OverSoldError bir satışın o tarihteki açık lotlardan fazla miktar içerdiğini belirtir
+type OverSoldError struct {
+	PortID     int
+	Instrument string
+	Date       time.Time
+	Quantity   event.Decimal // eşleştirilemeyen miktar
+}
+
+func (e *OverSoldError) Error() string {
+	return fmt.Sprintf("portfolio %d: sell of %s on %s exceeds open lots by %s",
+		e.PortID, e.Instrument, e.Date.Format("2006-01-02"), e.Quantity)
+}
+
+// openLot henüz satılmamış bir alım lotu; cost lotun kalan toplam maliyetidir
+type openLot struct {
+	acquired time.Time
+	quantity event.Decimal
+	cost     event.Decimal
+}
+
+// BuildRealizedGains portföyün alım ve satışlarını lotlara ayırıp eşleştirir.
+// FIFO'da satışlar en eski lotlardan düşülür. Ortalama maliyette maliyet bazı
+// satış anındaki ortalama birim maliyettir; elde tutma süresi yine en eski
+// lotlara göre belirlenir. Açık lotlardan fazla satış OverSoldError döndürür.
+//
+// Alım maliyetleri ve satış gelirleri para biriminin ondalık basamağına
+// yuvarlanır ve lotlara miktarla orantılı dağıtılır; son parça kalanı alır, böylece
+// lot tutarlarının toplamı işlem tutarına tam olarak eşittir.
+func BuildRealizedGains(p event.PortfolioTransactions, method event.LotMethod) (RealizedGains, error) {
+	currency := p.Currency.OrDefault()
+	units := currency.MinorUnits()
+	gains := RealizedGains{PortID: p.PortID, Name: p.Name, UserID: p.UserID, Currency: currency, Method: method}
+	lots := make(map[string][]openLot)
+
+	for _, t := range sortedTransactions(p.Transactions) {
+		if t.Quantity.Sign() <= 0 {
+			continue
+		}
+		switch t.Type {
+		case event.TransactionBuy:
+			lots[t.Instrument] = append(lots[t.Instrument], openLot{
+				acquired: t.Date.Time,
+				quantity: t.Quantity,
+				cost:     t.CashAmount().Round(units, event.RoundHalfUp),
+			})
+		case event.TransactionSell:
+			open := lots[t.Instrument]
+			if method == event.LotAverageCost {
+				poolAverageCost(open, units)
+			}
+			realized, remaining, unmatched := matchLots(open, t, currency)
+			if unmatched.Sign() > 0 {
+				return gains, &OverSoldError{PortID: p.PortID, Instrument: t.Instrument, Date: t.Date.Time, Quantity: unmatched}
+			}
+			lots[t.Instrument] = remaining
+			gains.Lots = append(gains.Lots, realized...)
+		}
+	}
+
+	gains.Years = summarizeYears(gains.Lots, currency)
+	return gains, nil
+}
+
+// BuildAllRealizedGains payload'daki her portföy için gerçekleşen kazançları hesaplar
+func BuildAllRealizedGains(payload event.RealizedGainsReportPayload) ([]RealizedGains, error) {
+	method := payload.LotMethodOrDefault()
+	all := make([]RealizedGains, 0, len(payload.Portfolios))
+	for _, p := range payload.Portfolios {
+		gains, err := BuildRealizedGains(p, method)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, gains)
+	}
+	return all, nil
+}
+
+// allocate total tutarının of miktarının qty kadarlık payını units basamağa
+// yuvarlanmış olarak döndürür; qty tüm miktarsa total aynen döner
+func allocate(total, qty, of event.Decimal, units int32) event.Decimal {
+	if qty.Cmp(of) >= 0 {
+		return total
+	}
+	return total.Mul(qty).Div(of, units, event.RoundHalfUp)
+}
+
+// poolAverageCost havuzun toplam maliyetini açık lotlara miktarlarıyla orantılı
+// dağıtır, böylece her lotun birim maliyeti havuzun ortalama maliyeti olur
+func poolAverageCost(lots []openLot, units int32) {
+	var quantity, cost event.Decimal
+	for _, l := range lots {
+		quantity = quantity.Add(l.quantity)
+		cost = cost.Add(l.cost)
+	}
+	for i := range lots {
+		share := allocate(cost, lots[i].quantity, quantity, units)
+		cost, quantity = cost.Sub(share), quantity.Sub(lots[i].quantity)
+		lots[i].cost = share
+	}
+}
+
+// matchLots satışı en eski lotlardan başlayarak eşleştirir; gerçekleşen lotları,
+// kalan açık lotları ve eşleştirilemeyen miktarı döndürür
+func matchLots(lots []openLot, sell event.Transaction, currency event.Currency) ([]RealizedLot, []openLot, event.Decimal) {
+	units := currency.MinorUnits()
+	proceedsLeft := sell.CashAmount().Round(units, event.RoundHalfUp)
+	remaining := sell.Quantity
+
+	var realized []RealizedLot
+	for len(lots) > 0 && remaining.Sign() > 0 {
+		lot := &lots[0]
+		qty := lot.quantity
+		if qty.Cmp(remaining) > 0 {
+			qty = remaining
+		}
+
+		proceeds := allocate(proceedsLeft, qty, remaining, units)
+		basis := allocate(lot.cost, qty, lot.quantity, units)
+		realized = append(realized, RealizedLot{
+			Instrument: sell.Instrument,
+			Acquired:   lot.acquired,
+			Sold:       sell.Date.Time,
+			Quantity:   qty,
+			Proceeds:   event.NewMoney(proceeds, currency),
+			CostBasis:  event.NewMoney(basis, currency),
+			Gain:       event.NewMoney(proceeds.Sub(basis), currency),
+			Term:       holdingTerm(lot.acquired, sell.Date.Time),
+		})
+
+		lot.quantity, lot.cost = lot.quantity.Sub(qty), lot.cost.Sub(basis)
+		remaining, proceedsLeft = remaining.Sub(qty), proceedsLeft.Sub(proceeds)
+		if lot.quantity.Sign() <= 0 {
+			lots = lots[1:]
+		}
+	}
+	return realized, lots, remaining
+}
+
+// holdingTerm bir yıldan uzun elde tutulan lotları uzun vadeli sayar
+func holdingTerm(acquired, sold time.Time) HoldingTerm {
+	if sold.After(acquired.AddDate(1, 0, 0)) {
+		return LongTerm
+	}
+	return ShortTerm
+}
+
+// summarizeYears gerçekleşen lotları satış yılına göre toplar
+func summarizeYears(lots []RealizedLot, currency event.Currency) []YearSummary {
+	type totals struct{ proceeds, basis, short, long event.Decimal }
+	byYear := make(map[int]*totals)
+	for _, l := range lots {
+		y, ok := byYear[l.Sold.Year()]
+		if !ok {
+			y = &totals{}
+			byYear[l.Sold.Year()] = y
+		}
+		y.proceeds = y.proceeds.Add(l.Proceeds.Amount)
+		y.basis = y.basis.Add(l.CostBasis.Amount)
+		if l.Term == LongTerm {
+			y.long = y.long.Add(l.Gain.Amount)
+		} else {
+			y.short = y.short.Add(l.Gain.Amount)
+		}
+	}
+
+	years := make([]YearSummary, 0, len(byYear))
+	for year, y := range byYear {
+		years = append(years, YearSummary{
+			Year:      year,
+			Proceeds:  event.NewMoney(y.proceeds, currency),
+			CostBasis: event.NewMoney(y.basis, currency),
+			ShortTerm: event.NewMoney(y.short, currency),
+			LongTerm:  event.NewMoney(y.long, currency),
+		})
+	}
+	sort.Slice(years, func(i, j int) bool { return years[i].Year < years[j].Year })
+	return years
+}
@@ -0,0 +1,147 @@
+package analytics
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+// decimalIs d'nin want metniyle sayısal olarak eşit olup olmadığını döndürür
+func decimalIs(d event.Decimal, want string) bool {
+	return d.Equal(event.MustParseDecimal(want))
+}
+
+// trade test verisi için kısa alım/satım yardımcısı
+func trade(date string, typ event.TransactionType, instrument, quantity, price string) event.Transaction {
+	return event.Transaction{
+		Date:       event.MustParseTimestamp(date),
+		Type:       typ,
+		Instrument: instrument,
+		Quantity:   event.MustParseDecimal(quantity),
+		Price:      event.MustParseDecimal(price),
+	}
+}
+
+// gainsFixture iki alım ve bunları kısmen tüketen iki satış içerir
+func gainsFixture() event.PortfolioTransactions {
+	return event.PortfolioTransactions{
+		PortID: 1, Name: "A", UserID: "u1",
+		Transactions: []event.Transaction{
+			trade("2022-01-10", event.TransactionBuy, "AAPL", "10", "100"),
+			trade("2023-06-01", event.TransactionBuy, "AAPL", "10", "130"),
+			trade("2023-03-01", event.TransactionSell, "AAPL", "4", "150"),
+			trade("2024-02-01", event.TransactionSell, "AAPL", "10", "160"),
+			{Date: event.MustParseTimestamp("2023-01-01"), Type: event.TransactionDeposit, Amount: event.NewDecimalFromInt(1000)},
+		},
+	}
+}
+
+func TestBuildRealizedGains_FIFO(t *testing.T) {
+	gains, err := BuildRealizedGains(gainsFixture(), event.LotFIFO)
+	if err != nil {
+		t.Fatalf("BuildRealizedGains error: %v", err)
+	}
+
+	want := []struct {
+		quantity, proceeds, basis, gain string
+		term                            HoldingTerm
+	}{
+		{"4", "600", "400", "200", LongTerm},
+		{"6", "960", "600", "360", LongTerm},
+		{"4", "640", "520", "120", ShortTerm},
+	}
+	if len(gains.Lots) != len(want) {
+		t.Fatalf("Got %d lots, want %d: %+v", len(gains.Lots), len(want), gains.Lots)
+	}
+	for i, l := range gains.Lots {
+		w := want[i]
+		if !decimalIs(l.Quantity, w.quantity) || !decimalIs(l.Proceeds.Amount, w.proceeds) ||
+			!decimalIs(l.CostBasis.Amount, w.basis) || !decimalIs(l.Gain.Amount, w.gain) || l.Term != w.term {
+			t.Errorf("Lot %d = %+v; want %+v", i, l, w)
+		}
+		if l.Proceeds.Currency != event.DefaultCurrency {
+			t.Errorf("Lot %d currency = %s; want USD", i, l.Proceeds.Currency)
+		}
+	}
+
+	if len(gains.Years) != 2 || gains.Years[0].Year != 2023 || !decimalIs(gains.Years[0].LongTerm.Amount, "200") ||
+		!decimalIs(gains.Years[1].LongTerm.Amount, "360") || !decimalIs(gains.Years[1].ShortTerm.Amount, "120") {
+		t.Errorf("Unexpected yearly summary %+v", gains.Years)
+	}
+	if total := gains.Total(); !decimalIs(total.Total().Amount, "680") {
+		t.Errorf("Total gain = %s; want 680", total.Total())
+	}
+}
+
+func TestBuildRealizedGains_AverageCost(t *testing.T) {
+	gains, err := BuildRealizedGains(gainsFixture(), event.LotAverageCost)
+	if err != nil {
+		t.Fatalf("BuildRealizedGains error: %v", err)
+	}
+
+	// The 2024 sale draws from 6 shares at 100 and 10 at 130: average 118.75
+	var basis2024 event.Decimal
+	for _, l := range gains.Lots {
+		if l.Sold.Year() == 2024 {
+			basis2024 = basis2024.Add(l.CostBasis.Amount)
+		}
+	}
+	if !decimalIs(basis2024, "1187.5") {
+		t.Errorf("2024 cost basis = %s; want 1187.50", basis2024)
+	}
+	// Holding periods still follow the oldest lots
+	if gains.Lots[1].Term != LongTerm || gains.Lots[2].Term != ShortTerm {
+		t.Errorf("Unexpected terms %+v", gains.Lots)
+	}
+}
+
+func TestBuildRealizedGains_OverSold(t *testing.T) {
+	p := event.PortfolioTransactions{PortID: 7, Transactions: []event.Transaction{
+		trade("2024-01-01", event.TransactionBuy, "MSFT", "1", "10"),
+		trade("2024-02-01", event.TransactionSell, "MSFT", "3", "12"),
+	}}
+
+	_, err := BuildRealizedGains(p, event.LotFIFO)
+	var oversold *OverSoldError
+	if !errors.As(err, &oversold) || oversold.Instrument != "MSFT" || !decimalIs(oversold.Quantity, "2") {
+		t.Errorf("Expected OverSoldError for 2 MSFT, got %v", err)
+	}
+}
+
+func TestBuildRealizedGains_ExactAllocation(t *testing.T) {
+	// Thirds of a cent: the lots must still add up to the trade amounts exactly
+	p := event.PortfolioTransactions{PortID: 1, Currency: "JPY", Transactions: []event.Transaction{
+		trade("2024-01-01", event.TransactionBuy, "7203", "3", "333.33"),
+		trade("2024-01-02", event.TransactionBuy, "7203", "3", "100"),
+		trade("2024-02-01", event.TransactionSell, "7203", "1", "500"),
+		trade("2024-02-02", event.TransactionSell, "7203", "5", "401"),
+	}}
+
+	for _, method := range []event.LotMethod{event.LotFIFO, event.LotAverageCost} {
+		gains, err := BuildRealizedGains(p, method)
+		if err != nil {
+			t.Fatalf("%s: BuildRealizedGains error: %v", method, err)
+		}
+		total := gains.Total()
+		// Costs and proceeds are rounded to whole yen: 1000 + 300, 500 + 2005
+		if !decimalIs(total.CostBasis.Amount, "1300") || !decimalIs(total.Proceeds.Amount, "2505") || total.Proceeds.Currency != "JPY" {
+			t.Errorf("%s: total = %+v; want cost 1300 JPY, proceeds 2505 JPY", method, total)
+		}
+		for _, l := range gains.Lots {
+			if l.CostBasis.Amount.Scale() > 0 || l.Proceeds.Amount.Scale() > 0 {
+				t.Errorf("%s: lot %+v has fractional yen", method, l)
+			}
+		}
+	}
+}
+
+func TestHoldingTerm(t *testing.T) {
+	acquired := event.MustParseTimestamp("2023-03-15").Time
+	if holdingTerm(acquired, acquired.AddDate(1, 0, 0)) != ShortTerm {
+		t.Error("Exactly one year should be short-term")
+	}
+	if holdingTerm(acquired, acquired.AddDate(1, 0, 1)) != LongTerm {
+		t.Error("More than one year should be long-term")
+	}
+}
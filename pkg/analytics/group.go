@@ -0,0 +1,50 @@
+package analytics
+
+import (
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+// MemberSection grup raporunda bir kullanıcının bölümü
+type MemberSection struct {
+	UserID     string
+	Portfolios []event.Portfolio // yalnızca bu kullanıcıya ait, dönem raporuna giren portföyler
+	Summary    PeriodSummary
+}
+
+// GroupReport bir hane ya da danışman portföyünün birleşik raporu
+type GroupReport struct {
+	Members []MemberSection // istekteki kullanıcı sırasıyla
+	Totals  PeriodSummary   // tüm bölümlerin toplamı
+}
+
+// BuildGroupReport portföyleri grup üyelerine göre bölümlere ayırır. Her bölüm yalnızca
+// kendi kullanıcısının portföylerini içerir; grupta olmayan kullanıcılara ait portföyler
+// hiçbir bölüme ve toplama girmez. Portföyü olmayan üyeler boş bölümle yer alır.
+func BuildGroupReport(userIDs []string, portfolios []event.Portfolio, period event.ResolvedPeriod) GroupReport {
+	byUser := make(map[string][]event.Portfolio, len(userIDs))
+	for _, p := range portfolios {
+		byUser[p.UserID] = append(byUser[p.UserID], p)
+	}
+
+	report := GroupReport{Members: make([]MemberSection, 0, len(userIDs))}
+	for _, userID := range userIDs {
+		kept, summary := FilterPortfolios(byUser[userID], period)
+		report.Members = append(report.Members, MemberSection{UserID: userID, Portfolios: kept, Summary: summary})
+		report.Totals.Total += summary.Total
+		report.Totals.New += summary.New
+		report.Totals.Updated += summary.Updated
+		report.Totals.Excluded += summary.Excluded
+	}
+	return report
+}
+
+// EmptyMembers portföyü olmayan üyeleri döndürür
+func (r GroupReport) EmptyMembers() []string {
+	var empty []string
+	for _, m := range r.Members {
+		if len(m.Portfolios) == 0 {
+			empty = append(empty, m.UserID)
+		}
+	}
+	return empty
+}
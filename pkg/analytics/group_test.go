@@ -0,0 +1,42 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+func TestBuildGroupReport(t *testing.T) {
+	day := event.MustParseTimestamp
+	portfolios := []event.Portfolio{
+		{PortID: 1, Name: "A", UserID: "u1", CreatedAt: day("2025-01-01"), LastUpdate: day("2026-08-01")},
+		{PortID: 2, Name: "B", UserID: "u2", CreatedAt: day("2026-07-10"), LastUpdate: day("2026-07-10")},
+		{PortID: 3, Name: "C", UserID: "u1", CreatedAt: day("2024-01-01"), LastUpdate: day("2024-02-01")},
+		{PortID: 4, Name: "Outsider", UserID: "u9", CreatedAt: day("2026-07-01"), LastUpdate: day("2026-07-01")},
+	}
+	period, _ := event.ReportPeriod{Period: "Q3-2026"}.Resolve(time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC))
+
+	group := BuildGroupReport([]string{"u2", "u1", "u3"}, portfolios, period)
+
+	if len(group.Members) != 3 || group.Members[0].UserID != "u2" || group.Members[2].UserID != "u3" {
+		t.Fatalf("Unexpected members: %+v", group.Members)
+	}
+	// Her bölüm yalnızca kendi kullanıcısının portföylerini içerir
+	for _, m := range group.Members {
+		for _, p := range m.Portfolios {
+			if p.UserID != m.UserID {
+				t.Errorf("Section %s contains portfolio %d of %s", m.UserID, p.PortID, p.UserID)
+			}
+		}
+	}
+	if got := group.Members[1].Summary; got != (PeriodSummary{Total: 2, Updated: 1}) {
+		t.Errorf("u1 summary = %+v", got)
+	}
+	if want := (PeriodSummary{Total: 3, New: 1, Updated: 1}); group.Totals != want {
+		t.Errorf("Totals = %+v; want %+v", group.Totals, want)
+	}
+	if empty := group.EmptyMembers(); len(empty) != 1 || empty[0] != "u3" {
+		t.Errorf("EmptyMembers = %v; want [u3]", empty)
+	}
+}
@@ -0,0 +1,138 @@
+package analytics
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+// Position piyasa değeri ve portföy içindeki ağırlığı hesaplanmış bir pozisyon
+type Position struct {
+	Holding event.Holding
+	// LocalValue pozisyonun kendi para birimindeki değeri, o para birimine yuvarlanmış
+	LocalValue event.Money
+	// MarketValue raporlama para birimine çevrilmiş ve yuvarlanmış değer
+	MarketValue event.Money
+	Weight      float64 // toplam piyasa değerine oranı (0-1)
+}
+
+// AllocationSlice bir varlık sınıfının toplam değeri ve ağırlığı
+type AllocationSlice struct {
+	AssetClass  event.AssetClass
+	MarketValue event.Money
+	Weight      float64 // toplam piyasa değerine oranı (0-1)
+}
+
+// HoldingsStatement bir portföyün pozisyon dökümü ve varlık dağılımı
+type HoldingsStatement struct {
+	PortID     int
+	Name       string
+	UserID     string
+	AsOf       event.Timestamp
+	Currency   event.Currency // raporlama para birimi
+	Positions  []Position     // piyasa değerine göre büyükten küçüğe
+	TotalValue event.Money
+	Allocation []AllocationSlice // event.AssetClasses sırasıyla, yalnızca değeri olan sınıflar
+}
+
+// BuildHoldingsStatement portföyün pozisyonlarının piyasa değerlerini raporlama
+// para birimine çevirir, ağırlıklarını ve varlık sınıfı dağılımını hesaplar.
+// Her değer çevrimden sonra bir kez yuvarlanır; toplamlar yuvarlanmış değerlerin
+// toplamıdır, böylece tablo satırları toplamla tutarlıdır.
+func BuildHoldingsStatement(p event.PortfolioHoldings, settings event.CurrencySettings) (HoldingsStatement, error) {
+	currency := settings.ReportingCurrencyOrDefault()
+	statement := HoldingsStatement{
+		PortID:     p.PortID,
+		Name:       p.Name,
+		UserID:     p.UserID,
+		AsOf:       p.AsOf,
+		Currency:   currency,
+		Positions:  make([]Position, 0, len(p.Holdings)),
+		TotalValue: event.NewMoney(event.Decimal{}, currency),
+	}
+
+	byClass := make(map[event.AssetClass]event.Decimal)
+	for _, h := range p.Holdings {
+		local := h.MarketValue(currency)
+		value, err := settings.ToReporting(local)
+		if err != nil {
+			return statement, fmt.Errorf("portfolio %d, %s: %w", p.PortID, h.Instrument, err)
+		}
+		statement.Positions = append(statement.Positions, Position{
+			Holding:     h,
+			LocalValue:  local.Round(settings.RoundingOrDefault()),
+			MarketValue: value,
+		})
+		statement.TotalValue.Amount = statement.TotalValue.Amount.Add(value.Amount)
+		byClass[h.AssetClass] = byClass[h.AssetClass].Add(value.Amount)
+	}
+
+	total := statement.TotalValue.Amount.Float64()
+	for i := range statement.Positions {
+		statement.Positions[i].Weight = Share(statement.Positions[i].MarketValue.Amount.Float64(), total)
+	}
+	sort.SliceStable(statement.Positions, func(i, j int) bool {
+		return statement.Positions[i].MarketValue.Amount.Cmp(statement.Positions[j].MarketValue.Amount) > 0
+	})
+
+	statement.Allocation = allocation(byClass, statement.TotalValue)
+	return statement, nil
+}
+
+// BuildHoldingsStatements payload'daki her portföy için bir döküm oluşturur
+func BuildHoldingsStatements(payload event.HoldingsReportPayload) ([]HoldingsStatement, error) {
+	statements := make([]HoldingsStatement, len(payload.Portfolios))
+	for i, p := range payload.Portfolios {
+		statement, err := BuildHoldingsStatement(p, payload.CurrencySettings)
+		if err != nil {
+			return nil, err
+		}
+		statements[i] = statement
+	}
+	return statements, nil
+}
+
+// AggregateAllocation tüm dökümlerin varlık sınıfı dağılımını birleştirir ve
+// toplam piyasa değeriyle birlikte döndürür. Dökümlerin aynı raporlama para
+// biriminde olduğu varsayılır.
+func AggregateAllocation(statements []HoldingsStatement) ([]AllocationSlice, event.Money) {
+	total := event.NewMoney(event.Decimal{}, event.DefaultCurrency)
+	if len(statements) > 0 {
+		total.Currency = statements[0].Currency
+	}
+
+	byClass := make(map[event.AssetClass]event.Decimal)
+	for _, s := range statements {
+		for _, slice := range s.Allocation {
+			byClass[slice.AssetClass] = byClass[slice.AssetClass].Add(slice.MarketValue.Amount)
+		}
+		total.Amount = total.Amount.Add(s.TotalValue.Amount)
+	}
+	return allocation(byClass, total), total
+}
+
+// allocation sınıf bazında değerleri event.AssetClasses sırasıyla dilimlere dönüştürür
+func allocation(byClass map[event.AssetClass]event.Decimal, total event.Money) []AllocationSlice {
+	var slices []AllocationSlice
+	for _, class := range event.AssetClasses {
+		value, ok := byClass[class]
+		if !ok || value.IsZero() {
+			continue
+		}
+		slices = append(slices, AllocationSlice{
+			AssetClass:  class,
+			MarketValue: event.NewMoney(value, total.Currency),
+			Weight:      Share(value.Float64(), total.Amount.Float64()),
+		})
+	}
+	return slices
+}
+
+// Share değerin toplama oranını döndürür; toplam sıfırsa 0
+func Share(value, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return value / total
+}
@@ -0,0 +1,129 @@
+package analytics
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+func approx(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+
+// holding test verisi için kısa yardımcı
+func holding(instrument string, class event.AssetClass, quantity, price string, currency event.Currency) event.Holding {
+	return event.Holding{
+		Instrument: instrument,
+		AssetClass: class,
+		Quantity:   event.MustParseDecimal(quantity),
+		Price:      event.MustParseDecimal(price),
+		Currency:   currency,
+	}
+}
+
+func TestBuildHoldingsStatement(t *testing.T) {
+	s, err := BuildHoldingsStatement(event.PortfolioHoldings{
+		PortID: 1, Name: "Growth", UserID: "u1",
+		Holdings: []event.Holding{
+			holding("BND", event.AssetFixedIncome, "10", "20", ""),
+			holding("AAPL", event.AssetEquity, "3", "200", ""),
+			holding("MSFT", event.AssetEquity, "1", "100", ""),
+			holding("USD", event.AssetCash, "100", "1", ""),
+		},
+	}, event.CurrencySettings{})
+	if err != nil {
+		t.Fatalf("BuildHoldingsStatement error: %v", err)
+	}
+
+	if s.TotalValue.String() != "1000.00 USD" {
+		t.Fatalf("TotalValue = %s; want 1000.00 USD", s.TotalValue)
+	}
+	if s.Positions[0].Holding.Instrument != "AAPL" || !approx(s.Positions[0].Weight, 0.6) {
+		t.Errorf("Largest position = %s (%.2f); want AAPL (0.60)", s.Positions[0].Holding.Instrument, s.Positions[0].Weight)
+	}
+
+	want := []struct {
+		class  event.AssetClass
+		value  string
+		weight float64
+	}{
+		{event.AssetEquity, "700.00", 0.7},
+		{event.AssetFixedIncome, "200.00", 0.2},
+		{event.AssetCash, "100.00", 0.1},
+	}
+	if len(s.Allocation) != len(want) {
+		t.Fatalf("Got %d allocation slices, want %d", len(s.Allocation), len(want))
+	}
+	for i, slice := range s.Allocation {
+		if slice.AssetClass != want[i].class || slice.MarketValue.Amount.String() != want[i].value || !approx(slice.Weight, want[i].weight) {
+			t.Errorf("Slice %d = %+v; want %+v", i, slice, want[i])
+		}
+	}
+}
+
+func TestBuildHoldingsStatement_MultiCurrency(t *testing.T) {
+	settings := event.CurrencySettings{
+		ReportingCurrency: "EUR",
+		FXRates: event.FXRates{
+			{From: "EUR", To: "USD", Rate: event.MustParseDecimal("1.08")},
+			{From: "USD", To: "JPY", Rate: event.MustParseDecimal("150")},
+		},
+	}
+	s, err := BuildHoldingsStatement(event.PortfolioHoldings{
+		PortID: 1,
+		Holdings: []event.Holding{
+			// 3 * 33.335 = 100.005 USD -> 92.5972... EUR
+			holding("AAPL", event.AssetEquity, "3", "33.335", "USD"),
+			// 10000 JPY -> USD -> EUR (cross rate)
+			holding("7203", event.AssetEquity, "1", "10000", "JPY"),
+			holding("SAP", event.AssetEquity, "0.5", "0.01", ""),
+		},
+	}, settings)
+	if err != nil {
+		t.Fatalf("BuildHoldingsStatement error: %v", err)
+	}
+
+	values := map[string]string{}
+	for _, p := range s.Positions {
+		values[p.Holding.Instrument] = p.MarketValue.String()
+	}
+	want := map[string]string{"AAPL": "92.60 EUR", "7203": "61.73 EUR", "SAP": "0.01 EUR"}
+	for instrument, v := range want {
+		if values[instrument] != v {
+			t.Errorf("%s = %s; want %s", instrument, values[instrument], v)
+		}
+	}
+	// The total is the sum of the rounded rows
+	if s.TotalValue.String() != "154.34 EUR" {
+		t.Errorf("TotalValue = %s; want 154.34 EUR", s.TotalValue)
+	}
+
+	_, err = BuildHoldingsStatement(event.PortfolioHoldings{PortID: 2, Holdings: []event.Holding{
+		holding("VOD", event.AssetEquity, "1", "1", "GBP"),
+	}}, settings)
+	if !errors.Is(err, event.ErrNoFXRate) {
+		t.Errorf("Expected ErrNoFXRate, got %v", err)
+	}
+}
+
+func TestAggregateAllocation(t *testing.T) {
+	statements, err := BuildHoldingsStatements(event.HoldingsReportPayload{Portfolios: []event.PortfolioHoldings{
+		{PortID: 1, Holdings: []event.Holding{holding("AAPL", event.AssetEquity, "1", "300", "")}},
+		{PortID: 2, Holdings: []event.Holding{holding("GLD", event.AssetCommodity, "1", "100", "")}},
+		{PortID: 3},
+	}})
+	if err != nil {
+		t.Fatalf("BuildHoldingsStatements error: %v", err)
+	}
+
+	slices, total := AggregateAllocation(statements)
+	if total.String() != "400.00 USD" {
+		t.Errorf("total = %s; want 400.00 USD", total)
+	}
+	if len(slices) != 2 || slices[0].AssetClass != event.AssetEquity || !approx(slices[0].Weight, 0.75) || !approx(slices[1].Weight, 0.25) {
+		t.Errorf("Unexpected aggregate allocation %+v", slices)
+	}
+	if statements[2].Allocation != nil || !statements[2].TotalValue.IsZero() {
+		t.Errorf("Empty portfolio should have no allocation, got %+v", statements[2])
+	}
+}
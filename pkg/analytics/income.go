@@ -0,0 +1,160 @@
+package analytics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+// IncomeTotals brüt gelir, kaynakta kesilen vergi ve net gelir toplamları
+type IncomeTotals struct {
+	Payments    int
+	Gross       event.Money
+	Withholding event.Money
+	Net         event.Money
+}
+
+// newIncomeTotals para biriminde sıfır toplamlar oluşturur
+func newIncomeTotals(currency event.Currency) IncomeTotals {
+	zero := event.NewMoney(event.Decimal{}, currency)
+	return IncomeTotals{Gross: zero, Withholding: zero, Net: zero}
+}
+
+// add bir ödemeyi toplamlara ekler
+func (t *IncomeTotals) add(p event.IncomePayment) {
+	t.Payments++
+	t.Gross.Amount = t.Gross.Amount.Add(p.Gross)
+	t.Withholding.Amount = t.Withholding.Amount.Add(p.WithholdingTax)
+	t.Net.Amount = t.Net.Amount.Add(p.Net())
+}
+
+// InstrumentIncome bir enstrümanın belirli bir gelir türündeki toplamları
+type InstrumentIncome struct {
+	Instrument string // faiz gibi enstrümansız ödemelerde boş
+	Type       event.IncomeType
+	IncomeTotals
+}
+
+// MonthIncome bir takvim ayının gelir toplamları
+type MonthIncome struct {
+	Month  time.Time                          // ayın ilk günü
+	ByType map[event.IncomeType]event.Decimal // türe göre brüt gelir
+	IncomeTotals
+}
+
+// IncomeStatement bir portföyün dönem içindeki gelir özeti
+type IncomeStatement struct {
+	PortID       int
+	Name         string
+	UserID       string
+	Currency     event.Currency
+	Period       event.ResolvedPeriod
+	ByInstrument []InstrumentIncome // enstrüman ve türe göre sıralı
+	ByType       map[event.IncomeType]IncomeTotals
+	Months       []MonthIncome // dönemin her ayı, ödeme olmasa da
+	Total        IncomeTotals
+}
+
+// BuildIncomeStatement portföyün çözülmüş dönemdeki ödemelerini enstrüman, tür ve
+// ay bazında toplar. Dönem dışındaki ve as-of tarihinden sonraki ödemeler yok sayılır.
+// Dönem sınırları UTC'dir; farklı UTC ofsetleriyle gelen tarihler aynı aylara düşsün
+// diye ödeme tarihleri de UTC'ye çevrilir ve aylar UTC takvimine göredir.
+func BuildIncomeStatement(p event.PortfolioIncome, period event.ResolvedPeriod) IncomeStatement {
+	currency := p.Currency.OrDefault()
+	statement := IncomeStatement{
+		PortID:   p.PortID,
+		Name:     p.Name,
+		UserID:   p.UserID,
+		Currency: currency,
+		Period:   period,
+		ByType:   make(map[event.IncomeType]IncomeTotals),
+		Months:   incomeMonths(period, currency),
+		Total:    newIncomeTotals(currency),
+	}
+
+	type key struct {
+		instrument string
+		typ        event.IncomeType
+	}
+	byInstrument := make(map[key]*InstrumentIncome)
+
+	for _, pay := range p.Payments {
+		date := pay.Date.UTC()
+		if !period.Contains(date) || date.After(period.AsOf) {
+			continue
+		}
+
+		k := key{pay.Instrument, pay.Type}
+		line, ok := byInstrument[k]
+		if !ok {
+			line = &InstrumentIncome{Instrument: pay.Instrument, Type: pay.Type, IncomeTotals: newIncomeTotals(currency)}
+			byInstrument[k] = line
+		}
+		line.add(pay)
+
+		typeTotals, ok := statement.ByType[pay.Type]
+		if !ok {
+			typeTotals = newIncomeTotals(currency)
+		}
+		typeTotals.add(pay)
+		statement.ByType[pay.Type] = typeTotals
+
+		if i := monthIndex(period.Start, date); i >= 0 && i < len(statement.Months) {
+			month := &statement.Months[i]
+			month.add(pay)
+			month.ByType[pay.Type] = month.ByType[pay.Type].Add(pay.Gross)
+		}
+
+		statement.Total.add(pay)
+	}
+
+	for _, line := range byInstrument {
+		statement.ByInstrument = append(statement.ByInstrument, *line)
+	}
+	sort.Slice(statement.ByInstrument, func(i, j int) bool {
+		a, b := statement.ByInstrument[i], statement.ByInstrument[j]
+		if a.Instrument != b.Instrument {
+			return a.Instrument < b.Instrument
+		}
+		return a.Type < b.Type
+	})
+	return statement
+}
+
+// BuildIncomeStatements payload'daki her portföy için çözülmüş dönemin gelir özetini oluşturur
+func BuildIncomeStatements(payload event.IncomeReportPayload, period event.ResolvedPeriod) []IncomeStatement {
+	statements := make([]IncomeStatement, len(payload.Portfolios))
+	for i, p := range payload.Portfolios {
+		statements[i] = BuildIncomeStatement(p, period)
+	}
+	return statements
+}
+
+// incomeMonths dönemin her takvim ayı için boş bir kayıt oluşturur. Dönemin iki sınırı
+// de yoksa ay tablosu boştur; bitiş sınırı hariç olduğundan son ay bitişten hemen önceki andır.
+func incomeMonths(period event.ResolvedPeriod, currency event.Currency) []MonthIncome {
+	var months []MonthIncome
+	if period.Start.IsZero() || period.End.IsZero() {
+		return months
+	}
+	last := period.End.Add(-time.Nanosecond)
+	for m := firstOfMonth(period.Start); !m.After(last); m = m.AddDate(0, 1, 0) {
+		months = append(months, MonthIncome{
+			Month:        m,
+			ByType:       make(map[event.IncomeType]event.Decimal),
+			IncomeTotals: newIncomeTotals(currency),
+		})
+	}
+	return months
+}
+
+// monthIndex t'nin from ayından itibaren kaçıncı ay olduğunu döndürür
+func monthIndex(from, t time.Time) int {
+	return (t.Year()-from.Year())*12 + int(t.Month()) - int(from.Month())
+}
+
+// firstOfMonth t'nin ayının ilk gününü döndürür
+func firstOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,120 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+func TestBuildIncomeStatements(t *testing.T) {
+	day, dec := event.MustParseTimestamp, event.MustParseDecimal
+	payload := event.IncomeReportPayload{
+		ReportPeriod: event.ReportPeriod{Period: "Q1-2024"},
+		Portfolios: []event.PortfolioIncome{{
+			PortID: 1, Name: "A", UserID: "u1",
+			Payments: []event.IncomePayment{
+				{Date: day("2024-01-15"), Type: event.IncomeDividend, Instrument: "AAPL", Gross: dec("100"), WithholdingTax: dec("15")},
+				{Date: day("2024-03-31 18:00:00"), Type: event.IncomeDividend, Instrument: "AAPL", Gross: dec("110"), WithholdingTax: dec("16.5")},
+				{Date: day("2024-02-01"), Type: event.IncomeCoupon, Instrument: "BND", Gross: dec("40")},
+				{Date: day("2024-02-29"), Type: event.IncomeInterest, Gross: dec("5")},
+				{Date: day("2023-12-31"), Type: event.IncomeDividend, Instrument: "AAPL", Gross: dec("999")}, // outside the period
+			},
+		}},
+	}
+
+	s := BuildIncomeStatements(payload, resolvePeriod(t, payload.ReportPeriod))[0]
+
+	if s.Total.Payments != 4 || s.Total.Gross.String() != "255 USD" || s.Total.Withholding.String() != "31.5 USD" || s.Total.Net.String() != "223.5 USD" {
+		t.Errorf("Unexpected total %+v", s.Total)
+	}
+
+	if len(s.ByInstrument) != 3 {
+		t.Fatalf("Got %d instrument lines, want 3: %+v", len(s.ByInstrument), s.ByInstrument)
+	}
+	// Interest has no instrument and sorts first
+	if s.ByInstrument[0].Type != event.IncomeInterest || s.ByInstrument[1].Instrument != "AAPL" || s.ByInstrument[1].Payments != 2 {
+		t.Errorf("Unexpected instrument lines %+v", s.ByInstrument)
+	}
+
+	if len(s.Months) != 3 {
+		t.Fatalf("Got %d months, want 3", len(s.Months))
+	}
+	wantGross := []string{"100", "45", "110"}
+	for i, m := range s.Months {
+		if !decimalIs(m.Gross.Amount, wantGross[i]) {
+			t.Errorf("Month %s gross = %s; want %s", m.Month.Format("2006-01"), m.Gross, wantGross[i])
+		}
+	}
+	if !decimalIs(s.Months[1].ByType[event.IncomeCoupon], "40") || !decimalIs(s.ByType[event.IncomeDividend].Net.Amount, "178.5") {
+		t.Errorf("Unexpected per-type totals: %+v / %+v", s.Months[1].ByType, s.ByType)
+	}
+}
+
+func TestBuildIncomeStatements_MixedOffsets(t *testing.T) {
+	ts, dec := event.MustParseTimestamp, event.MustParseDecimal
+	tsPtr := func(s string) *event.Timestamp { v := ts(s); return &v }
+	payload := event.IncomeReportPayload{
+		ReportPeriod: event.ReportPeriod{
+			PeriodStart: tsPtr("2026-01-01T00:00:00+03:00"), // 2025-12-31T21:00:00Z
+			PeriodEnd:   tsPtr("2026-01-31"),
+		},
+		Portfolios: []event.PortfolioIncome{{
+			PortID: 1, Name: "A", UserID: "u1",
+			Payments: []event.IncomePayment{
+				{Date: ts("2025-12-31T22:00:00Z"), Type: event.IncomeDividend, Instrument: "AAPL", Gross: dec("10")},
+				{Date: ts("2026-01-15T09:00:00-05:00"), Type: event.IncomeDividend, Instrument: "AAPL", Gross: dec("20")},
+				{Date: ts("2026-02-01T01:00:00+02:00"), Type: event.IncomeDividend, Instrument: "AAPL", Gross: dec("30")}, // 2026-01-31T23:00Z
+			},
+		}},
+	}
+
+	s := BuildIncomeStatements(payload, resolvePeriod(t, payload.ReportPeriod))[0]
+
+	if s.Total.Payments != 3 || !decimalIs(s.Total.Gross.Amount, "60") {
+		t.Errorf("Unexpected total %+v", s.Total)
+	}
+	if len(s.Months) != 2 {
+		t.Fatalf("Got %d months, want 2 (Dec and Jan in UTC)", len(s.Months))
+	}
+	wantGross := []string{"10", "50"}
+	for i, m := range s.Months {
+		if m.Month.Location() != time.UTC || !decimalIs(m.Gross.Amount, wantGross[i]) {
+			t.Errorf("Month %s gross = %s; want %s in UTC", m.Month.Format(time.RFC3339), m.Gross, wantGross[i])
+		}
+	}
+}
+
+func TestBuildIncomeStatements_AsOf(t *testing.T) {
+	day, dec := event.MustParseTimestamp, event.MustParseDecimal
+	asOf := day("2024-02-15")
+	payload := event.IncomeReportPayload{
+		ReportPeriod: event.ReportPeriod{Period: "Q1-2024", AsOf: &asOf},
+		Portfolios: []event.PortfolioIncome{{
+			PortID: 1, Name: "A", UserID: "u1",
+			Payments: []event.IncomePayment{
+				{Date: day("2024-01-15"), Type: event.IncomeInterest, Gross: dec("10")},
+				{Date: day("2024-03-01"), Type: event.IncomeInterest, Gross: dec("20")}, // after asOf
+			},
+		}},
+	}
+
+	s := BuildIncomeStatements(payload, resolvePeriod(t, payload.ReportPeriod))[0]
+
+	if s.Total.Payments != 1 || !decimalIs(s.Total.Gross.Amount, "10") {
+		t.Errorf("Unexpected total %+v", s.Total)
+	}
+	if len(s.Months) != 3 {
+		t.Errorf("Got %d months, want the whole quarter", len(s.Months))
+	}
+}
+
+// resolvePeriod rapor dönemini testler için sabit bir ana göre çözer
+func resolvePeriod(t *testing.T, p event.ReportPeriod) event.ResolvedPeriod {
+	t.Helper()
+	period, err := p.Resolve(time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Resolve error: %v", err)
+	}
+	return period
+}
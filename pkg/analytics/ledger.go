@@ -0,0 +1,88 @@
+package analytics
+
+import (
+	"sort"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+// LedgerEntry defterdeki tek bir satır: işlem, nakit etkisi ve işlem sonrası bakiye
+type LedgerEntry struct {
+	Transaction event.Transaction
+	CashFlow    event.Money // bakiyeye işaretli etki
+	Balance     event.Money // işlemden sonraki nakit bakiye
+}
+
+// Ledger bir portföyün tarih sırasına dizilmiş işlem defteri. Tutarlar kesindir;
+// yuvarlama yalnızca raporlarda gösterim sırasında yapılır.
+type Ledger struct {
+	PortID         int
+	Name           string
+	UserID         string
+	Currency       event.Currency
+	OpeningBalance event.Money
+	ClosingBalance event.Money
+	Entries        []LedgerEntry
+
+	// Totals işlem türüne göre işaretsiz nakit tutarı toplamları
+	Totals map[event.TransactionType]event.Money
+	// Counts işlem türüne göre işlem sayıları
+	Counts map[event.TransactionType]int
+}
+
+// BuildLedger portföyün işlemlerini tarih sırasına dizer ve yürüyen bakiyeyi hesaplar.
+// Aynı tarihli işlemler gönderildikleri sırayı korur.
+func BuildLedger(p event.PortfolioTransactions) Ledger {
+	transactions := sortedTransactions(p.Transactions)
+	currency := p.Currency.OrDefault()
+
+	ledger := Ledger{
+		PortID:         p.PortID,
+		Name:           p.Name,
+		UserID:         p.UserID,
+		Currency:       currency,
+		OpeningBalance: event.NewMoney(p.OpeningBalance, currency),
+		Entries:        make([]LedgerEntry, 0, len(transactions)),
+		Totals:         make(map[event.TransactionType]event.Money),
+		Counts:         make(map[event.TransactionType]int),
+	}
+
+	balance := p.OpeningBalance
+	totals := make(map[event.TransactionType]event.Decimal)
+	for _, t := range transactions {
+		flow := t.CashFlow()
+		balance = balance.Add(flow)
+		ledger.Entries = append(ledger.Entries, LedgerEntry{
+			Transaction: t,
+			CashFlow:    event.NewMoney(flow, currency),
+			Balance:     event.NewMoney(balance, currency),
+		})
+		totals[t.Type] = totals[t.Type].Add(t.CashAmount())
+		ledger.Counts[t.Type]++
+	}
+	for t, total := range totals {
+		ledger.Totals[t] = event.NewMoney(total, currency)
+	}
+	ledger.ClosingBalance = event.NewMoney(balance, currency)
+	return ledger
+}
+
+// BuildLedgers payload'daki her portföy için bir defter oluşturur
+func BuildLedgers(payload event.TransactionReportPayload) []Ledger {
+	ledgers := make([]Ledger, len(payload.Portfolios))
+	for i, p := range payload.Portfolios {
+		ledgers[i] = BuildLedger(p)
+	}
+	return ledgers
+}
+
+// sortedTransactions işlemlerin tarih sırasına dizilmiş bir kopyasını döndürür.
+// Aynı tarihli işlemler gönderildikleri sırayı korur.
+func sortedTransactions(transactions []event.Transaction) []event.Transaction {
+	sorted := make([]event.Transaction, len(transactions))
+	copy(sorted, transactions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date.Time)
+	})
+	return sorted
+}
@@ -0,0 +1,54 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+func TestBuildLedger(t *testing.T) {
+	day, dec := event.MustParseTimestamp, event.MustParseDecimal
+	p := event.PortfolioTransactions{
+		PortID:         1,
+		Name:           "Growth",
+		UserID:         "u1",
+		Currency:       "EUR",
+		OpeningBalance: dec("100"),
+		// Deliberately out of order; the two trades on 2024-01-03 keep their order
+		Transactions: []event.Transaction{
+			{Date: day("2024-01-03"), Type: event.TransactionBuy, Instrument: "AAPL", Quantity: dec("5"), Price: dec("100")},
+			{Date: day("2024-01-03"), Type: event.TransactionFee, Amount: dec("2.5")},
+			{Date: day("2024-01-01"), Type: event.TransactionDeposit, Amount: dec("1000")},
+			{Date: day("2024-01-05"), Type: event.TransactionSell, Instrument: "AAPL", Quantity: dec("2"), Price: dec("120"), Amount: dec("238")},
+			{Date: day("2024-01-06"), Type: event.TransactionWithdrawal, Amount: dec("50")},
+		},
+	}
+
+	ledger := BuildLedger(p)
+
+	wantTypes := []event.TransactionType{event.TransactionDeposit, event.TransactionBuy, event.TransactionFee, event.TransactionSell, event.TransactionWithdrawal}
+	wantBalances := []string{"1100", "600", "597.5", "835.5", "785.5"}
+	if len(ledger.Entries) != len(wantTypes) {
+		t.Fatalf("Got %d entries, want %d", len(ledger.Entries), len(wantTypes))
+	}
+	for i, e := range ledger.Entries {
+		if e.Transaction.Type != wantTypes[i] || !decimalIs(e.Balance.Amount, wantBalances[i]) || e.Balance.Currency != "EUR" {
+			t.Errorf("Entry %d = %s balance %s; want %s balance %s EUR", i, e.Transaction.Type, e.Balance, wantTypes[i], wantBalances[i])
+		}
+	}
+
+	if ledger.ClosingBalance.String() != "785.5 EUR" {
+		t.Errorf("ClosingBalance = %s; want 785.5 EUR", ledger.ClosingBalance)
+	}
+	// Buy amount falls back to quantity * price; sell uses the explicit amount
+	if !decimalIs(ledger.Totals[event.TransactionBuy].Amount, "500") || !decimalIs(ledger.Totals[event.TransactionSell].Amount, "238") {
+		t.Errorf("Unexpected totals %v", ledger.Totals)
+	}
+	if ledger.Counts[event.TransactionFee] != 1 {
+		t.Errorf("Unexpected counts %v", ledger.Counts)
+	}
+	// The input is not reordered in place
+	if p.Transactions[0].Type != event.TransactionBuy {
+		t.Error("BuildLedger modified the input slice")
+	}
+}
@@ -0,0 +1,256 @@
+package analytics
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+// Period getiri hesaplanan standart dönem
+type Period string
+
+// Standart dönemler
+const (
+	PeriodMTD            Period = "MTD"
+	PeriodQTD            Period = "QTD"
+	PeriodYTD            Period = "YTD"
+	PeriodSinceInception Period = "SI"
+)
+
+// Periods tüm dönemler, raporlardaki sıralarıyla
+var Periods = []Period{PeriodMTD, PeriodQTD, PeriodYTD, PeriodSinceInception}
+
+// daysPerYear yıllıklandırmada kullanılan ortalama yıl uzunluğu (gün)
+const daysPerYear = 365.25
+
+// Label dönemin raporlarda gösterilen adı
+func (p Period) Label() string {
+	switch p {
+	case PeriodMTD:
+		return "Month to date"
+	case PeriodQTD:
+		return "Quarter to date"
+	case PeriodYTD:
+		return "Year to date"
+	case PeriodSinceInception:
+		return "Since inception"
+	}
+	return string(p)
+}
+
+// Start asOf tarihine göre dönemin başladığı günü döndürür. Başlangıç değeri bu
+// günden önceki (ya da bu güne ait) son değerlemedir. Takvim kuralları rapor
+// dönemleriyle aynıdır (bkz. event.ParseNamedPeriod; sınırlar UTC'dir).
+// Since inception için sıfır döner.
+func (p Period) Start(asOf time.Time) time.Time {
+	if p == PeriodSinceInception {
+		return time.Time{}
+	}
+	start, _, err := event.ParseNamedPeriod(string(p), asOf)
+	if err != nil {
+		return time.Time{}
+	}
+	return start
+}
+
+// PeriodReturn bir dönemin zaman ağırlıklı (TWR) ve para ağırlıklı (MWR) getirisi.
+// Getiriler dönem boyunca kümülatiftir; bir yıldan uzun dönemler ayrıca yıllıklandırılır.
+// Tutarlar kesindir; getiriler oran olduğundan kayan noktayla hesaplanır.
+type PeriodReturn struct {
+	Period     Period
+	From, To   time.Time
+	BeginValue event.Money
+	EndValue   event.Money
+	NetFlows   event.Money // dönem içindeki net dış nakit akışı
+
+	// Available dönemde en az iki değerleme noktası varsa true
+	Available bool
+	TWR       float64
+	// MWRAvailable para ağırlıklı getiri çözülebildiyse true
+	MWRAvailable bool
+	MWR          float64
+}
+
+// Years dönemin yıl cinsinden uzunluğu
+func (r PeriodReturn) Years() float64 {
+	return r.To.Sub(r.From).Hours() / 24 / daysPerYear
+}
+
+// Annualize kümülatif bir getiriyi yıllıklandırır. Bir yıldan kısa dönemlerde
+// yıllıklandırma yanıltıcı olduğundan ikinci değer false döner.
+func (r PeriodReturn) Annualize(cumulative float64) (float64, bool) {
+	years := r.Years()
+	if years <= 1 || cumulative <= -1 {
+		return 0, false
+	}
+	return math.Pow(1+cumulative, 1/years) - 1, true
+}
+
+// Performance bir portföyün değer serisi ve dönem getirileri
+type Performance struct {
+	PortID    int
+	Name      string
+	UserID    string
+	Currency  event.Currency
+	AsOf      time.Time         // son değerleme tarihi
+	Series    []event.Valuation // tarih sırasına dizilmiş değerlemeler
+	CashFlows []event.CashFlow  // tarih sırasına dizilmiş dış nakit akışları
+	Returns   []PeriodReturn    // Periods sırasıyla
+}
+
+// BuildPerformance portföyün değerlemelerini ve nakit akışlarını tarih sırasına
+// dizer ve her standart dönem için TWR ve MWR hesaplar
+func BuildPerformance(p event.PortfolioPerformance) Performance {
+	series := make([]event.Valuation, len(p.Valuations))
+	copy(series, p.Valuations)
+	sort.SliceStable(series, func(i, j int) bool { return series[i].Date.Before(series[j].Date.Time) })
+
+	flows := make([]event.CashFlow, len(p.CashFlows))
+	copy(flows, p.CashFlows)
+	sort.SliceStable(flows, func(i, j int) bool { return flows[i].Date.Before(flows[j].Date.Time) })
+
+	perf := Performance{
+		PortID:    p.PortID,
+		Name:      p.Name,
+		UserID:    p.UserID,
+		Currency:  p.Currency.OrDefault(),
+		Series:    series,
+		CashFlows: flows,
+	}
+	if len(series) == 0 {
+		return perf
+	}
+	perf.AsOf = series[len(series)-1].Date.Time
+
+	for _, period := range Periods {
+		perf.Returns = append(perf.Returns, periodReturn(period, series, flows, perf.AsOf, perf.Currency))
+	}
+	return perf
+}
+
+// BuildPerformances payload'daki her portföy için performans hesaplar
+func BuildPerformances(payload event.PerformanceReportPayload) []Performance {
+	performances := make([]Performance, len(payload.Portfolios))
+	for i, p := range payload.Portfolios {
+		performances[i] = BuildPerformance(p)
+	}
+	return performances
+}
+
+// periodReturn dönemin başlangıç değerlemesini bulur ve getirileri hesaplar.
+// Portföy dönem başından sonra açıldıysa dönem ilk değerlemeden başlar.
+func periodReturn(period Period, series []event.Valuation, flows []event.CashFlow, asOf time.Time, currency event.Currency) PeriodReturn {
+	start := 0
+	if boundary := period.Start(asOf); !boundary.IsZero() {
+		for i, v := range series {
+			if v.Date.After(boundary) {
+				break
+			}
+			start = i
+		}
+	}
+	end := len(series) - 1
+	window := series[start:]
+
+	r := PeriodReturn{
+		Period:     period,
+		From:       series[start].Date.Time,
+		To:         series[end].Date.Time,
+		BeginValue: event.NewMoney(series[start].Value, currency),
+		EndValue:   event.NewMoney(series[end].Value, currency),
+		NetFlows:   event.NewMoney(event.Decimal{}, currency),
+	}
+	periodFlows := flowsBetween(flows, r.From, r.To)
+	for _, f := range periodFlows {
+		r.NetFlows.Amount = r.NetFlows.Amount.Add(f.Amount)
+	}
+	if len(window) < 2 {
+		return r
+	}
+
+	r.Available = true
+	r.TWR = timeWeightedReturn(window, flows)
+	r.MWR, r.MWRAvailable = moneyWeightedReturn(r.BeginValue.Amount.Float64(), r.EndValue.Amount.Float64(), r.From, r.To, periodFlows)
+	return r
+}
+
+// flowsBetween (from, to] aralığındaki nakit akışlarını döndürür. Değerleme
+// gününe ait akışların o günün değerlemesine dahil olduğu varsayılır.
+func flowsBetween(flows []event.CashFlow, from, to time.Time) []event.CashFlow {
+	var out []event.CashFlow
+	for _, f := range flows {
+		if f.Date.After(from) && !f.Date.After(to) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// timeWeightedReturn ardışık değerlemeler arasındaki alt dönem getirilerini
+// zincirler. Alt dönem içindeki akışlar Modified Dietz yöntemiyle, alt dönemde
+// kaldıkları süreye göre ağırlıklandırılır.
+func timeWeightedReturn(series []event.Valuation, flows []event.CashFlow) float64 {
+	growth := 1.0
+	for i := 1; i < len(series); i++ {
+		from, to := series[i-1].Date.Time, series[i].Date.Time
+		length := to.Sub(from).Seconds()
+
+		var net, weighted float64
+		for _, f := range flowsBetween(flows, from, to) {
+			amount := f.Amount.Float64()
+			net += amount
+			weighted += amount * to.Sub(f.Date.Time).Seconds() / length
+		}
+
+		begin, end := series[i-1].Value.Float64(), series[i].Value.Float64()
+		denominator := begin + weighted
+		if denominator <= 0 {
+			// Sermayesiz alt dönemin getirisi tanımsızdır; zincire etki etmez
+			continue
+		}
+		growth *= 1 + (end-begin-net)/denominator
+	}
+	return growth - 1
+}
+
+// moneyWeightedReturn dönemin iç verim oranını (IRR) kümülatif getiri olarak
+// hesaplar: begin*m + Σ akış*m^(kalan süre oranı) = end denklemini sağlayan
+// büyüme çarpanı m ikiye bölme yöntemiyle bulunur. Çözüm yoksa false döner.
+func moneyWeightedReturn(begin, end float64, from, to time.Time, flows []event.CashFlow) (float64, bool) {
+	length := to.Sub(from).Seconds()
+	if length <= 0 {
+		return 0, false
+	}
+	exponents := make([]float64, len(flows))
+	amounts := make([]float64, len(flows))
+	for i, f := range flows {
+		exponents[i] = to.Sub(f.Date.Time).Seconds() / length
+		amounts[i] = f.Amount.Float64()
+	}
+	excess := func(m float64) float64 {
+		v := begin * m
+		for i := range flows {
+			v += amounts[i] * math.Pow(m, exponents[i])
+		}
+		return v - end
+	}
+
+	lo, hi := 1e-9, 2.0
+	for excess(hi) < 0 && hi < 1e9 {
+		hi *= 2
+	}
+	if excess(lo) > 0 || excess(hi) < 0 {
+		return 0, false
+	}
+	for i := 0; i < 200 && hi-lo > 1e-12; i++ {
+		mid := (lo + hi) / 2
+		if excess(mid) < 0 {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo+hi)/2 - 1, true
+}
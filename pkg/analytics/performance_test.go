@@ -0,0 +1,129 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+func valuation(date string, value float64) event.Valuation {
+	return event.Valuation{Date: event.MustParseTimestamp(date), Value: event.DecimalFromFloat(value)}
+}
+
+func closeTo(a, b float64) bool { return math.Abs(a-b) < 1e-6 }
+
+func TestPeriodStart(t *testing.T) {
+	asOf := time.Date(2024, time.May, 20, 0, 0, 0, 0, time.UTC)
+	cases := map[Period]string{
+		PeriodMTD: "2024-05-01",
+		PeriodQTD: "2024-04-01",
+		PeriodYTD: "2024-01-01",
+	}
+	for period, want := range cases {
+		if got := period.Start(asOf).Format("2006-01-02"); got != want {
+			t.Errorf("%s start = %s; want %s", period, got, want)
+		}
+	}
+	if !PeriodSinceInception.Start(asOf).IsZero() {
+		t.Error("Since inception start should be zero")
+	}
+
+	// Sınırlar rapor dönemleri gibi UTC takvimine göredir
+	local := time.Date(2024, time.July, 1, 1, 0, 0, 0, time.FixedZone("UTC+3", 3*60*60)) // 2024-06-30T22:00Z
+	if got := PeriodQTD.Start(local); !got.Equal(time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("QTD start for %s = %s; want 2024-04-01 UTC", local.Format(time.RFC3339), got.Format(time.RFC3339))
+	}
+}
+
+func TestBuildPerformance_NoFlows(t *testing.T) {
+	perf := BuildPerformance(event.PortfolioPerformance{
+		PortID: 1,
+		// Deliberately out of order
+		Valuations: []event.Valuation{
+			valuation("2024-06-30", 133.1),
+			valuation("2023-12-31", 100),
+			valuation("2024-03-31", 110),
+			valuation("2024-05-31", 121),
+		},
+	})
+
+	want := map[Period]float64{PeriodMTD: 0.10, PeriodQTD: 0.21, PeriodYTD: 0.331, PeriodSinceInception: 0.331}
+	for _, r := range perf.Returns {
+		if !r.Available || !r.MWRAvailable {
+			t.Fatalf("%s not available", r.Period)
+		}
+		// Without external flows both methods agree
+		if !closeTo(r.TWR, want[r.Period]) || !closeTo(r.MWR, want[r.Period]) {
+			t.Errorf("%s TWR=%.6f MWR=%.6f; want %.6f", r.Period, r.TWR, r.MWR, want[r.Period])
+		}
+	}
+	if perf.Returns[0].From.Format("2006-01-02") != "2024-05-31" {
+		t.Errorf("MTD should start at the prior month-end valuation, got %s", perf.Returns[0].From)
+	}
+}
+
+func TestBuildPerformance_WithFlows(t *testing.T) {
+	perf := BuildPerformance(event.PortfolioPerformance{
+		PortID: 1,
+		Valuations: []event.Valuation{
+			valuation("2024-01-01", 100),
+			valuation("2024-01-11", 210), // includes the contribution of 100 on the same day
+			valuation("2024-01-21", 231),
+		},
+		CashFlows: []event.CashFlow{{Date: event.MustParseTimestamp("2024-01-11"), Amount: event.NewDecimalFromInt(100)}},
+	})
+
+	si := perf.Returns[len(perf.Returns)-1]
+	// Two sub-periods of +10% each
+	if !closeTo(si.TWR, 0.21) {
+		t.Errorf("TWR = %.6f; want 0.21", si.TWR)
+	}
+	// 100*m + 100*sqrt(m) = 231 => m = 1.21
+	if !si.MWRAvailable || !closeTo(si.MWR, 0.21) {
+		t.Errorf("MWR = %.6f; want 0.21", si.MWR)
+	}
+	if si.NetFlows.String() != "100 USD" {
+		t.Errorf("NetFlows = %s; want 100 USD", si.NetFlows)
+	}
+}
+
+func TestBuildPerformance_ShortHistory(t *testing.T) {
+	perf := BuildPerformance(event.PortfolioPerformance{
+		PortID: 1,
+		Valuations: []event.Valuation{
+			valuation("2024-05-15", 100),
+			valuation("2024-06-01", 105),
+		},
+	})
+
+	for _, r := range perf.Returns {
+		switch r.Period {
+		case PeriodMTD:
+			// The only valuation on or before June 1 is the last one
+			if r.Available {
+				t.Errorf("MTD should not be available, got %+v", r)
+			}
+		case PeriodYTD:
+			// Inception after January 1: the period starts at the first valuation
+			if !r.Available || r.From.Format("2006-01-02") != "2024-05-15" || !closeTo(r.TWR, 0.05) {
+				t.Errorf("Unexpected YTD return %+v", r)
+			}
+			if _, ok := r.Annualize(r.TWR); ok {
+				t.Error("Returns shorter than a year should not be annualized")
+			}
+		}
+	}
+}
+
+func TestPeriodReturn_Annualize(t *testing.T) {
+	r := PeriodReturn{
+		From: time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+	got, ok := r.Annualize(0.21)
+	if !ok || math.Abs(got-0.10) > 1e-3 {
+		t.Errorf("Annualize(0.21) over two years = %.4f, %v; want ~0.10", got, ok)
+	}
+}
@@ -0,0 +1,53 @@
+package analytics
+
+import (
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+// PeriodSummary bir dönem raporuna giren portföylerin dönem içindeki hareketleri
+type PeriodSummary struct {
+	Total    int // rapora giren portföyler
+	New      int // dönem içinde oluşturulanlar
+	Updated  int // dönem içinde güncellenen, dönemden önce oluşturulmuş olanlar
+	Excluded int // as-of tarihinde henüz oluşturulmamış olduğu için dışarıda kalanlar
+}
+
+// InPeriod portföyün dönem raporuna girip girmediğini döndürür. Rapor as-of tarihindeki
+// durumu gösterdiğinden, o tarihten sonra oluşturulan portföyler dışarıda kalır.
+// Dönemsiz raporlarda (sıfır değer) tüm portföyler rapora girer.
+func InPeriod(p event.Portfolio, period event.ResolvedPeriod) bool {
+	return period.IsZero() || !p.CreatedAt.After(period.AsOf)
+}
+
+// Add portföyü özete ekler; rapora girmeyen portföyler Excluded olarak sayılır
+func (s *PeriodSummary) Add(p event.Portfolio, period event.ResolvedPeriod) {
+	if !InPeriod(p, period) {
+		s.Excluded++
+		return
+	}
+	s.Total++
+
+	// Başlangıç ya da bitişi olmayan (yalnızca as-of verilen) raporlarda dönem hareketi yoktur
+	if period.IsZero() || (period.Start.IsZero() && period.End.IsZero()) {
+		return
+	}
+	switch {
+	case period.Contains(p.CreatedAt.Time):
+		s.New++
+	case period.Contains(p.LastUpdate.Time) && !p.LastUpdate.After(period.AsOf):
+		s.Updated++
+	}
+}
+
+// FilterPortfolios dönem raporuna giren portföyleri sırasını koruyarak döndürür
+func FilterPortfolios(portfolios []event.Portfolio, period event.ResolvedPeriod) ([]event.Portfolio, PeriodSummary) {
+	var summary PeriodSummary
+	kept := make([]event.Portfolio, 0, len(portfolios))
+	for _, p := range portfolios {
+		summary.Add(p, period)
+		if InPeriod(p, period) {
+			kept = append(kept, p)
+		}
+	}
+	return kept, summary
+}
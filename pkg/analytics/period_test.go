@@ -0,0 +1,37 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+func TestFilterPortfolios(t *testing.T) {
+	day := event.MustParseTimestamp
+	portfolios := []event.Portfolio{
+		{PortID: 1, Name: "Old", CreatedAt: day("2025-01-10"), LastUpdate: day("2025-02-01")},
+		{PortID: 2, Name: "Updated", CreatedAt: day("2025-03-01"), LastUpdate: day("2026-08-15")},
+		{PortID: 3, Name: "New", CreatedAt: day("2026-07-01"), LastUpdate: day("2026-07-02")},
+		{PortID: 4, Name: "Later", CreatedAt: day("2026-10-05"), LastUpdate: day("2026-10-06")},
+	}
+
+	period, err := event.ReportPeriod{Period: "Q3-2026"}.Resolve(time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Resolve error: %v", err)
+	}
+	kept, summary := FilterPortfolios(portfolios, period)
+
+	if len(kept) != 3 || kept[0].PortID != 1 || kept[2].PortID != 3 {
+		t.Errorf("Unexpected portfolios in period: %+v", kept)
+	}
+	if want := (PeriodSummary{Total: 3, New: 1, Updated: 1, Excluded: 1}); summary != want {
+		t.Errorf("Summary = %+v; want %+v", summary, want)
+	}
+
+	// Dönemsiz raporda tüm portföyler yer alır ve dönem hareketi sayılmaz
+	kept, summary = FilterPortfolios(portfolios, event.ResolvedPeriod{})
+	if len(kept) != len(portfolios) || summary != (PeriodSummary{Total: 4}) {
+		t.Errorf("Unexpected result without period: %d portfolios, %+v", len(kept), summary)
+	}
+}
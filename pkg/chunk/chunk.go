@@ -0,0 +1,149 @@
+package chunk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/burakmike/report-export-service/pkg/job"
+)
+
+// DefaultTimeout bir isteğin eksik parçalarının en fazla ne kadar bekletileceği
+const DefaultTimeout = 30 * time.Minute
+
+// DefaultExpireInterval zaman aşımına uğrayan isteklerin ne sıklıkla silineceği
+const DefaultExpireInterval = time.Minute
+
+// ErrInvalidChunk parça bilgisi geçersiz olduğunda ya da parçalar birbiriyle
+// tutarsız olduğunda döner; bu hatalar yeniden denemeyle düzelmez
+var ErrInvalidChunk = errors.New("invalid chunk")
+
+// Store gelen parçaları istek tamamlanana kadar saklayan arayüz
+type Store interface {
+	// Save parçayı saklar; aynı sıradaki parça tekrar gelirse (yeniden teslim) yok sayılır
+	Save(ctx context.Context, part event.BaseEvent) error
+	// Count isteğin saklanan parça sayısını döndürür
+	Count(ctx context.Context, requestID string) (int, error)
+	// Load isteğin saklanan tüm parçalarını döndürür
+	Load(ctx context.Context, requestID string) ([]event.BaseEvent, error)
+	// Delete isteğin tüm parçalarını siler
+	Delete(ctx context.Context, requestID string) error
+	// DeleteExpired ilk parçası before'dan önce gelen isteklerin parçalarını siler
+	// ve silinen istekleri döndürür
+	DeleteExpired(ctx context.Context, before time.Time) ([]ExpiredRequest, error)
+}
+
+// ExpiredRequest zaman aşımı nedeniyle silinen, tamamlanmamış bir parçalı istek
+type ExpiredRequest struct {
+	RequestID string
+	EventType event.EventType
+	Received  int // silinen parça sayısı
+	Total     int // beklenen parça sayısı
+}
+
+// Assembler parçalı mesajları Store'da biriktirir ve tüm parçalar geldiğinde
+// bölünen event'i yeniden oluşturur. Timeout süresinde tamamlanmayan istekler
+// Expire (ya da arka planda Run) ile silinir.
+type Assembler struct {
+	Store   Store
+	Timeout time.Duration
+	// Jobs verilmişse süresi dolan istekler reddedildi olarak kaydedilir; böylece
+	// yayıncı isteğin neden işlenmediğini iş durumundan öğrenebilir
+	Jobs job.Recorder
+
+	now func() time.Time
+}
+
+// NewAssembler yeni bir parça birleştirici oluşturur; timeout 0 ise DefaultTimeout kullanılır
+func NewAssembler(store Store, timeout time.Duration) *Assembler {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Assembler{Store: store, Timeout: timeout, now: time.Now}
+}
+
+// Add parçayı saklar. İsteğin tüm parçaları geldiyse birleştirilmiş event'i ve true
+// döndürür; parçalar, event işlendikten sonra Release ile silinmelidir.
+func (a *Assembler) Add(ctx context.Context, part event.BaseEvent) (event.BaseEvent, bool, error) {
+	if part.Chunk == nil {
+		return event.BaseEvent{}, false, fmt.Errorf("%w: event %s has no chunk info", ErrInvalidChunk, part.EventID)
+	}
+	if err := part.Chunk.Validate(); err != nil {
+		return event.BaseEvent{}, false, fmt.Errorf("%w: %w", ErrInvalidChunk, err)
+	}
+
+	requestID := part.Chunk.RequestID
+	if err := a.Store.Save(ctx, part); err != nil {
+		return event.BaseEvent{}, false, err
+	}
+	count, err := a.Store.Count(ctx, requestID)
+	if err != nil {
+		return event.BaseEvent{}, false, err
+	}
+	if count < part.Chunk.Total {
+		return event.BaseEvent{}, false, nil
+	}
+
+	parts, err := a.Store.Load(ctx, requestID)
+	if err != nil {
+		return event.BaseEvent{}, false, err
+	}
+	evt, err := event.JoinChunks(parts)
+	if err != nil {
+		// Tutarsız parçalar hiçbir zaman birleştirilemez; bekletilmeleri anlamsızdır
+		if delErr := a.Store.Delete(ctx, requestID); delErr != nil {
+			log.Printf("Warning: failed to discard chunks of request %s: %v", requestID, delErr)
+		}
+		return event.BaseEvent{}, false, fmt.Errorf("%w: %w", ErrInvalidChunk, err)
+	}
+	return evt, true, nil
+}
+
+// Release birleştirilmiş isteğin parçalarını siler
+func (a *Assembler) Release(ctx context.Context, requestID string) error {
+	return a.Store.Delete(ctx, requestID)
+}
+
+// Run ctx iptal edilene kadar her interval'de bir Expire çağırır; interval 0 ise
+// DefaultExpireInterval kullanılır. Silme tüm tabloyu taradığından her parçada değil
+// bu döngüde yapılır.
+func (a *Assembler) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultExpireInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			a.Expire(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Expire zaman aşımına uğrayan isteklerin parçalarını siler. Hatalar yalnızca
+// loglanır; bir sonraki çağrıda yeniden denenir.
+func (a *Assembler) Expire(ctx context.Context) {
+	expired, err := a.Store.DeleteExpired(ctx, a.now().Add(-a.Timeout))
+	if err != nil {
+		log.Printf("Warning: failed to discard expired chunks: %v", err)
+		return
+	}
+	for _, req := range expired {
+		log.Printf("Discarded incomplete chunked request %s after %s: %d of %d chunks received", req.RequestID, a.Timeout, req.Received, req.Total)
+		if a.Jobs == nil {
+			continue
+		}
+		// İstek kimliği bölünen event'in kimliğidir; iş kaydı bu kimlikle tutulur
+		evt := event.BaseEvent{EventID: req.RequestID, EventType: req.EventType}
+		reason := fmt.Errorf("incomplete: %d of %d chunks received within %s", req.Received, req.Total, a.Timeout)
+		if err := a.Jobs.Record(ctx, evt, job.StatusRejected, reason); err != nil {
+			log.Printf("Warning: %v (request %s)", err, req.RequestID)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package chunk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/burakmike/report-export-service/pkg/job"
+)
+
+func splitSample(t *testing.T, size int) (event.BaseEvent, []event.BaseEvent) {
+	t.Helper()
+	evt, err := event.NewPortfolioReportEvent(event.CreateSamplePortfolios())
+	if err != nil {
+		t.Fatalf("NewPortfolioReportEvent error: %v", err)
+	}
+	parts, err := event.SplitEvent(evt, size)
+	if err != nil {
+		t.Fatalf("SplitEvent error: %v", err)
+	}
+	if len(parts) < 3 {
+		t.Fatalf("Expected at least 3 chunks, got %d", len(parts))
+	}
+	return evt, parts
+}
+
+func TestAssembler_Add(t *testing.T) {
+	ctx := context.Background()
+	evt, parts := splitSample(t, 100)
+	a := NewAssembler(NewMemoryStore(), time.Minute)
+
+	// Son parça önce, ardından tekrar teslim edilen bir parça
+	order := append([]event.BaseEvent{parts[len(parts)-1], parts[0], parts[0]}, parts[1:len(parts)-1]...)
+	for i, part := range order {
+		joined, ok, err := a.Add(ctx, part)
+		if err != nil {
+			t.Fatalf("Add(%d) error: %v", i, err)
+		}
+		if last := i == len(order)-1; ok != last {
+			t.Fatalf("Add(%d) complete = %v; want %v", i, ok, last)
+		}
+		if ok && string(joined.Payload) != string(evt.Payload) {
+			t.Errorf("Joined payload differs from the original")
+		}
+	}
+
+	// Parçalar Release çağrılana kadar saklanır
+	if n, _ := a.Store.Count(ctx, evt.EventID); n != len(parts) {
+		t.Errorf("Count before release = %d; want %d", n, len(parts))
+	}
+	if err := a.Release(ctx, evt.EventID); err != nil {
+		t.Fatalf("Release error: %v", err)
+	}
+	if n, _ := a.Store.Count(ctx, evt.EventID); n != 0 {
+		t.Errorf("Count after release = %d; want 0", n)
+	}
+}
+
+func TestAssembler_InvalidChunk(t *testing.T) {
+	ctx := context.Background()
+	_, parts := splitSample(t, 100)
+	a := NewAssembler(NewMemoryStore(), time.Minute)
+
+	bad := parts[0]
+	bad.Chunk = &event.ChunkInfo{RequestID: "r1", Seq: 0, Total: 2}
+	if _, _, err := a.Add(ctx, bad); !errors.Is(err, ErrInvalidChunk) {
+		t.Errorf("Expected ErrInvalidChunk for seq 0, got %v", err)
+	}
+
+	// Toplamı farklı bildiren parçalar birleştirilemez ve silinir
+	inconsistent := append([]event.BaseEvent{}, parts...)
+	info := *inconsistent[0].Chunk
+	info.Total = len(parts) + 1
+	inconsistent[0].Chunk = &info
+	for i, part := range inconsistent {
+		_, ok, err := a.Add(ctx, part)
+		if i < len(parts)-1 {
+			if err != nil || ok {
+				t.Fatalf("Add(%d) = %v, %v; want buffered", i, ok, err)
+			}
+		} else if !errors.Is(err, ErrInvalidChunk) {
+			t.Errorf("Expected ErrInvalidChunk for inconsistent totals, got %v", err)
+		}
+	}
+	if n, _ := a.Store.Count(ctx, parts[0].Chunk.RequestID); n != 0 {
+		t.Errorf("Expected inconsistent chunks to be discarded, %d left", n)
+	}
+}
+
+func TestAssembler_Timeout(t *testing.T) {
+	ctx := context.Background()
+	_, parts := splitSample(t, 100)
+	a := NewAssembler(NewMemoryStore(), time.Minute)
+
+	if _, _, err := a.Add(ctx, parts[0]); err != nil {
+		t.Fatalf("Add error: %v", err)
+	}
+
+	// Parça eklemek süresi dolan istekleri silmez; bu iş Expire'a aittir
+	a.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	if _, ok, err := a.Add(ctx, parts[1]); err != nil || ok {
+		t.Fatalf("Add after timeout = %v, %v", ok, err)
+	}
+	if n, _ := a.Store.Count(ctx, parts[0].Chunk.RequestID); n != 2 {
+		t.Errorf("Count before Expire = %d; want 2", n)
+	}
+
+	jobs := &stubRecorder{}
+	a.Jobs = jobs
+	a.Expire(ctx)
+	if n, _ := a.Store.Count(ctx, parts[0].Chunk.RequestID); n != 0 {
+		t.Errorf("Count after Expire = %d; want 0", n)
+	}
+
+	// Yayıncı düşürülen isteği iş durumundan öğrenir
+	if len(jobs.records) != 1 {
+		t.Fatalf("Got %d job records; want 1", len(jobs.records))
+	}
+	rec := jobs.records[0]
+	wantErr := fmt.Sprintf("incomplete: 2 of %d chunks received within 1m0s", parts[0].Chunk.Total)
+	if rec.evt.EventID != parts[0].Chunk.RequestID || rec.evt.EventType != parts[0].EventType ||
+		rec.status != job.StatusRejected || rec.err == nil || rec.err.Error() != wantErr {
+		t.Errorf("Unexpected job record %+v (%v); want rejected with %q", rec, rec.err, wantErr)
+	}
+}
+
+// stubRecorder kaydedilen iş durumlarını saklar
+type stubRecorder struct {
+	records []jobRecord
+}
+
+type jobRecord struct {
+	evt    event.BaseEvent
+	status job.Status
+	err    error
+}
+
+func (r *stubRecorder) Record(ctx context.Context, evt event.BaseEvent, status job.Status, err error) error {
+	r.records = append(r.records, jobRecord{evt, status, err})
+	return nil
+}
+
+func TestAssembler_Run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	_, parts := splitSample(t, 100)
+	a := NewAssembler(NewMemoryStore(), time.Minute)
+	if _, _, err := a.Add(ctx, parts[0]); err != nil {
+		t.Fatalf("Add error: %v", err)
+	}
+	a.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+
+	done := make(chan struct{})
+	go func() {
+		a.Run(ctx, time.Millisecond)
+		close(done)
+	}()
+	deadline := time.Now().Add(time.Second)
+	for {
+		if n, _ := a.Store.Count(ctx, parts[0].Chunk.RequestID); n == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Run did not discard the expired request")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+}
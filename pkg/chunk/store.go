@@ -0,0 +1,241 @@
+package chunk
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+// CreateTableQuery bekleyen parçaların tablosunu oluşturur. Parçalar servis yeniden
+// başlasa da korunur.
+const CreateTableQuery = `
+	CREATE TABLE IF NOT EXISTS report_chunks (
+		request_id TEXT NOT NULL,
+		seq INTEGER NOT NULL,
+		total INTEGER NOT NULL,
+		event_type TEXT NOT NULL,
+		data BYTEA NOT NULL,
+		received_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+		PRIMARY KEY (request_id, seq)
+	);`
+
+// Parça sorguları
+const (
+	insertQuery = `
+	INSERT INTO report_chunks(request_id, seq, total, event_type, data)
+	VALUES($1, $2, $3, $4, $5)
+	ON CONFLICT (request_id, seq) DO NOTHING`
+
+	countQuery = `SELECT count(*) FROM report_chunks WHERE request_id = $1`
+
+	loadQuery = `SELECT data FROM report_chunks WHERE request_id = $1 ORDER BY seq`
+
+	deleteQuery = `DELETE FROM report_chunks WHERE request_id = $1`
+
+	deleteExpiredQuery = `
+	DELETE FROM report_chunks WHERE request_id IN (
+		SELECT request_id FROM report_chunks GROUP BY request_id HAVING min(received_at) < $1
+	)
+	RETURNING request_id, event_type, total`
+)
+
+// SQLStore parçaları PostgreSQL'deki report_chunks tablosunda saklar
+type SQLStore struct {
+	DB *sql.DB
+}
+
+// NewSQLStore yeni bir SQL parça deposu oluşturur
+func NewSQLStore(db *sql.DB) *SQLStore {
+	return &SQLStore{DB: db}
+}
+
+// Save parçayı zarfıyla birlikte JSON olarak saklar
+func (s *SQLStore) Save(ctx context.Context, part event.BaseEvent) error {
+	data, err := json.Marshal(part)
+	if err != nil {
+		return fmt.Errorf("failed to encode chunk: %w", err)
+	}
+	_, err = s.DB.ExecContext(ctx, insertQuery,
+		part.Chunk.RequestID, part.Chunk.Seq, part.Chunk.Total, string(part.EventType), data)
+	if err != nil {
+		return fmt.Errorf("failed to save chunk: %w", err)
+	}
+	return nil
+}
+
+// Count isteğin saklanan parça sayısını döndürür
+func (s *SQLStore) Count(ctx context.Context, requestID string) (int, error) {
+	var count int
+	if err := s.DB.QueryRowContext(ctx, countQuery, requestID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count chunks: %w", err)
+	}
+	return count, nil
+}
+
+// Load isteğin parçalarını sırayla döndürür
+func (s *SQLStore) Load(ctx context.Context, requestID string) ([]event.BaseEvent, error) {
+	rows, err := s.DB.QueryContext(ctx, loadQuery, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chunks: %w", err)
+	}
+	defer rows.Close()
+
+	var parts []event.BaseEvent
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to scan chunk: %w", err)
+		}
+		var part event.BaseEvent
+		if err := json.Unmarshal(data, &part); err != nil {
+			return nil, fmt.Errorf("failed to decode chunk: %w", err)
+		}
+		parts = append(parts, part)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read chunks: %w", err)
+	}
+	return parts, nil
+}
+
+// Delete isteğin parçalarını siler
+func (s *SQLStore) Delete(ctx context.Context, requestID string) error {
+	if _, err := s.DB.ExecContext(ctx, deleteQuery, requestID); err != nil {
+		return fmt.Errorf("failed to delete chunks: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired ilk parçası before'dan önce gelen isteklerin parçalarını siler
+func (s *SQLStore) DeleteExpired(ctx context.Context, before time.Time) ([]ExpiredRequest, error) {
+	rows, err := s.DB.QueryContext(ctx, deleteExpiredQuery, before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete expired chunks: %w", err)
+	}
+	defer rows.Close()
+
+	index := make(map[string]int)
+	var expired []ExpiredRequest
+	for rows.Next() {
+		var requestID, eventType string
+		var total int
+		if err := rows.Scan(&requestID, &eventType, &total); err != nil {
+			return nil, fmt.Errorf("failed to scan expired chunk: %w", err)
+		}
+		i, ok := index[requestID]
+		if !ok {
+			i = len(expired)
+			index[requestID] = i
+			expired = append(expired, ExpiredRequest{RequestID: requestID, EventType: event.EventType(eventType)})
+		}
+		expired[i].Received++
+		if total > expired[i].Total {
+			expired[i].Total = total
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read expired chunks: %w", err)
+	}
+	return expired, nil
+}
+
+// MemoryStore parçaları bellekte saklar. Servis yeniden başladığında parçalar
+// kaybolur; veritabanı olmadan çalışırken ve testlerde kullanılır.
+type MemoryStore struct {
+	mu       sync.Mutex
+	requests map[string]*memoryRequest
+}
+
+// memoryRequest bir isteğin bellekte bekleyen parçaları
+type memoryRequest struct {
+	receivedAt time.Time
+	parts      map[int]event.BaseEvent
+}
+
+// NewMemoryStore yeni bir bellek içi parça deposu oluşturur
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{requests: make(map[string]*memoryRequest)}
+}
+
+// Save parçayı saklar; aynı sıradaki parça tekrar gelirse yok sayılır
+func (s *MemoryStore) Save(ctx context.Context, part event.BaseEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	req, ok := s.requests[part.Chunk.RequestID]
+	if !ok {
+		req = &memoryRequest{receivedAt: time.Now(), parts: make(map[int]event.BaseEvent)}
+		s.requests[part.Chunk.RequestID] = req
+	}
+	if _, dup := req.parts[part.Chunk.Seq]; !dup {
+		req.parts[part.Chunk.Seq] = part
+	}
+	return nil
+}
+
+// Count isteğin saklanan parça sayısını döndürür
+func (s *MemoryStore) Count(ctx context.Context, requestID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req, ok := s.requests[requestID]; ok {
+		return len(req.parts), nil
+	}
+	return 0, nil
+}
+
+// Load isteğin parçalarını sırayla döndürür
+func (s *MemoryStore) Load(ctx context.Context, requestID string) ([]event.BaseEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	req, ok := s.requests[requestID]
+	if !ok {
+		return nil, nil
+	}
+	parts := make([]event.BaseEvent, 0, len(req.parts))
+	for _, part := range req.parts {
+		parts = append(parts, part)
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].Chunk.Seq < parts[j].Chunk.Seq })
+	return parts, nil
+}
+
+// Delete isteğin parçalarını siler
+func (s *MemoryStore) Delete(ctx context.Context, requestID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.requests, requestID)
+	return nil
+}
+
+// DeleteExpired ilk parçası before'dan önce gelen isteklerin parçalarını siler
+func (s *MemoryStore) DeleteExpired(ctx context.Context, before time.Time) ([]ExpiredRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var expired []ExpiredRequest
+	for requestID, req := range s.requests {
+		if !req.receivedAt.Before(before) {
+			continue
+		}
+		e := ExpiredRequest{RequestID: requestID, Received: len(req.parts)}
+		for _, part := range req.parts {
+			e.EventType = part.EventType
+			if part.Chunk.Total > e.Total {
+				e.Total = part.Chunk.Total
+			}
+		}
+		expired = append(expired, e)
+		delete(s.requests, requestID)
+	}
+	sort.Slice(expired, func(i, j int) bool { return expired[i].RequestID < expired[j].RequestID })
+	return expired, nil
+}
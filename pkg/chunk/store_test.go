@@ -0,0 +1,69 @@
+package chunk
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+func TestSQLStore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	_, parts := splitSample(t, 100)
+	part := parts[1]
+	data, _ := json.Marshal(part)
+	store := NewSQLStore(db)
+
+	mock.ExpectExec("INSERT INTO report_chunks").
+		WithArgs(part.Chunk.RequestID, 2, part.Chunk.Total, "portfolio.report", data).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery("SELECT count").WithArgs(part.Chunk.RequestID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("SELECT data FROM report_chunks").WithArgs(part.Chunk.RequestID).
+		WillReturnRows(sqlmock.NewRows([]string{"data"}).AddRow(data))
+	mock.ExpectQuery("DELETE FROM report_chunks WHERE request_id IN").
+		WillReturnRows(sqlmock.NewRows([]string{"request_id", "event_type", "total"}).
+			AddRow("r1", "portfolio.report", 3).AddRow("r1", "portfolio.report", 3).AddRow("r2", "income.report", 4))
+	mock.ExpectExec("DELETE FROM report_chunks WHERE request_id =").WithArgs(part.Chunk.RequestID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := store.Save(ctx, part); err != nil {
+		t.Fatalf("Save error: %v", err)
+	}
+	if n, err := store.Count(ctx, part.Chunk.RequestID); err != nil || n != 1 {
+		t.Errorf("Count = %d, %v; want 1", n, err)
+	}
+	loaded, err := store.Load(ctx, part.Chunk.RequestID)
+	if err != nil {
+		t.Fatalf("Load error: %v", err)
+	}
+	if len(loaded) != 1 || loaded[0].Chunk == nil || loaded[0].Chunk.Seq != 2 || string(loaded[0].Payload) != string(part.Payload) {
+		t.Errorf("Unexpected loaded chunks: %+v", loaded)
+	}
+	expired, err := store.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("DeleteExpired error: %v", err)
+	}
+	want := []ExpiredRequest{
+		{RequestID: "r1", EventType: event.PortfolioReport, Received: 2, Total: 3},
+		{RequestID: "r2", EventType: event.IncomeReport, Received: 1, Total: 4},
+	}
+	if len(expired) != len(want) || expired[0] != want[0] || expired[1] != want[1] {
+		t.Errorf("DeleteExpired = %+v; want %+v", expired, want)
+	}
+	if err := store.Delete(ctx, part.Chunk.RequestID); err != nil {
+		t.Fatalf("Delete error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("DB expectations not met: %v", err)
+	}
+}
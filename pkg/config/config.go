@@ -2,6 +2,8 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"time"
 )
 
 // Config struct to hold RabbitMQ connection parameters
@@ -11,6 +13,7 @@ type Config struct {
 	RabbitMQUser     string
 	RabbitMQPassword string
 	RabbitMQVHost    string
+	EventFormats     string // Routing key başına mesaj formatı, ör. "portfolio.report=cloudevents-binary"
 
 	// Database configuration
 	DBHost           string
@@ -18,6 +21,15 @@ type Config struct {
 	DBUser           string
 	DBPassword       string
 	DBName           string
+
+	// Report generation configuration
+	StreamThresholdBytes int           // Bu boyutu aşan payload'lar akış yoluyla işlenir (0: kapalı)
+	PDFVolumeRows        int           // Akış yolunda bir PDF cildindeki en fazla satır sayısı
+	SigningKeyPath       string        // Raporları imzalamak için PEM özel anahtar yolu (boş: imzalama kapalı)
+	EmbedSourceData      bool          // Kaynak verileri PDF'e ek olarak göm
+	SchemaHTTPAddr       string        // Event şemalarının sunulduğu HTTP adresi, ör. ":8081" (boş: kapalı)
+	ChunkTimeout         time.Duration // Parçalı bir isteğin eksik parçalarının en fazla bekletileceği süre
+	MaxDecompressedBytes int           // Sıkıştırılmış bir mesaj gövdesinin açıldıktan sonraki en fazla boyutu
 }
 
 // LoadConfigFromEnv loads configuration from environment variables
@@ -28,6 +40,7 @@ func LoadConfigFromEnv() Config {
 		RabbitMQUser:     getEnv("RABBITMQ_USER", "guest"),
 		RabbitMQPassword: getEnv("RABBITMQ_PASSWORD", "guest"),
 		RabbitMQVHost:    getEnv("RABBITMQ_VHOST", "/"),
+		EventFormats:     getEnv("EVENT_FORMATS", ""),
 
 		// Load database configuration
 		DBHost:           getEnv("DB_HOST", "localhost"),
@@ -35,6 +48,15 @@ func LoadConfigFromEnv() Config {
 		DBUser:           getEnv("DB_USER", "postgres"),
 		DBPassword:       getEnv("DB_PASSWORD", "postgres"),
 		DBName:           getEnv("DB_NAME", "reportdb"),
+
+		// Load report generation configuration
+		StreamThresholdBytes: getEnvInt("REPORT_STREAM_THRESHOLD_BYTES", 8<<20),
+		PDFVolumeRows:        getEnvInt("REPORT_PDF_VOLUME_ROWS", 10000),
+		SigningKeyPath:       getEnv("REPORT_SIGNING_KEY", ""),
+		EmbedSourceData:      getEnvBool("REPORT_EMBED_SOURCE_DATA", false),
+		SchemaHTTPAddr:       getEnv("SCHEMA_HTTP_ADDR", ""),
+		ChunkTimeout:         getEnvDuration("REPORT_CHUNK_TIMEOUT", 30*time.Minute),
+		MaxDecompressedBytes: getEnvInt("REPORT_MAX_DECOMPRESSED_BYTES", 256<<20),
 	}
 }
 
@@ -44,4 +66,37 @@ func getEnv(key, defaultValue string) string {
 		return value
 	}
 	return defaultValue
-} 
\ No newline at end of file
+} 
+
+// getEnvInt retrieves an integer environment variable or returns the default value
+// when the variable is unset or not a valid integer
+func getEnvInt(key string, defaultValue int) int {
+	if value, exists := os.LookupEnv(key); exists {
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	}
+	return defaultValue
+}
+
+// getEnvBool retrieves a boolean environment variable ("true", "1", ...) or returns
+// the default value when the variable is unset or not a valid boolean
+func getEnvBool(key string, defaultValue bool) bool {
+	if value, exists := os.LookupEnv(key); exists {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return defaultValue
+}
+
+// getEnvDuration retrieves a duration environment variable ("90s", "30m", ...) or returns
+// the default value when the variable is unset or not a valid duration
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 func TestLoadConfigFromEnv_Defaults(t *testing.T) {
@@ -10,6 +11,8 @@ func TestLoadConfigFromEnv_Defaults(t *testing.T) {
 	keys := []string{
 		"RABBITMQ_HOST", "RABBITMQ_PORT", "RABBITMQ_USER", "RABBITMQ_PASSWORD", "RABBITMQ_VHOST",
 		"DB_HOST", "DB_PORT", "DB_USER", "DB_PASSWORD", "DB_NAME",
+		"REPORT_STREAM_THRESHOLD_BYTES", "REPORT_PDF_VOLUME_ROWS", "REPORT_EMBED_SOURCE_DATA",
+		"SCHEMA_HTTP_ADDR", "REPORT_CHUNK_TIMEOUT", "REPORT_MAX_DECOMPRESSED_BYTES",
 	}
 	for _, k := range keys {
 		os.Unsetenv(k)
@@ -26,6 +29,21 @@ func TestLoadConfigFromEnv_Defaults(t *testing.T) {
 	if got, want := cfg.DBName, "reportdb"; got != want {
 		t.Errorf("DBName default = %q; want %q", got, want)
 	}
+	if got, want := cfg.PDFVolumeRows, 10000; got != want {
+		t.Errorf("PDFVolumeRows default = %d; want %d", got, want)
+	}
+	if cfg.EmbedSourceData {
+		t.Errorf("EmbedSourceData default = true; want false")
+	}
+	if cfg.SchemaHTTPAddr != "" {
+		t.Errorf("SchemaHTTPAddr default = %q; want empty", cfg.SchemaHTTPAddr)
+	}
+	if got, want := cfg.ChunkTimeout, 30*time.Minute; got != want {
+		t.Errorf("ChunkTimeout default = %s; want %s", got, want)
+	}
+	if got, want := cfg.MaxDecompressedBytes, 256<<20; got != want {
+		t.Errorf("MaxDecompressedBytes default = %d; want %d", got, want)
+	}
 }
 
 func TestLoadConfigFromEnv_Custom(t *testing.T) {
@@ -36,6 +54,14 @@ func TestLoadConfigFromEnv_Custom(t *testing.T) {
 	defer os.Unsetenv("RABBITMQ_HOST")
 	os.Setenv("DB_NAME", dbname)
 	defer os.Unsetenv("DB_NAME")
+	os.Setenv("REPORT_STREAM_THRESHOLD_BYTES", "1024")
+	defer os.Unsetenv("REPORT_STREAM_THRESHOLD_BYTES")
+	os.Setenv("REPORT_PDF_VOLUME_ROWS", "not-a-number")
+	defer os.Unsetenv("REPORT_PDF_VOLUME_ROWS")
+	os.Setenv("REPORT_EMBED_SOURCE_DATA", "true")
+	defer os.Unsetenv("REPORT_EMBED_SOURCE_DATA")
+	os.Setenv("REPORT_CHUNK_TIMEOUT", "90s")
+	defer os.Unsetenv("REPORT_CHUNK_TIMEOUT")
 
 	cfg := LoadConfigFromEnv()
 	if got, want := cfg.RabbitMQHost, domain; got != want {
@@ -44,4 +70,17 @@ func TestLoadConfigFromEnv_Custom(t *testing.T) {
 	if got, want := cfg.DBName, dbname; got != want {
 		t.Errorf("DBName = %q; want %q", got, want)
 	}
+	if got, want := cfg.StreamThresholdBytes, 1024; got != want {
+		t.Errorf("StreamThresholdBytes = %d; want %d", got, want)
+	}
+	if got, want := cfg.ChunkTimeout, 90*time.Second; got != want {
+		t.Errorf("ChunkTimeout = %s; want %s", got, want)
+	}
+	// Invalid integers fall back to the default
+	if got, want := cfg.PDFVolumeRows, 10000; got != want {
+		t.Errorf("PDFVolumeRows = %d; want %d", got, want)
+	}
+	if !cfg.EmbedSourceData {
+		t.Errorf("EmbedSourceData = false; want true")
+	}
 } 
\ No newline at end of file
@@ -0,0 +1,146 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ChunkInfo parçalara bölünmüş bir event'in mesajlarından birini tanımlar. Her parçanın
+// payload'ı, bölünen event'in JSON payload'ından bir kesiti içeren bir JSON metnidir.
+type ChunkInfo struct {
+	RequestID string `json:"request_id" jsonschema:"minLength=1"` // Bölünen event'in event_id'si, tüm parçalarda aynı
+	Seq       int    `json:"seq" jsonschema:"minimum=1"`          // Parçanın sırası (1'den başlar)
+	Total     int    `json:"total" jsonschema:"minimum=1"`        // Toplam parça sayısı
+}
+
+// Validate parça bilgisini doğrular
+func (c ChunkInfo) Validate() error {
+	var errs ValidationErrors
+	add := func(path, rule string, value interface{}, message string) {
+		errs = append(errs, FieldError{Path: path, Rule: rule, Value: value, Message: message})
+	}
+
+	if strings.TrimSpace(c.RequestID) == "" {
+		add("chunk.request_id", RuleRequired, c.RequestID, "must not be blank")
+	}
+	if c.Total < 1 {
+		add("chunk.total", RuleMin, c.Total, "must be a positive integer")
+	}
+	if c.Seq < 1 {
+		add("chunk.seq", RuleMin, c.Seq, "must be a positive integer")
+	} else if c.Total >= 1 && c.Seq > c.Total {
+		add("chunk.seq", RuleMax, c.Seq, fmt.Sprintf("must not exceed chunk.total (%d)", c.Total))
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// SplitEvent payload'ı maxPayloadBytes'ı aşan bir event'i, payload'ı en fazla bu kadar
+// byte içeren parçalara böler. Parçalar zarfı (tip, korelasyon, istek sahibi) korur,
+// kendi event_id'lerini alır ve chunk.request_id olarak event'in kimliğini taşır.
+// Payload sınırı aşmıyorsa event tek başına döner. Kesitler UTF-8 karakterlerini bölmez.
+// Parçalar ancak event_id ile birleştirilebildiğinden event_id'si olmayan event'ler reddedilir.
+func SplitEvent(evt BaseEvent, maxPayloadBytes int) ([]BaseEvent, error) {
+	if evt.EventID == "" {
+		return nil, fmt.Errorf("cannot split event without event_id")
+	}
+	if maxPayloadBytes <= 0 {
+		return nil, fmt.Errorf("invalid chunk size %d", maxPayloadBytes)
+	}
+	if len(evt.Payload) <= maxPayloadBytes {
+		return []BaseEvent{evt}, nil
+	}
+
+	var fragments []string
+	for rest := evt.Payload; len(rest) > 0; {
+		cut := maxPayloadBytes
+		if cut >= len(rest) {
+			cut = len(rest)
+		} else {
+			for cut > 0 && !utf8.RuneStart(rest[cut]) {
+				cut--
+			}
+			if cut == 0 {
+				return nil, fmt.Errorf("invalid chunk size %d: smaller than a character", maxPayloadBytes)
+			}
+		}
+		fragments = append(fragments, string(rest[:cut]))
+		rest = rest[cut:]
+	}
+
+	parts := make([]BaseEvent, len(fragments))
+	for i, fragment := range fragments {
+		data, err := json.Marshal(fragment)
+		if err != nil {
+			return nil, fmt.Errorf("payload serialization error: %w", err)
+		}
+		part := evt
+		part.EventID = NewEventID()
+		part.Chunk = &ChunkInfo{RequestID: evt.EventID, Seq: i + 1, Total: len(fragments)}
+		part.Payload = data
+		parts[i] = part
+	}
+	return parts, nil
+}
+
+// JoinChunks bir isteğin tüm parçalarını sırayla birleştirerek bölünen event'i yeniden
+// oluşturur. Zarf ilk parçadan alınır; event_id istek kimliğidir.
+func JoinChunks(parts []BaseEvent) (BaseEvent, error) {
+	if len(parts) == 0 {
+		return BaseEvent{}, fmt.Errorf("no chunks to join")
+	}
+	sorted := make([]BaseEvent, len(parts))
+	copy(sorted, parts)
+	for _, part := range sorted {
+		if part.Chunk == nil {
+			return BaseEvent{}, fmt.Errorf("event %s is not a chunk", part.EventID)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Chunk.Seq < sorted[j].Chunk.Seq })
+
+	first := sorted[0].Chunk
+	if len(sorted) != first.Total {
+		return BaseEvent{}, fmt.Errorf("request %s has %d of %d chunks", first.RequestID, len(sorted), first.Total)
+	}
+
+	var payload strings.Builder
+	for i, part := range sorted {
+		c := part.Chunk
+		switch {
+		case c.RequestID != first.RequestID:
+			return BaseEvent{}, fmt.Errorf("chunk %d belongs to request %s, not %s", c.Seq, c.RequestID, first.RequestID)
+		case c.Total != first.Total:
+			return BaseEvent{}, fmt.Errorf("chunk %d of request %s has total %d, expected %d", c.Seq, c.RequestID, c.Total, first.Total)
+		case part.EventType != sorted[0].EventType:
+			return BaseEvent{}, fmt.Errorf("chunk %d of request %s has event type %s, expected %s", c.Seq, c.RequestID, part.EventType, sorted[0].EventType)
+		case c.Seq != i+1:
+			return BaseEvent{}, fmt.Errorf("request %s is missing chunk %d", first.RequestID, i+1)
+		}
+
+		var fragment string
+		if err := json.Unmarshal(part.Payload, &fragment); err != nil {
+			return BaseEvent{}, fmt.Errorf("chunk %d of request %s: payload must be a JSON string: %w", c.Seq, c.RequestID, err)
+		}
+		payload.WriteString(fragment)
+	}
+
+	joined := []byte(payload.String())
+	if !json.Valid(joined) {
+		return BaseEvent{}, fmt.Errorf("reassembled payload of request %s is not valid JSON", first.RequestID)
+	}
+
+	evt := sorted[0]
+	evt.EventID = first.RequestID
+	evt.Chunk = nil
+	evt.Payload = joined
+	if evt.CorrelationID == "" {
+		evt.CorrelationID = evt.EventID
+	}
+	return evt, nil
+}
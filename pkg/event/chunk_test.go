@@ -0,0 +1,124 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSplitEvent_JoinChunks(t *testing.T) {
+	portfolios := CreateSamplePortfolios()
+	portfolios[0].Name = "Büyüme Fonu — ğüşiöç"
+	evt, err := NewPortfolioReportEvent(portfolios)
+	if err != nil {
+		t.Fatalf("NewPortfolioReportEvent error: %v", err)
+	}
+	evt.RequestedBy = "ops"
+
+	parts, err := SplitEvent(evt, 50)
+	if err != nil {
+		t.Fatalf("SplitEvent error: %v", err)
+	}
+	if len(parts) < 2 {
+		t.Fatalf("Expected several chunks, got %d", len(parts))
+	}
+	for i, part := range parts {
+		if part.Chunk == nil || part.Chunk.RequestID != evt.EventID || part.Chunk.Seq != i+1 || part.Chunk.Total != len(parts) {
+			t.Errorf("Unexpected chunk info for part %d: %+v", i, part.Chunk)
+		}
+		if part.EventID == evt.EventID || part.CorrelationID != evt.CorrelationID || part.RequestedBy != "ops" {
+			t.Errorf("Unexpected envelope for part %d: %+v", i, part)
+		}
+		var fragment string
+		if err := json.Unmarshal(part.Payload, &fragment); err != nil || len(fragment) > 50 {
+			t.Errorf("Part %d payload is not a fragment of at most 50 bytes: %s", i, part.Payload)
+		}
+	}
+
+	// Parçaların sırası birleştirmeyi etkilemez
+	reversed := make([]BaseEvent, len(parts))
+	for i, part := range parts {
+		reversed[len(parts)-1-i] = part
+	}
+	joined, err := JoinChunks(reversed)
+	if err != nil {
+		t.Fatalf("JoinChunks error: %v", err)
+	}
+	if joined.EventID != evt.EventID || joined.Chunk != nil || string(joined.Payload) != string(evt.Payload) {
+		t.Errorf("Joined event differs from the original:\n%+v\n%+v", joined, evt)
+	}
+}
+
+func TestSplitEvent_SmallPayload(t *testing.T) {
+	evt, _ := NewPortfolioReportRequestEvent("user123")
+	parts, err := SplitEvent(evt, 1<<20)
+	if err != nil {
+		t.Fatalf("SplitEvent error: %v", err)
+	}
+	if len(parts) != 1 || parts[0].Chunk != nil {
+		t.Errorf("Expected the event unchanged, got %+v", parts)
+	}
+	if _, err := SplitEvent(evt, 0); err == nil {
+		t.Error("Expected error for a zero chunk size")
+	}
+}
+
+func TestSplitEvent_MissingEventID(t *testing.T) {
+	evt, _ := NewPortfolioReportRequestEvent("user123")
+	evt.EventID = ""
+	for _, size := range []int{10, 1 << 20} {
+		if _, err := SplitEvent(evt, size); err == nil {
+			t.Errorf("SplitEvent(size %d): expected error for an empty event_id", size)
+		}
+	}
+}
+
+func TestJoinChunks_Errors(t *testing.T) {
+	evt, _ := NewPortfolioReportEvent(CreateSamplePortfolios())
+	parts, err := SplitEvent(evt, 100)
+	if err != nil {
+		t.Fatalf("SplitEvent error: %v", err)
+	}
+
+	if _, err := JoinChunks(parts[1:]); err == nil {
+		t.Error("Expected error for a missing chunk")
+	}
+
+	duplicate := append([]BaseEvent{}, parts...)
+	duplicate[1] = parts[0]
+	if _, err := JoinChunks(duplicate); err == nil || !strings.Contains(err.Error(), "missing chunk 2") {
+		t.Errorf("Expected missing chunk error, got %v", err)
+	}
+
+	mismatched := append([]BaseEvent{}, parts...)
+	other := *mismatched[1].Chunk
+	other.Total++
+	mismatched[1].Chunk = &other
+	if _, err := JoinChunks(mismatched); err == nil {
+		t.Error("Expected error for inconsistent totals")
+	}
+}
+
+func TestChunkInfo_Validate(t *testing.T) {
+	if err := (ChunkInfo{RequestID: "r1", Seq: 2, Total: 2}).Validate(); err != nil {
+		t.Errorf("Validate on valid chunk returned %v", err)
+	}
+
+	var verrs ValidationErrors
+	if !errors.As((ChunkInfo{Seq: 3, Total: 2}).Validate(), &verrs) {
+		t.Fatal("Expected ValidationErrors")
+	}
+	want := map[string]string{
+		"chunk.request_id": RuleRequired,
+		"chunk.seq":        RuleMax,
+	}
+	if len(verrs) != len(want) {
+		t.Errorf("Got %d errors, want %d: %v", len(verrs), len(want), verrs)
+	}
+	for _, fe := range verrs {
+		if rule, ok := want[fe.Path]; !ok || rule != fe.Rule {
+			t.Errorf("Unexpected error %s (%s)", fe.Path, fe.Rule)
+		}
+	}
+}
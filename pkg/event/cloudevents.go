@@ -0,0 +1,157 @@
+package event
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CloudEvents 1.0 sabitleri
+const (
+	CloudEventsSpecVersion = "1.0"
+	// CloudEventsContentType structured mode mesajlarının içerik tipi
+	CloudEventsContentType = "application/cloudevents+json"
+	// JSONContentType event verisinin (data) içerik tipi
+	JSONContentType = "application/json"
+)
+
+// CloudEvent CloudEvents 1.0 structured mode JSON gösterimi.
+// Zarfımızdaki ek alanlar CloudEvents uzantı (extension) öznitelikleri olarak taşınır.
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Time            string          `json:"time,omitempty"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+	DataBase64      string          `json:"data_base64,omitempty"`
+
+	// Uzantı öznitelikleri
+	CorrelationID string `json:"correlationid,omitempty"`
+	CausationID   string `json:"causationid,omitempty"`
+	SchemaVersion string `json:"schemaversion,omitempty"`
+	RequestedBy   string `json:"requestedby,omitempty"`
+}
+
+// ToCloudEvent zarfı CloudEvents 1.0 gösterimine dönüştürür
+func ToCloudEvent(evt BaseEvent) CloudEvent {
+	source := evt.Source
+	if source == "" {
+		source = DefaultSource
+	}
+
+	ce := CloudEvent{
+		SpecVersion:     CloudEventsSpecVersion,
+		ID:              evt.EventID,
+		Source:          source,
+		Type:            string(evt.EventType),
+		Time:            evt.Timestamp.String(),
+		DataContentType: JSONContentType,
+		Data:            evt.Payload,
+		CorrelationID:   evt.CorrelationID,
+		CausationID:     evt.CausationID,
+		RequestedBy:     evt.RequestedBy,
+	}
+	if evt.SchemaVersion != 0 {
+		ce.SchemaVersion = strconv.Itoa(evt.SchemaVersion)
+	}
+	return ce
+}
+
+// ToBaseEvent CloudEvent'i zarfımıza dönüştürür ve zorunlu öznitelikleri doğrular
+func (ce CloudEvent) ToBaseEvent() (BaseEvent, error) {
+	if ce.SpecVersion != CloudEventsSpecVersion {
+		return BaseEvent{}, fmt.Errorf("unsupported CloudEvents specversion %q", ce.SpecVersion)
+	}
+	for attr, value := range map[string]string{"id": ce.ID, "source": ce.Source, "type": ce.Type} {
+		if value == "" {
+			return BaseEvent{}, fmt.Errorf("CloudEvent is missing required attribute %q", attr)
+		}
+	}
+	if ce.DataContentType != "" && !IsJSONContentType(ce.DataContentType) {
+		return BaseEvent{}, fmt.Errorf("unsupported CloudEvent datacontenttype %q", ce.DataContentType)
+	}
+
+	payload := ce.Data
+	if ce.DataBase64 != "" {
+		decoded, err := base64.StdEncoding.DecodeString(ce.DataBase64)
+		if err != nil {
+			return BaseEvent{}, fmt.Errorf("invalid CloudEvent data_base64: %w", err)
+		}
+		payload = decoded
+	}
+
+	schemaVersion := SchemaVersionV1
+	if ce.SchemaVersion != "" {
+		v, err := strconv.Atoi(ce.SchemaVersion)
+		if err != nil {
+			return BaseEvent{}, fmt.Errorf("invalid CloudEvent schemaversion %q", ce.SchemaVersion)
+		}
+		schemaVersion = v
+	}
+
+	timestamp, err := ParseTimestamp(ce.Time)
+	if err != nil {
+		return BaseEvent{}, fmt.Errorf("invalid CloudEvent time: %w", err)
+	}
+
+	evt := BaseEvent{
+		EventID:       ce.ID,
+		EventType:     EventType(ce.Type),
+		Timestamp:     timestamp,
+		CorrelationID: ce.CorrelationID,
+		CausationID:   ce.CausationID,
+		Source:        ce.Source,
+		SchemaVersion: schemaVersion,
+		RequestedBy:   ce.RequestedBy,
+		Payload:       payload,
+	}
+	if evt.CorrelationID == "" {
+		evt.CorrelationID = evt.EventID
+	}
+	return evt, nil
+}
+
+// MarshalCloudEvent zarfı structured mode CloudEvents JSON'una dönüştürür
+func MarshalCloudEvent(evt BaseEvent) ([]byte, error) {
+	data, err := json.Marshal(ToCloudEvent(evt))
+	if err != nil {
+		return nil, fmt.Errorf("cloudevent serialization error: %w", err)
+	}
+	return data, nil
+}
+
+// IsJSONContentType içerik tipinin JSON olup olmadığını döndürür (parametreler yok sayılır)
+func IsJSONContentType(contentType string) bool {
+	mediaType := strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+	return mediaType == JSONContentType || strings.HasSuffix(mediaType, "+json")
+}
+
+// isStructuredCloudEvent JSON mesajının CloudEvents structured mode olup olmadığını belirler.
+// Gövde ardından yeniden çözüleceği için tamamı çözülmez: yalnızca üst düzey anahtarlar
+// okunur, diğer değerler ham olarak atlanır ve specversion bulununca durulur.
+func isStructuredCloudEvent(data []byte) bool {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return false
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return false
+		}
+		if key, _ := tok.(string); key == "specversion" {
+			var version string
+			return dec.Decode(&version) == nil && version != ""
+		}
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package event
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseEvent_StructuredCloudEvent(t *testing.T) {
+	data := []byte(`{
+		"specversion": "1.0",
+		"id": "abc-123",
+		"source": "/portfolio-service",
+		"type": "portfolio.report",
+		"time": "2024-01-01T10:00:00Z",
+		"datacontenttype": "application/json",
+		"correlationid": "req-1",
+		"data": {"portfolios": []}
+	}`)
+
+	evt, err := ParseEvent(data)
+	if err != nil {
+		t.Fatalf("ParseEvent error: %v", err)
+	}
+	if evt.EventType != PortfolioReport || evt.EventID != "abc-123" || evt.Source != "/portfolio-service" {
+		t.Errorf("Unexpected envelope: %+v", evt)
+	}
+	if evt.Timestamp.String() != "2024-01-01T10:00:00Z" || evt.CorrelationID != "req-1" {
+		t.Errorf("time/correlation not mapped: %+v", evt)
+	}
+	var payload PortfolioReportPayload
+	if err := evt.ParsePayload(&payload); err != nil {
+		t.Errorf("ParsePayload error: %v", err)
+	}
+}
+
+func TestParseEvent_InvalidCloudEvent(t *testing.T) {
+	for name, data := range map[string]string{
+		"missing id":       `{"specversion":"1.0","source":"s","type":"t"}`,
+		"wrong version":    `{"specversion":"0.3","id":"1","source":"s","type":"t"}`,
+		"non-json content": `{"specversion":"1.0","id":"1","source":"s","type":"t","datacontenttype":"text/csv"}`,
+	} {
+		if _, err := ParseEvent([]byte(data)); err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
+
+func TestIsStructuredCloudEvent(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want bool
+	}{
+		{"specversion first", `{"specversion":"1.0","id":"1"}`, true},
+		{"specversion after nested data", `{"data":{"a":[1,{"b":"c"}]},"specversion":"1.0"}`, true},
+		{"native envelope", `{"event_type":"portfolio.report","payload":{"portfolios":[]}}`, false},
+		{"nested specversion only", `{"payload":{"specversion":"1.0"}}`, false},
+		{"empty specversion", `{"specversion":""}`, false},
+		{"non-string specversion", `{"specversion":1}`, false},
+		{"array", `[{"specversion":"1.0"}]`, false},
+		{"invalid json", `{"payload":`, false},
+	}
+	for _, tt := range tests {
+		if got := isStructuredCloudEvent([]byte(tt.data)); got != tt.want {
+			t.Errorf("%s: isStructuredCloudEvent = %v; want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCloudEvent_RoundTrip(t *testing.T) {
+	evt, _ := NewPortfolioReportEvent(CreateSamplePortfolios())
+	evt.RequestedBy = "advisor42"
+
+	data, err := MarshalCloudEvent(evt)
+	if err != nil {
+		t.Fatalf("MarshalCloudEvent error: %v", err)
+	}
+	var raw map[string]interface{}
+	json.Unmarshal(data, &raw)
+	if raw["specversion"] != "1.0" || raw["type"] != "portfolio.report" || raw["id"] != evt.EventID {
+		t.Errorf("Unexpected CloudEvent JSON: %s", data)
+	}
+
+	parsed, err := ParseEvent(data)
+	if err != nil {
+		t.Fatalf("ParseEvent error: %v", err)
+	}
+	if parsed.EventID != evt.EventID || parsed.CorrelationID != evt.CorrelationID ||
+		parsed.SchemaVersion != evt.SchemaVersion || parsed.RequestedBy != "advisor42" {
+		t.Errorf("Round trip mismatch: got %+v, want %+v", parsed, evt)
+	}
+}
@@ -0,0 +1,344 @@
+package event
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode ondalık sayıların belirli bir basamağa yuvarlanma kuralı
+type RoundingMode string
+
+// Yuvarlama kuralları
+const (
+	RoundHalfUp   RoundingMode = "half_up"   // yarım değerler sıfırdan uzağa (1.005 -> 1.01)
+	RoundHalfEven RoundingMode = "half_even" // yarım değerler çift basamağa, bankacı yuvarlaması (1.005 -> 1.00)
+	RoundDown     RoundingMode = "down"      // sıfıra doğru kesme (1.009 -> 1.00)
+)
+
+// DefaultRoundingMode yuvarlama kuralı belirtilmediğinde kullanılan kural
+const DefaultRoundingMode = RoundHalfUp
+
+// IsValid yuvarlama kuralının bilinen kurallardan biri olup olmadığını döndürür
+func (m RoundingMode) IsValid() bool {
+	switch m {
+	case RoundHalfUp, RoundHalfEven, RoundDown:
+		return true
+	}
+	return false
+}
+
+// Decimal parasal tutarlar için kayan nokta hatası içermeyen ondalık sayı:
+// değer = katsayı * 10^-ölçek. Sıfır değer 0'dır. Değerler değişmezdir; tüm
+// işlemler yeni bir Decimal döndürür. JSON'da metin olarak yazılır, metin ya da
+// sayı olarak okunur (sayı literali de tam olarak çözülür). JSON'dan okunup
+// çözülemeyen değerler payload'ın çözülmesini engellemez; girdi saklanır (bkz.
+// InvalidInput) ve doğrulama sırasında alan yoluyla RuleDecimal hatası olarak bildirilir.
+type Decimal struct {
+	coef    *big.Int // nil ise 0
+	scale   int32
+	invalid string
+}
+
+// NewDecimal katsayı ve ölçekten bir Decimal oluşturur, ör. NewDecimal(1234, 2) = 12.34
+func NewDecimal(coef int64, scale int32) Decimal {
+	return Decimal{coef: big.NewInt(coef), scale: scale}
+}
+
+// NewDecimalFromInt tam sayıdan bir Decimal oluşturur
+func NewDecimalFromInt(i int64) Decimal {
+	return NewDecimal(i, 0)
+}
+
+// DecimalFromFloat kayan noktalı sayıyı en kısa ondalık gösterimiyle Decimal'e
+// çevirir (0.1 -> 0.1). Yalnızca eski float alanlarından geçiş için kullanılmalıdır.
+func DecimalFromFloat(f float64) Decimal {
+	d, err := ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+	if err != nil {
+		return Decimal{}
+	}
+	return d
+}
+
+// Çözülebilen üs ve ölçek sınırları. Parasal tutarlar bu sınırlara yaklaşmaz;
+// "1e50000000" gibi değerlerin devasa katsayılar üretmesini engellerler.
+const (
+	MaxDecimalExponent = 64
+	MaxDecimalScale    = 38
+)
+
+// ParseDecimal "-12.34", "1e3" ya da "0.000001" gibi bir metni çözer. Mutlak
+// değeri MaxDecimalExponent'i aşan üsler ve MaxDecimalScale'den fazla ondalık
+// basamak reddedilir.
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	mantissa, exp := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+		if e > MaxDecimalExponent || e < -MaxDecimalExponent {
+			return Decimal{}, fmt.Errorf("invalid decimal %q: exponent out of range (max %d)", s, MaxDecimalExponent)
+		}
+		mantissa, exp = s[:i], e
+	}
+
+	digits := mantissa
+	var scale int64
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		digits = mantissa[:i] + mantissa[i+1:]
+		scale = int64(len(mantissa) - i - 1)
+	}
+	unsigned := strings.TrimLeft(digits, "+-")
+	if unsigned == "" || len(digits)-len(unsigned) > 1 || strings.ContainsAny(unsigned, "+-") {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	coef, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	scale -= exp
+	if scale > MaxDecimalScale {
+		return Decimal{}, fmt.Errorf("invalid decimal %q: more than %d decimal places", s, MaxDecimalScale)
+	}
+	if scale < 0 {
+		coef.Mul(coef, pow10(-scale))
+		scale = 0
+	}
+	return Decimal{coef: coef, scale: int32(scale)}, nil
+}
+
+// MustParseDecimal ParseDecimal gibidir ancak hata durumunda panic eder;
+// sabit değerli örnek ve test verileri için kullanılır
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// pow10 10^n değerini döndürür
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil)
+}
+
+// coefficient katsayıyı döndürür; sıfır değer için 0
+func (d Decimal) coefficient() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// rescale değeri daha büyük bir ölçeğe taşır (değer değişmez)
+func (d Decimal) rescale(scale int32) *big.Int {
+	c := new(big.Int).Set(d.coefficient())
+	if scale > d.scale {
+		c.Mul(c, pow10(int64(scale-d.scale)))
+	}
+	return c
+}
+
+// align iki sayıyı ortak ölçeğe getirir
+func align(a, b Decimal) (*big.Int, *big.Int, int32) {
+	scale := a.scale
+	if b.scale > scale {
+		scale = b.scale
+	}
+	return a.rescale(scale), b.rescale(scale), scale
+}
+
+// Scale ondalık basamak sayısını döndürür
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Add d + o
+func (d Decimal) Add(o Decimal) Decimal {
+	a, b, scale := align(d, o)
+	return Decimal{coef: a.Add(a, b), scale: scale}
+}
+
+// Sub d - o
+func (d Decimal) Sub(o Decimal) Decimal {
+	a, b, scale := align(d, o)
+	return Decimal{coef: a.Sub(a, b), scale: scale}
+}
+
+// Mul d * o; sonucun ölçeği iki ölçeğin toplamıdır, yuvarlama yapılmaz
+func (d Decimal) Mul(o Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.coefficient(), o.coefficient()), scale: d.scale + o.scale}
+}
+
+// Div d / o sonucunu verilen ölçeğe yuvarlanmış olarak döndürür. Sıfıra bölmede panic eder.
+func (d Decimal) Div(o Decimal, scale int32, mode RoundingMode) Decimal {
+	if o.IsZero() {
+		panic("event: decimal division by zero")
+	}
+	// d/o = (cd * 10^(scale+1+os)) / (co * 10^ds) * 10^-(scale+1); bir fazla basamak yuvarlama içindir
+	num := new(big.Int).Mul(d.coefficient(), pow10(int64(scale)+1+int64(o.scale)))
+	den := new(big.Int).Mul(o.coefficient(), pow10(int64(d.scale)))
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() != 0 {
+		// Kesilen kalan, yarım değerin tam üstünde mi yoksa tam yarım mı olduğunu ayırt etmek için
+		// en düşük basamağa sıfırdan uzak bir iz bırakır
+		q.Mul(q, big.NewInt(10))
+		q.Add(q, big.NewInt(int64(num.Sign()*den.Sign())))
+		return Decimal{coef: q, scale: scale + 2}.Round(scale, mode)
+	}
+	return Decimal{coef: q, scale: scale + 1}.Round(scale, mode)
+}
+
+// Round değeri verilen ondalık basamak sayısına yuvarlar. Ölçek zaten
+// daha küçükse değer aynı kalır ve ölçek büyütülür (12.3 -> 12.30).
+func (d Decimal) Round(scale int32, mode RoundingMode) Decimal {
+	if scale >= d.scale {
+		return Decimal{coef: d.rescale(scale), scale: scale}
+	}
+
+	divisor := pow10(int64(d.scale - scale))
+	q, r := new(big.Int).QuoRem(d.coefficient(), divisor, new(big.Int))
+	if r.Sign() == 0 || mode == RoundDown {
+		return Decimal{coef: q, scale: scale}
+	}
+
+	// Kalanın yarıma göre konumu: |2r| ile bölenin karşılaştırması
+	half := new(big.Int).Abs(r)
+	half.Mul(half, big.NewInt(2))
+	cmp := half.Cmp(divisor)
+	if cmp > 0 || (cmp == 0 && (mode == RoundHalfUp || q.Bit(0) == 1)) {
+		q.Add(q, big.NewInt(int64(r.Sign())))
+	}
+	return Decimal{coef: q, scale: scale}
+}
+
+// Neg -d
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.coefficient()), scale: d.scale}
+}
+
+// Abs |d|
+func (d Decimal) Abs() Decimal {
+	return Decimal{coef: new(big.Int).Abs(d.coefficient()), scale: d.scale}
+}
+
+// Sign d < 0 için -1, d == 0 için 0, d > 0 için 1 döndürür
+func (d Decimal) Sign() int {
+	return d.coefficient().Sign()
+}
+
+// IsZero değerin sıfır olup olmadığını döndürür
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp d < o için -1, d == o için 0, d > o için 1 döndürür (ölçekten bağımsız)
+func (d Decimal) Cmp(o Decimal) int {
+	a, b, _ := align(d, o)
+	return a.Cmp(b)
+}
+
+// Equal iki değerin sayısal olarak eşit olup olmadığını döndürür (1.0 == 1.00)
+func (d Decimal) Equal(o Decimal) bool {
+	return d.Cmp(o) == 0
+}
+
+// Float64 değerin en yakın float64 karşılığını döndürür; yalnızca oran ve
+// grafik hesapları içindir, tutarlar için kullanılmamalıdır
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String değeri ölçeği koruyarak düz ondalık gösterimle döndürür, ör. "-12.30"
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.coefficient()).String()
+	sign := ""
+	if d.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale <= 0 {
+		return sign + digits
+	}
+	if pad := int(d.scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	point := len(digits) - int(d.scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// InvalidInput JSON'dan okunup çözülemeyen girdiyi döndürür; değer geçerliyse boş metin
+func (d Decimal) InvalidInput() string {
+	return d.invalid
+}
+
+// MarshalText Decimal'i düz ondalık metin olarak yazar; çözülemeyen girdiler aynen geri yazılır
+func (d Decimal) MarshalText() ([]byte, error) {
+	if d.invalid != "" {
+		return []byte(d.invalid), nil
+	}
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText ondalık metni çözer
+func (d *Decimal) UnmarshalText(data []byte) error {
+	parsed, err := ParseDecimal(string(data))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// GobEncode Decimal'i gob için düz ondalık metin olarak kodlar
+func (d Decimal) GobEncode() ([]byte, error) {
+	return d.MarshalText()
+}
+
+// GobDecode gob ile kodlanmış ondalık metni çözer
+func (d *Decimal) GobDecode(data []byte) error {
+	return d.UnmarshalText(data)
+}
+
+// MarshalJSON Decimal'i tam değeri koruyan bir JSON metni olarak yazar
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	text, _ := d.MarshalText()
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON JSON metni ya da sayı literalini tam olarak çözer; null sıfır değer
+// bırakır. Metin ya da sayı olmayan değerler hata döndürür; çözülemeyen ya da
+// sınırları aşan değerler ise hata yerine InvalidInput'ta saklanır ki payload'daki
+// diğer alan hataları da birlikte bildirilebilsin.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*d = Decimal{}
+		return nil
+	}
+	input := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &input); err != nil {
+			return err
+		}
+	} else if len(data) == 0 || !(data[0] == '-' || (data[0] >= '0' && data[0] <= '9')) {
+		return fmt.Errorf("invalid decimal %s: must be a string or number", data)
+	}
+	parsed, err := ParseDecimal(input)
+	if err != nil {
+		*d = Decimal{invalid: input}
+		return nil
+	}
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,128 @@
+package event
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	cases := map[string]string{
+		"12.34":    "12.34",
+		"-0.5":     "-0.5",
+		"+7":       "7",
+		"1e3":      "1000",
+		"1.5E-3":   "0.0015",
+		"0.000001": "0.000001",
+		" 42.10 ":  "42.10",
+	}
+	for in, want := range cases {
+		d, err := ParseDecimal(in)
+		if err != nil || d.String() != want {
+			t.Errorf("ParseDecimal(%q) = %s, %v; want %s", in, d, err, want)
+		}
+	}
+	for _, in := range []string{"", "abc", "1.2.3", "--1", "1-2", "1e"} {
+		if _, err := ParseDecimal(in); err == nil {
+			t.Errorf("ParseDecimal(%q) should fail", in)
+		}
+	}
+}
+
+func TestParseDecimal_Limits(t *testing.T) {
+	for _, in := range []string{"1e64", "1e-38", "0." + strings.Repeat("0", 37) + "1"} {
+		if _, err := ParseDecimal(in); err != nil {
+			t.Errorf("ParseDecimal(%q) error: %v", in, err)
+		}
+	}
+	// Huge exponents would otherwise build enormous coefficients
+	for _, in := range []string{"1e50000000", "1e-50000000", "1e65", "1e-39", "0." + strings.Repeat("0", 38) + "1"} {
+		if _, err := ParseDecimal(in); err == nil {
+			t.Errorf("ParseDecimal(%q) should fail", in)
+		}
+	}
+}
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	a, b := MustParseDecimal("0.1"), MustParseDecimal("0.2")
+	if got := a.Add(b); got.String() != "0.3" || !got.Equal(MustParseDecimal("0.30")) {
+		t.Errorf("0.1 + 0.2 = %s; want 0.3", got)
+	}
+	if got := a.Sub(b); got.String() != "-0.1" {
+		t.Errorf("0.1 - 0.2 = %s; want -0.1", got)
+	}
+	if got := MustParseDecimal("19.99").Mul(NewDecimalFromInt(3)); got.String() != "59.97" {
+		t.Errorf("19.99 * 3 = %s; want 59.97", got)
+	}
+	if got := NewDecimalFromInt(1).Div(NewDecimalFromInt(3), 4, RoundHalfUp); got.String() != "0.3333" {
+		t.Errorf("1 / 3 = %s; want 0.3333", got)
+	}
+	if got := NewDecimalFromInt(-2).Div(NewDecimalFromInt(3), 2, RoundHalfUp); got.String() != "-0.67" {
+		t.Errorf("-2 / 3 = %s; want -0.67", got)
+	}
+	var zero Decimal
+	if !zero.IsZero() || zero.String() != "0" || zero.Add(a).String() != "0.1" {
+		t.Errorf("Zero value misbehaves: %s", zero)
+	}
+	if MustParseDecimal("2").Cmp(MustParseDecimal("10.5")) != -1 {
+		t.Error("Cmp should compare numerically")
+	}
+}
+
+func TestDecimal_Round(t *testing.T) {
+	cases := []struct {
+		in   string
+		mode RoundingMode
+		want string
+	}{
+		{"1.005", RoundHalfUp, "1.01"},
+		{"1.005", RoundHalfEven, "1.00"},
+		{"1.015", RoundHalfEven, "1.02"},
+		{"1.0051", RoundHalfEven, "1.01"},
+		{"1.009", RoundDown, "1.00"},
+		{"-1.005", RoundHalfUp, "-1.01"},
+		{"-1.005", RoundHalfEven, "-1.00"},
+		{"-1.009", RoundDown, "-1.00"},
+		{"12.3", RoundHalfUp, "12.30"},
+	}
+	for _, c := range cases {
+		if got := MustParseDecimal(c.in).Round(2, c.mode); got.String() != c.want {
+			t.Errorf("Round(%s, %s) = %s; want %s", c.in, c.mode, got, c.want)
+		}
+	}
+	// Division keeps the discarded remainder so an exact half is not confused with more than half
+	if got := MustParseDecimal("1.0051").Div(NewDecimalFromInt(1), 2, RoundHalfEven); got.String() != "1.01" {
+		t.Errorf("1.0051 / 1 half_even = %s; want 1.01", got)
+	}
+}
+
+func TestDecimal_JSON(t *testing.T) {
+	var v struct {
+		A Decimal `json:"a"`
+		B Decimal `json:"b"`
+		C Decimal `json:"c"`
+	}
+	if err := json.Unmarshal([]byte(`{"a":0.1,"b":"12.50","c":null}`), &v); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if v.A.String() != "0.1" || v.B.String() != "12.50" || !v.C.IsZero() {
+		t.Errorf("Decoded %s, %s, %s", v.A, v.B, v.C)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil || string(data) != `{"a":"0.1","b":"12.50","c":"0"}` {
+		t.Errorf("Marshal = %s, %v", data, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"a":true}`), &v); err == nil {
+		t.Error("Expected an error for a boolean decimal")
+	}
+
+	// Out-of-range values are kept for validation instead of failing the decode
+	if err := json.Unmarshal([]byte(`{"a":1e50000000,"b":"abc"}`), &v); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if v.A.InvalidInput() != "1e50000000" || v.B.InvalidInput() != "abc" {
+		t.Errorf("Invalid inputs not kept: %q, %q", v.A.InvalidInput(), v.B.InvalidInput())
+	}
+}
@@ -1,6 +1,8 @@
 package event
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -11,17 +13,45 @@ type EventType string
 
 // Event tipleri burada tanımlanır
 const (
-	PortfolioReport EventType = "portfolio.report"
+	PortfolioReport     EventType = "portfolio.report"
+	TransactionReport   EventType = "transaction.report"
+	HoldingsReport      EventType = "holdings.report"
+	PerformanceReport   EventType = "performance.report"
+	RealizedGainsReport EventType = "realized_gains.report"
+	IncomeReport        EventType = "income.report"
+	// PortfolioReportRequest portföyleri mesajda taşımadan portfolio.report raporu ister
+	PortfolioReportRequest EventType = "portfolio.report.request"
+	// PortfolioGroupReport bir hane ya da danışman portföyündeki kullanıcıları tek raporda birleştirir
+	PortfolioGroupReport EventType = "portfolio.group.report"
 	// Diğer event tipleri buraya eklenebilir
 	// Örnek: UserRegistered EventType = "user.registered"
 	// Örnek: OrderCreated EventType = "order.created"
 )
 
+// Şema sürümleri. Her event tipi kendi güncel sürümünü RegisterPayloadType ile
+// bildirir; eski sürümler Upcast ile güncel yapıya yükseltilir.
+const (
+	// SchemaVersionV1 yalnızca event_type, timestamp ve payload içeren eski mesajlar
+	SchemaVersionV1 = 1
+	// CurrentSchemaVersion kimlik ve korelasyon alanlarını içeren güncel zarf
+	CurrentSchemaVersion = 2
+)
+
+// DefaultSource bu servisin ürettiği eventlerin kaynağı
+const DefaultSource = "report-export-service"
+
 // BaseEvent tüm eventlerin içermesi gereken temel alanları tanımlar
 type BaseEvent struct {
-	EventType EventType       `json:"event_type"`
-	Timestamp string          `json:"timestamp"`
-	Payload   json.RawMessage `json:"payload"`
+	EventID       string          `json:"event_id,omitempty"` // Tekrarlanan mesajları ayırt etmek için benzersiz kimlik
+	EventType     EventType       `json:"event_type"`
+	Timestamp     Timestamp       `json:"timestamp"`
+	CorrelationID string          `json:"correlation_id,omitempty"`                        // Aynı istekten doğan tüm eventleri bağlar
+	CausationID   string          `json:"causation_id,omitempty"`                          // Bu eventi doğrudan tetikleyen eventin kimliği
+	Source        string          `json:"source,omitempty"`                                // Eventi üreten servis
+	SchemaVersion int             `json:"schema_version,omitempty" jsonschema:"minimum=1"` // Zarf ve payload şema sürümü
+	RequestedBy   string          `json:"requested_by,omitempty"`                          // İsteği yapan kullanıcı veya sistem
+	Chunk         *ChunkInfo      `json:"chunk,omitempty"`                                 // Parçalı mesajlarda parça bilgisi (bkz. SplitEvent)
+	Payload       json.RawMessage `json:"payload"`
 }
 
 // NewBaseEvent yeni bir temel event oluşturur
@@ -32,26 +62,122 @@ func NewBaseEvent(eventType EventType, payload interface{}) (BaseEvent, error) {
 		return BaseEvent{}, fmt.Errorf("payload serialization error: %w", err)
 	}
 
-	// Temel event oluştur
+	// Temel event oluştur; yeni bir akışın korelasyon kimliği kendi kimliğidir
+	eventID := NewEventID()
 	return BaseEvent{
-		EventType: eventType,
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
-		Payload:   payloadBytes,
+		EventID:       eventID,
+		EventType:     eventType,
+		Timestamp:     Now(),
+		CorrelationID: eventID,
+		Source:        DefaultSource,
+		SchemaVersion: SchemaVersionFor(eventType),
+		Payload:       payloadBytes,
 	}, nil
 }
 
-// ParseEvent bir JSON mesajını BaseEvent yapısına dönüştürür
+// NewCausedEvent parent event'in sonucu olarak yeni bir event oluşturur.
+// Korelasyon kimliği ve isteği yapan bilgisi korunur, causation_id parent'ı gösterir.
+func NewCausedEvent(parent BaseEvent, eventType EventType, payload interface{}) (BaseEvent, error) {
+	evt, err := NewBaseEvent(eventType, payload)
+	if err != nil {
+		return BaseEvent{}, err
+	}
+
+	if parent.CorrelationID != "" {
+		evt.CorrelationID = parent.CorrelationID
+	}
+	evt.CausationID = parent.EventID
+	evt.RequestedBy = parent.RequestedBy
+	return evt, nil
+}
+
+// NewEventID rastgele bir UUID (v4) üretir
+func NewEventID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand hata vermemeli; yine de benzersiz bir değer üret
+		return fmt.Sprintf("evt-%d", time.Now().UnixNano())
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // sürüm 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 varyantı
+	return formatUUID(b[:])
+}
+
+// contentEventID mesaj içeriğinden deterministik bir kimlik üretir. Kimliği olmayan
+// v1 mesajları yeniden teslim edildiğinde aynı kimliği alır ve ayıklanabilir.
+func contentEventID(data []byte) string {
+	sum := sha256.Sum256(data)
+	b := sum[:16]
+	b[6] = (b[6] & 0x0f) | 0x50 // isim tabanlı (sürüm 5 biçiminde)
+	b[8] = (b[8] & 0x3f) | 0x80
+	return formatUUID(b)
+}
+
+// formatUUID 16 byte'ı standart UUID metnine dönüştürür
+func formatUUID(b []byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// ParseEvent bir JSON mesajını BaseEvent yapısına dönüştürür.
+// Hem kendi zarfımızı hem de CloudEvents 1.0 structured mode mesajlarını kabul eder.
 func ParseEvent(data []byte) (BaseEvent, error) {
+	return ParseEventWithIDs(data, "", "")
+}
+
+// ParseEventWithIDs ParseEvent gibidir; ancak event_id taşımayan kendi zarfımızdaki
+// mesajlarda kimlik ve korelasyon için verilen değerleri (ör. AMQP message-id ve
+// correlation-id özellikleri) kullanır. Boş değerler yok sayılır. CloudEvents'te id
+// zorunlu olduğundan bu değerler CloudEvents mesajlarına uygulanmaz.
+func ParseEventWithIDs(data []byte, eventID, correlationID string) (BaseEvent, error) {
+	if isStructuredCloudEvent(data) {
+		var ce CloudEvent
+		if err := json.Unmarshal(data, &ce); err != nil {
+			return BaseEvent{}, fmt.Errorf("event parsing error: %w", err)
+		}
+		event, err := ce.ToBaseEvent()
+		if err != nil {
+			return BaseEvent{}, fmt.Errorf("event parsing error: %w", err)
+		}
+		return event, nil
+	}
+
 	var event BaseEvent
 	err := json.Unmarshal(data, &event)
 	if err != nil {
 		return BaseEvent{}, fmt.Errorf("event parsing error: %w", err)
 	}
+	// Zarfın tarihi için alan doğrulaması yoktur; geçersiz değerler burada reddedilir
+	if input := event.Timestamp.InvalidInput(); input != "" {
+		_, err := ParseTimestamp(input)
+		return BaseEvent{}, fmt.Errorf("event parsing error: %w", err)
+	}
+
+	// v1 mesajlarıyla geriye dönük uyumluluk: eksik zarf alanlarını tamamla
+	if event.SchemaVersion == 0 {
+		event.SchemaVersion = SchemaVersionV1
+	}
+	if event.EventID == "" {
+		event.EventID = eventID
+		if event.CorrelationID == "" {
+			event.CorrelationID = correlationID
+		}
+	}
+	if event.EventID == "" {
+		event.EventID = contentEventID(data)
+	}
+	if event.CorrelationID == "" {
+		event.CorrelationID = event.EventID
+	}
 
 	return event, nil
 }
 
+// LogContext log satırlarına eklenecek zarf bilgisini döndürür
+func (e *BaseEvent) LogContext() string {
+	return fmt.Sprintf("event_id=%s correlation_id=%s schema=v%d", e.EventID, e.CorrelationID, e.SchemaVersion)
+}
+
 // ParsePayload event payload'ını belirtilen yapıya dönüştürür
 func (e *BaseEvent) ParsePayload(target interface{}) error {
 	return json.Unmarshal(e.Payload, target)
-} 
\ No newline at end of file
+}
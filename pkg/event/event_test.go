@@ -15,8 +15,8 @@ func TestNewBaseEvent(t *testing.T) {
 	if evt.EventType != PortfolioReport {
 		t.Errorf("EventType = %v; want %v", evt.EventType, PortfolioReport)
 	}
-	// Timestamp must be RFC3339
-	if _, err := time.Parse(time.RFC3339, evt.Timestamp); err != nil {
+	// Timestamp must be set and marshal as RFC3339
+	if _, err := time.Parse(time.RFC3339, evt.Timestamp.String()); err != nil || evt.Timestamp.IsZero() {
 		t.Errorf("Timestamp not RFC3339: %q", evt.Timestamp)
 	}
 	// Payload contains correct JSON
@@ -45,7 +45,7 @@ func TestParseEvent_ValidAndInvalid(t *testing.T) {
 
 func TestParsePayload(t *testing.T) {
 	raw := json.RawMessage(`{"name":"test"}`)
-	evt := BaseEvent{EventType: PortfolioReport, Timestamp: "x", Payload: raw}
+	evt := BaseEvent{EventType: PortfolioReport, Timestamp: Now(), Payload: raw}
 	var m map[string]string
 	if err := evt.ParsePayload(&m); err != nil {
 		t.Fatalf("ParsePayload error: %v", err)
@@ -53,4 +53,69 @@ func TestParsePayload(t *testing.T) {
 	if m["name"] != "test" {
 		t.Errorf("Parsed name = %q; want %q", m["name"], "test")
 	}
-} 
\ No newline at end of file
+} 
+
+func TestNewBaseEvent_EnvelopeV2(t *testing.T) {
+	evt, err := NewBaseEvent(PortfolioReport, map[string]string{})
+	if err != nil {
+		t.Fatalf("NewBaseEvent error: %v", err)
+	}
+	if evt.EventID == "" || evt.CorrelationID != evt.EventID {
+		t.Errorf("EventID = %q, CorrelationID = %q; want generated ID used as correlation", evt.EventID, evt.CorrelationID)
+	}
+	if evt.SchemaVersion != CurrentSchemaVersion || evt.Source != DefaultSource {
+		t.Errorf("SchemaVersion = %d, Source = %q", evt.SchemaVersion, evt.Source)
+	}
+
+	other, _ := NewBaseEvent(PortfolioReport, map[string]string{})
+	if other.EventID == evt.EventID {
+		t.Errorf("Event IDs must be unique, got %q twice", evt.EventID)
+	}
+
+	// Round trip keeps all envelope fields
+	data, _ := json.Marshal(evt)
+	parsed, err := ParseEvent(data)
+	if err != nil {
+		t.Fatalf("ParseEvent error: %v", err)
+	}
+	if parsed.EventID != evt.EventID || parsed.SchemaVersion != CurrentSchemaVersion {
+		t.Errorf("Round trip lost envelope fields: %+v", parsed)
+	}
+}
+
+func TestParseEvent_V1BackwardCompatibility(t *testing.T) {
+	v1 := []byte(`{"event_type":"portfolio.report","timestamp":"2006-01-02T15:04:05Z","payload":{"portfolios":[]}}`)
+	evt, err := ParseEvent(v1)
+	if err != nil {
+		t.Fatalf("ParseEvent error: %v", err)
+	}
+	if evt.SchemaVersion != SchemaVersionV1 {
+		t.Errorf("SchemaVersion = %d; want %d", evt.SchemaVersion, SchemaVersionV1)
+	}
+	if evt.EventID == "" || evt.CorrelationID != evt.EventID {
+		t.Errorf("v1 event should get a derived ID used as correlation, got %q / %q", evt.EventID, evt.CorrelationID)
+	}
+
+	// Redelivered v1 messages get the same ID so they can be deduplicated
+	again, _ := ParseEvent(v1)
+	if again.EventID != evt.EventID {
+		t.Errorf("Derived IDs differ for identical messages: %q vs %q", evt.EventID, again.EventID)
+	}
+}
+
+func TestNewCausedEvent(t *testing.T) {
+	parent, _ := NewBaseEvent(PortfolioReport, map[string]string{})
+	parent.RequestedBy = "advisor42"
+
+	child, err := NewCausedEvent(parent, "report.generated", map[string]string{})
+	if err != nil {
+		t.Fatalf("NewCausedEvent error: %v", err)
+	}
+	if child.CorrelationID != parent.CorrelationID || child.CausationID != parent.EventID {
+		t.Errorf("child correlation/causation = %q/%q; want %q/%q",
+			child.CorrelationID, child.CausationID, parent.CorrelationID, parent.EventID)
+	}
+	if child.EventID == parent.EventID || child.RequestedBy != "advisor42" {
+		t.Errorf("unexpected child envelope: %+v", child)
+	}
+}
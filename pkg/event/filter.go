@@ -0,0 +1,75 @@
+package event
+
+import (
+	"github.com/burakmike/report-export-service/pkg/filter"
+)
+
+// portfolioFilterSchema filtre ifadelerinde kullanılabilen portföy alanları (JSON adlarıyla)
+var portfolioFilterSchema = filter.Schema{
+	"portID":     filter.Number,
+	"name":       filter.String,
+	"userID":     filter.String,
+	"createdAt":  filter.Time,
+	"lastUpdate": filter.Time,
+}
+
+// PortfolioFilter payload'daki filtre ifadesinin derlenmiş hali. nil filtre tüm
+// portföyleri kabul eder.
+type PortfolioFilter struct {
+	expr filter.Expr
+}
+
+// ParsePortfolioFilter filtre ifadesini portföy alanlarına göre çözer. Boş ifade
+// için nil döner. Hatalar *filter.SyntaxError'dur.
+func ParsePortfolioFilter(src string) (*PortfolioFilter, error) {
+	if src == "" {
+		return nil, nil
+	}
+	expr, err := filter.Parse(src, portfolioFilterSchema)
+	if err != nil {
+		return nil, err
+	}
+	return &PortfolioFilter{expr: expr}, nil
+}
+
+// Match portföyün filtreye uyup uymadığını döndürür
+func (f *PortfolioFilter) Match(p Portfolio) bool {
+	if f == nil {
+		return true
+	}
+	return f.expr.Match(func(field string) filter.Value {
+		switch field {
+		case "portID":
+			return filter.NumberValue(float64(p.PortID))
+		case "name":
+			return filter.StringValue(p.Name)
+		case "userID":
+			return filter.StringValue(p.UserID)
+		case "createdAt":
+			return filter.TimeValue(p.CreatedAt.Time)
+		default: // lastUpdate
+			return filter.TimeValue(p.LastUpdate.Time)
+		}
+	})
+}
+
+// Apply filtreye uyan portföyleri sırasını koruyarak döndürür
+func (f *PortfolioFilter) Apply(portfolios []Portfolio) []Portfolio {
+	if f == nil {
+		return portfolios
+	}
+	matched := make([]Portfolio, 0, len(portfolios))
+	for _, p := range portfolios {
+		if f.Match(p) {
+			matched = append(matched, p)
+		}
+	}
+	return matched
+}
+
+// validateFilter filtre ifadesini doğrular; çözülemeyen ifadeler alan hatası olarak bildirilir
+func validateFilter(src string, add func(path, rule string, value interface{}, message string)) {
+	if _, err := ParsePortfolioFilter(src); err != nil {
+		add("filter", RuleSyntax, src, err.Error())
+	}
+}
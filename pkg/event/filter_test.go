@@ -0,0 +1,71 @@
+package event
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPortfolioFilter(t *testing.T) {
+	portfolios := []Portfolio{
+		{PortID: 1, Name: "Growth", UserID: "u1", CreatedAt: MustParseTimestamp("2025-01-01"), LastUpdate: MustParseTimestamp("2025-06-01")},
+		{PortID: 2, Name: "Income", UserID: "u2", CreatedAt: MustParseTimestamp("2025-02-01"), LastUpdate: MustParseTimestamp("2026-02-01")},
+		{PortID: 3, Name: "Growth II", UserID: "u3", CreatedAt: MustParseTimestamp("2026-01-15"), LastUpdate: MustParseTimestamp("2026-03-01")},
+	}
+
+	tests := []struct {
+		expr string
+		want []int
+	}{
+		{``, []int{1, 2, 3}},
+		{`userID in ("u1", "u2")`, []int{1, 2}},
+		{`lastUpdate > 2026-01-01 and name like "growth*"`, []int{3}},
+		{`createdAt < 2026-01-01 and not portID = 1`, []int{2}},
+		{`portid >= 2`, []int{2, 3}},
+	}
+	for _, tt := range tests {
+		f, err := ParsePortfolioFilter(tt.expr)
+		if err != nil {
+			t.Errorf("ParsePortfolioFilter(%q) error: %v", tt.expr, err)
+			continue
+		}
+		got := f.Apply(portfolios)
+		ids := make([]int, len(got))
+		for i, p := range got {
+			ids[i] = p.PortID
+		}
+		if len(ids) != len(tt.want) {
+			t.Errorf("%q matched %v; want %v", tt.expr, ids, tt.want)
+			continue
+		}
+		for i := range ids {
+			if ids[i] != tt.want[i] {
+				t.Errorf("%q matched %v; want %v", tt.expr, ids, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestPortfolioFilter_Validate(t *testing.T) {
+	portfolios := []Portfolio{{PortID: 1, Name: "A", UserID: "u1", CreatedAt: MustParseTimestamp("2024-01-01"), LastUpdate: MustParseTimestamp("2024-01-02")}}
+
+	payload := PortfolioReportPayload{Filter: `owner = "u1"`, Portfolios: portfolios}
+	var verrs ValidationErrors
+	if !errors.As(payload.Validate(), &verrs) || len(verrs) != 1 {
+		t.Fatalf("Expected one validation error, got %v", payload.Validate())
+	}
+	if fe := verrs[0]; fe.Path != "filter" || fe.Rule != RuleSyntax || !strings.Contains(fe.Message, `at position 1: unknown field "owner"`) {
+		t.Errorf("Unexpected error: %+v", fe)
+	}
+
+	request := PortfolioReportRequestPayload{UserID: "u1", Filter: `lastUpdate > "yesterday"`}
+	if err := request.Validate(); err == nil || !strings.Contains(err.Error(), "filter: at position 14: invalid date") {
+		t.Errorf("Expected filter error on request payload, got %v", err)
+	}
+
+	payload.Filter = `userID = "u1"`
+	if err := payload.Validate(); err != nil {
+		t.Errorf("Validate on valid filter returned %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package event
+
+// LotMethod satışların hangi alım lotlarıyla eşleştirileceğini belirler
+type LotMethod string
+
+// Lot eşleştirme yöntemleri
+const (
+	LotFIFO        LotMethod = "fifo"
+	LotAverageCost LotMethod = "average_cost"
+)
+
+// RealizedGainsReportPayload realized_gains.report olayının payload'ını tanımlar.
+// Portföylerin işlem geçmişi transaction.report ile aynı yapıdadır.
+type RealizedGainsReportPayload struct {
+	// Method lot eşleştirme yöntemi; boşsa fifo kullanılır
+	Method     LotMethod               `json:"method,omitempty"`
+	Portfolios []PortfolioTransactions `json:"portfolios" jsonschema:"minItems=1"`
+}
+
+// LotMethodOrDefault payload'ın lot eşleştirme yöntemini, boşsa fifo döndürür
+func (p RealizedGainsReportPayload) LotMethodOrDefault() LotMethod {
+	if p.Method == "" {
+		return LotFIFO
+	}
+	return p.Method
+}
+
+// NewRealizedGainsReportEvent yeni bir realized gains report event'i oluşturur
+func NewRealizedGainsReportEvent(method LotMethod, portfolios []PortfolioTransactions) (BaseEvent, error) {
+	return NewBaseEvent(RealizedGainsReport, RealizedGainsReportPayload{Method: method, Portfolios: portfolios})
+}
+
+// UserIDs payload'daki portföylerin kullanıcılarını portföy sırasıyla döndürür
+func (p RealizedGainsReportPayload) UserIDs() []string {
+	ids := make([]string, len(p.Portfolios))
+	for i, portfolio := range p.Portfolios {
+		ids[i] = portfolio.UserID
+	}
+	return ids
+}
+
+// Validate realized_gains.report payload'ını doğrular ve tüm alan hatalarını birlikte döndürür
+func (p RealizedGainsReportPayload) Validate() error {
+	var errs ValidationErrors
+	switch p.Method {
+	case "", LotFIFO, LotAverageCost:
+	default:
+		errs = append(errs, FieldError{Path: "method", Rule: RuleOneOf, Value: p.Method, Message: "must be one of fifo, average_cost"})
+	}
+	errs = append(errs, validateTransactionPortfolios(p.Portfolios)...)
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
@@ -0,0 +1,141 @@
+package event
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AssetClass bir enstrümanın varlık sınıfı
+type AssetClass string
+
+// Varlık sınıfları
+const (
+	AssetEquity      AssetClass = "equity"
+	AssetFixedIncome AssetClass = "fixed_income"
+	AssetCash        AssetClass = "cash"
+	AssetRealEstate  AssetClass = "real_estate"
+	AssetCommodity   AssetClass = "commodity"
+	AssetAlternative AssetClass = "alternative"
+)
+
+// AssetClasses tüm varlık sınıfları, raporlardaki sıralarıyla
+var AssetClasses = []AssetClass{
+	AssetEquity, AssetFixedIncome, AssetCash, AssetRealEstate, AssetCommodity, AssetAlternative,
+}
+
+// IsValid varlık sınıfının bilinen sınıflardan biri olup olmadığını döndürür
+func (c AssetClass) IsValid() bool {
+	for _, known := range AssetClasses {
+		if c == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Holding bir portföydeki tek bir pozisyon
+type Holding struct {
+	Instrument  string     `json:"instrument" jsonschema:"minLength=1"` // ör. AAPL
+	Description string     `json:"description,omitempty"`
+	AssetClass  AssetClass `json:"assetClass"`
+	Quantity    Decimal    `json:"quantity" jsonschema:"minimum=0"`
+	Price       Decimal    `json:"price" jsonschema:"minimum=0"`
+	Currency    Currency   `json:"currency,omitempty"` // fiyatın para birimi; boşsa raporlama para birimi
+}
+
+// CurrencyOr pozisyonun para birimini, boşsa fallback'i döndürür
+func (h Holding) CurrencyOr(fallback Currency) Currency {
+	if h.Currency == "" {
+		return fallback
+	}
+	return h.Currency
+}
+
+// MarketValue pozisyonun kendi para birimindeki yuvarlanmamış piyasa değerini
+// (miktar * fiyat) döndürür; para birimi boşsa fallback kullanılır
+func (h Holding) MarketValue(fallback Currency) Money {
+	return NewMoney(h.Quantity.Mul(h.Price), h.CurrencyOr(fallback))
+}
+
+// PortfolioHoldings bir portföyün belirli bir tarihteki pozisyonları
+type PortfolioHoldings struct {
+	PortID   int       `json:"portID" jsonschema:"minimum=1"`
+	Name     string    `json:"name" jsonschema:"minLength=1"`
+	UserID   string    `json:"userID" jsonschema:"minLength=1"`
+	AsOf     Timestamp `json:"asOf,omitempty"` // fiyatların geçerli olduğu tarih
+	Holdings []Holding `json:"holdings"`
+}
+
+// HoldingsReportPayload holdings.report olayının payload'ını tanımlar. Farklı
+// para birimlerindeki pozisyonlar CurrencySettings'e göre raporlama para birimine çevrilir.
+type HoldingsReportPayload struct {
+	CurrencySettings
+	Portfolios []PortfolioHoldings `json:"portfolios" jsonschema:"minItems=1"`
+}
+
+// NewHoldingsReportEvent yeni bir holdings report event'i oluşturur
+func NewHoldingsReportEvent(settings CurrencySettings, portfolios []PortfolioHoldings) (BaseEvent, error) {
+	return NewBaseEvent(HoldingsReport, HoldingsReportPayload{CurrencySettings: settings, Portfolios: portfolios})
+}
+
+// UserIDs payload'daki portföylerin kullanıcılarını portföy sırasıyla döndürür
+func (p HoldingsReportPayload) UserIDs() []string {
+	ids := make([]string, len(p.Portfolios))
+	for i, portfolio := range p.Portfolios {
+		ids[i] = portfolio.UserID
+	}
+	return ids
+}
+
+// Validate holdings.report payload'ını doğrular ve tüm alan hatalarını birlikte döndürür
+func (p HoldingsReportPayload) Validate() error {
+	var errs ValidationErrors
+	add := func(path, rule string, value interface{}, message string) {
+		errs = append(errs, FieldError{Path: path, Rule: rule, Value: value, Message: message})
+	}
+
+	if len(p.Portfolios) == 0 {
+		add("portfolios", RuleRequired, nil, "must contain at least one portfolio")
+	}
+	reporting := p.ReportingCurrencyOrDefault()
+	var used []Currency
+	seen := make(map[int]int)
+	for i, portfolio := range p.Portfolios {
+		path := fmt.Sprintf("portfolios[%d]", i)
+		if portfolio.PortID <= 0 {
+			add(path+".portID", RuleMin, portfolio.PortID, "must be a positive integer")
+		} else if first, dup := seen[portfolio.PortID]; dup {
+			add(path+".portID", RuleUnique, portfolio.PortID, fmt.Sprintf("duplicates portfolios[%d].portID", first))
+		} else {
+			seen[portfolio.PortID] = i
+		}
+		if strings.TrimSpace(portfolio.UserID) == "" {
+			add(path+".userID", RuleRequired, portfolio.UserID, "must not be blank")
+		}
+		checkTimestamp(add, path+".asOf", portfolio.AsOf, false)
+
+		for j, h := range portfolio.Holdings {
+			hpath := fmt.Sprintf("%s.holdings[%d]", path, j)
+			if strings.TrimSpace(h.Instrument) == "" {
+				add(hpath+".instrument", RuleRequired, h.Instrument, "must not be blank")
+			}
+			if !h.AssetClass.IsValid() {
+				add(hpath+".assetClass", RuleOneOf, h.AssetClass, "must be one of equity, fixed_income, cash, real_estate, commodity, alternative")
+			}
+			if checkDecimal(add, hpath+".quantity", h.Quantity) && h.Quantity.Sign() < 0 {
+				add(hpath+".quantity", RuleMin, h.Quantity.String(), "must not be negative")
+			}
+			if checkDecimal(add, hpath+".price", h.Price) && h.Price.Sign() < 0 {
+				add(hpath+".price", RuleMin, h.Price.String(), "must not be negative")
+			}
+			checkCurrency(add, hpath+".currency", h.Currency)
+			used = append(used, h.CurrencyOr(reporting))
+		}
+	}
+	p.CurrencySettings.validate(add, used)
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
@@ -0,0 +1,89 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestHoldingsReportPayload_Validate(t *testing.T) {
+	valid := HoldingsReportPayload{Portfolios: []PortfolioHoldings{{
+		PortID: 1, Name: "A", UserID: "u1",
+		Holdings: []Holding{{Instrument: "AAPL", AssetClass: AssetEquity, Quantity: NewDecimalFromInt(1), Price: NewDecimalFromInt(100)}},
+	}}}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate on valid payload returned %v", err)
+	}
+
+	invalid := HoldingsReportPayload{
+		CurrencySettings: CurrencySettings{
+			Rounding: "ceiling",
+			FXRates:  FXRates{{From: "EUR", To: "USD", Rate: NewDecimalFromInt(0)}},
+		},
+		Portfolios: []PortfolioHoldings{
+			{PortID: 1, Name: "A", UserID: "u1", Holdings: []Holding{
+				{AssetClass: AssetCash, Quantity: NewDecimalFromInt(1), Price: NewDecimalFromInt(1)},
+				{Instrument: "X", AssetClass: "crypto", Quantity: NewDecimalFromInt(-1), Price: NewDecimal(-2, 0)},
+				{Instrument: "VOD", AssetClass: AssetEquity, Currency: "GBP"},
+				{Instrument: "Y", AssetClass: AssetEquity, Currency: "XXX"},
+			}},
+			{PortID: 1, Name: "B", UserID: " "},
+		},
+	}
+	var verrs ValidationErrors
+	if !errors.As(invalid.Validate(), &verrs) {
+		t.Fatal("Expected ValidationErrors")
+	}
+	want := map[string]string{
+		"portfolios[0].holdings[0].instrument": RuleRequired,
+		"portfolios[0].holdings[1].assetClass": RuleOneOf,
+		"portfolios[0].holdings[1].quantity":   RuleMin,
+		"portfolios[0].holdings[1].price":      RuleMin,
+		"portfolios[0].holdings[3].currency":   RuleOneOf,
+		"portfolios[1].portID":                 RuleUnique,
+		"portfolios[1].userID":                 RuleRequired,
+		"rounding":                             RuleOneOf,
+		"fxRates[0].rate":                      RuleMin,
+		"fxRates":                              RuleRequired, // GBP -> USD
+	}
+	if len(verrs) != len(want) {
+		t.Errorf("Got %d errors, want %d: %v", len(verrs), len(want), verrs)
+	}
+	for _, fe := range verrs {
+		if rule, ok := want[fe.Path]; !ok || rule != fe.Rule {
+			t.Errorf("Unexpected error %s (%s)", fe.Path, fe.Rule)
+		}
+	}
+}
+
+func TestHoldingsReportPayload_JSON(t *testing.T) {
+	data := []byte(`{"reportingCurrency":"EUR","fxRates":[{"from":"USD","to":"EUR","rate":0.92}],
+		"portfolios":[{"portID":1,"name":"A","userID":"u1","holdings":[
+			{"instrument":"AAPL","assetClass":"equity","quantity":10,"price":"189.95","currency":"USD"}]}]}`)
+
+	var p HoldingsReportPayload
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if p.ReportingCurrency != "EUR" || p.FXRates[0].Rate.String() != "0.92" {
+		t.Errorf("Currency settings not decoded: %+v", p.CurrencySettings)
+	}
+	if got := p.Portfolios[0].Holdings[0].MarketValue("EUR"); got.String() != "1899.50 USD" {
+		t.Errorf("MarketValue = %s; want 1899.50 USD", got)
+	}
+	if err := p.Validate(); err != nil {
+		t.Errorf("Validate returned %v", err)
+	}
+
+	// An out-of-range exponent is reported as a field error, not a decode failure
+	bad := []byte(`{"portfolios":[{"portID":1,"name":"A","userID":"u1","holdings":[
+		{"instrument":"AAPL","assetClass":"equity","quantity":1e50000000,"price":"1"}]}]}`)
+	var q HoldingsReportPayload
+	if err := json.Unmarshal(bad, &q); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	var verrs ValidationErrors
+	if !errors.As(q.Validate(), &verrs) || len(verrs) != 1 || verrs[0].Rule != RuleDecimal || verrs[0].Path != "portfolios[0].holdings[0].quantity" {
+		t.Errorf("Expected a decimal error on quantity, got %v", verrs)
+	}
+}
@@ -0,0 +1,135 @@
+package event
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IncomeType bir gelir ödemesinin türü
+type IncomeType string
+
+// Gelir türleri
+const (
+	IncomeDividend IncomeType = "dividend"
+	IncomeCoupon   IncomeType = "coupon"
+	IncomeInterest IncomeType = "interest"
+)
+
+// IncomeTypes tüm gelir türleri, raporlardaki sıralarıyla
+var IncomeTypes = []IncomeType{IncomeDividend, IncomeCoupon, IncomeInterest}
+
+// IncomePayment tek bir temettü, kupon ya da faiz ödemesi. Tutarlar portföyün para birimindedir.
+type IncomePayment struct {
+	Date           Timestamp  `json:"date"`
+	Type           IncomeType `json:"type"`
+	Instrument     string     `json:"instrument,omitempty"` // temettü ve kuponlarda zorunlu
+	Gross          Decimal    `json:"gross" jsonschema:"minimum=0"`
+	WithholdingTax Decimal    `json:"withholdingTax,omitempty" jsonschema:"minimum=0"` // kaynakta kesilen vergi
+	Description    string     `json:"description,omitempty"`
+}
+
+// Net ödemenin stopaj sonrası tutarı
+func (p IncomePayment) Net() Decimal {
+	return p.Gross.Sub(p.WithholdingTax)
+}
+
+// PortfolioIncome bir portföyün gelir ödemeleri
+type PortfolioIncome struct {
+	PortID   int             `json:"portID" jsonschema:"minimum=1"`
+	Name     string          `json:"name" jsonschema:"minLength=1"`
+	UserID   string          `json:"userID" jsonschema:"minLength=1"`
+	Currency Currency        `json:"currency,omitempty"` // ödemelerin para birimi; boşsa USD
+	Payments []IncomePayment `json:"payments"`
+}
+
+// IncomeReportPayload income.report olayının payload'ını tanımlar. Rapor dönemi
+// portfolio.report ile aynı parametrelerle (ReportPeriod) verilir ve sınırlı olmalıdır:
+// ya adlandırılmış bir dönem ya da periodStart ve periodEnd birlikte. Dönem dışındaki
+// ve as-of tarihinden sonraki ödemeler yok sayılır.
+type IncomeReportPayload struct {
+	ReportPeriod
+	Portfolios []PortfolioIncome `json:"portfolios" jsonschema:"minItems=1"`
+}
+
+// NewIncomeReportEvent yeni bir income report event'i oluşturur
+func NewIncomeReportEvent(period ReportPeriod, portfolios []PortfolioIncome) (BaseEvent, error) {
+	return NewBaseEvent(IncomeReport, IncomeReportPayload{ReportPeriod: period, Portfolios: portfolios})
+}
+
+// UserIDs payload'daki portföylerin kullanıcılarını portföy sırasıyla döndürür
+func (p IncomeReportPayload) UserIDs() []string {
+	ids := make([]string, len(p.Portfolios))
+	for i, portfolio := range p.Portfolios {
+		ids[i] = portfolio.UserID
+	}
+	return ids
+}
+
+// Validate income.report payload'ını doğrular ve tüm alan hatalarını birlikte döndürür
+func (p IncomeReportPayload) Validate() error {
+	var errs ValidationErrors
+	add := func(path, rule string, value interface{}, message string) {
+		errs = append(errs, FieldError{Path: path, Rule: rule, Value: value, Message: message})
+	}
+
+	p.ReportPeriod.validate(add)
+	if p.Period == "" {
+		// Aylık tablolar için dönemin iki sınırı da gerekir
+		if p.PeriodStart == nil {
+			add("periodStart", RuleRequired, nil, "must be set unless period is given")
+		}
+		if p.PeriodEnd == nil {
+			add("periodEnd", RuleRequired, nil, "must be set unless period is given")
+		}
+	}
+
+	if len(p.Portfolios) == 0 {
+		add("portfolios", RuleRequired, nil, "must contain at least one portfolio")
+	}
+	seen := make(map[int]int)
+	for i, portfolio := range p.Portfolios {
+		path := fmt.Sprintf("portfolios[%d]", i)
+		if portfolio.PortID <= 0 {
+			add(path+".portID", RuleMin, portfolio.PortID, "must be a positive integer")
+		} else if first, dup := seen[portfolio.PortID]; dup {
+			add(path+".portID", RuleUnique, portfolio.PortID, fmt.Sprintf("duplicates portfolios[%d].portID", first))
+		} else {
+			seen[portfolio.PortID] = i
+		}
+		if strings.TrimSpace(portfolio.UserID) == "" {
+			add(path+".userID", RuleRequired, portfolio.UserID, "must not be blank")
+		}
+		checkCurrency(add, path+".currency", portfolio.Currency)
+
+		for j, pay := range portfolio.Payments {
+			ppath := fmt.Sprintf("%s.payments[%d]", path, j)
+			checkTimestamp(add, ppath+".date", pay.Date, true)
+			switch pay.Type {
+			case IncomeDividend, IncomeCoupon:
+				if strings.TrimSpace(pay.Instrument) == "" {
+					add(ppath+".instrument", RuleRequired, pay.Instrument, "must not be blank for "+string(pay.Type))
+				}
+			case IncomeInterest:
+			default:
+				add(ppath+".type", RuleOneOf, pay.Type, "must be one of dividend, coupon, interest")
+			}
+			grossOK := checkDecimal(add, ppath+".gross", pay.Gross)
+			if grossOK && pay.Gross.Sign() <= 0 {
+				add(ppath+".gross", RuleMin, pay.Gross.String(), "must be positive")
+			}
+			if !checkDecimal(add, ppath+".withholdingTax", pay.WithholdingTax) {
+				continue
+			}
+			if pay.WithholdingTax.Sign() < 0 {
+				add(ppath+".withholdingTax", RuleMin, pay.WithholdingTax.String(), "must not be negative")
+			} else if grossOK && pay.WithholdingTax.Cmp(pay.Gross) > 0 && pay.Gross.Sign() > 0 {
+				add(ppath+".withholdingTax", RuleMax, pay.WithholdingTax.String(), "must not exceed gross")
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
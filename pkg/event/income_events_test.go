@@ -0,0 +1,58 @@
+package event
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIncomeReportPayload_Validate(t *testing.T) {
+	ts := func(s string) *Timestamp { v := MustParseTimestamp(s); return &v }
+	valid := IncomeReportPayload{
+		ReportPeriod: ReportPeriod{Period: "2024"},
+		Portfolios: []PortfolioIncome{{PortID: 1, Name: "A", UserID: "u1", Payments: []IncomePayment{
+			{Date: MustParseTimestamp("2024-03-01"), Type: IncomeInterest, Gross: MustParseDecimal("5")},
+		}}},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate on valid payload returned %v", err)
+	}
+
+	invalid := IncomeReportPayload{
+		ReportPeriod: ReportPeriod{PeriodStart: ts("2024-12-31"), PeriodEnd: ts("2024-01-01")},
+		Portfolios: []PortfolioIncome{{PortID: 1, Name: "A", UserID: "u1", Payments: []IncomePayment{
+			{Date: MustParseTimestamp("2024-03-01"), Type: IncomeDividend, Gross: MustParseDecimal("10"), WithholdingTax: MustParseDecimal("11")},
+			{Date: MustParseTimestamp("2024-03-01"), Type: "rent", Gross: MustParseDecimal("0")},
+		}}},
+	}
+	var verrs ValidationErrors
+	if !errors.As(invalid.Validate(), &verrs) {
+		t.Fatal("Expected ValidationErrors")
+	}
+	want := map[string]string{
+		"periodEnd":                                RuleMin,
+		"portfolios[0].payments[0].instrument":     RuleRequired,
+		"portfolios[0].payments[0].withholdingTax": RuleMax,
+		"portfolios[0].payments[1].type":           RuleOneOf,
+		"portfolios[0].payments[1].gross":          RuleMin,
+	}
+	if len(verrs) != len(want) {
+		t.Errorf("Got %d errors, want %d: %v", len(verrs), len(want), verrs)
+	}
+	for _, fe := range verrs {
+		if rule, ok := want[fe.Path]; !ok || rule != fe.Rule {
+			t.Errorf("Unexpected error %s (%s)", fe.Path, fe.Rule)
+		}
+	}
+}
+
+func TestIncomeReportPayload_ValidateUnboundedPeriod(t *testing.T) {
+	ts := func(s string) *Timestamp { v := MustParseTimestamp(s); return &v }
+	payload := IncomeReportPayload{
+		ReportPeriod: ReportPeriod{PeriodStart: ts("2024-01-01")},
+		Portfolios:   []PortfolioIncome{{PortID: 1, Name: "A", UserID: "u1"}},
+	}
+	var verrs ValidationErrors
+	if !errors.As(payload.Validate(), &verrs) || len(verrs) != 1 || verrs[0].Path != "periodEnd" || verrs[0].Rule != RuleRequired {
+		t.Errorf("Expected a required error on periodEnd, got %v", payload.Validate())
+	}
+}
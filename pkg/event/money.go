@@ -0,0 +1,227 @@
+package event
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Currency ISO 4217 para birimi kodu, ör. USD
+type Currency string
+
+// DefaultCurrency raporlama para birimi belirtilmediğinde kullanılan para birimi
+const DefaultCurrency Currency = "USD"
+
+// currencyMinorUnits desteklenen ISO 4217 para birimlerinin ondalık basamak sayıları
+var currencyMinorUnits = map[Currency]int32{
+	"AED": 2, "AUD": 2, "BHD": 3, "BRL": 2, "CAD": 2, "CHF": 2, "CLP": 0, "CNY": 2,
+	"CZK": 2, "DKK": 2, "EUR": 2, "GBP": 2, "HKD": 2, "HUF": 2, "IDR": 2, "ILS": 2,
+	"INR": 2, "ISK": 0, "JOD": 3, "JPY": 0, "KRW": 0, "KWD": 3, "MXN": 2, "NOK": 2,
+	"NZD": 2, "OMR": 3, "PLN": 2, "QAR": 2, "RON": 2, "SAR": 2, "SEK": 2, "SGD": 2,
+	"THB": 2, "TND": 3, "TRY": 2, "TWD": 2, "USD": 2, "ZAR": 2,
+}
+
+// IsValid para biriminin desteklenen bir ISO 4217 kodu olup olmadığını döndürür
+func (c Currency) IsValid() bool {
+	_, ok := currencyMinorUnits[c]
+	return ok
+}
+
+// MinorUnits para biriminin ondalık basamak sayısını döndürür (USD 2, JPY 0, KWD 3)
+func (c Currency) MinorUnits() int32 {
+	if units, ok := currencyMinorUnits[c]; ok {
+		return units
+	}
+	return 2
+}
+
+// OrDefault para birimini, boşsa DefaultCurrency döndürür
+func (c Currency) OrDefault() Currency {
+	if c == "" {
+		return DefaultCurrency
+	}
+	return c
+}
+
+// checkCurrency opsiyonel bir para birimi alanını doğrular
+func checkCurrency(add func(path, rule string, value interface{}, message string), path string, c Currency) {
+	if c != "" && !c.IsValid() {
+		add(path, RuleOneOf, c, "must be a supported ISO 4217 currency code")
+	}
+}
+
+// Money bir para birimindeki kesin tutar
+type Money struct {
+	Amount   Decimal  `json:"amount"`
+	Currency Currency `json:"currency"`
+}
+
+// NewMoney tutar ve para biriminden bir Money oluşturur
+func NewMoney(amount Decimal, currency Currency) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// ErrCurrencyMismatch farklı para birimlerindeki tutarlar birleştirilmeye çalışıldığında döner
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// Add aynı para birimindeki iki tutarı toplar
+func (m Money) Add(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return m, fmt.Errorf("%w: %s + %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	return Money{Amount: m.Amount.Add(o.Amount), Currency: m.Currency}, nil
+}
+
+// Round tutarı para biriminin ondalık basamak sayısına yuvarlar
+func (m Money) Round(mode RoundingMode) Money {
+	return Money{Amount: m.Amount.Round(m.Currency.MinorUnits(), mode), Currency: m.Currency}
+}
+
+// IsZero tutarın sıfır olup olmadığını döndürür
+func (m Money) IsZero() bool {
+	return m.Amount.IsZero()
+}
+
+// String tutarı para birimiyle birlikte döndürür, ör. "12.34 USD"
+func (m Money) String() string {
+	return m.Amount.String() + " " + string(m.Currency)
+}
+
+// fxRateScale ters ve çapraz kurların hesaplandığı ondalık basamak sayısı
+const fxRateScale = 12
+
+// ErrNoFXRate iki para birimi arasında kur bulunamadığında döner
+var ErrNoFXRate = errors.New("no fx rate")
+
+// FXRate bir döviz kuru: 1 birim From = Rate birim To
+type FXRate struct {
+	From Currency `json:"from"`
+	To   Currency `json:"to"`
+	Rate Decimal  `json:"rate"`
+}
+
+// FXRates payload ile gönderilen kur tablosu. Kurlar her iki yönde kullanılır;
+// doğrudan kur yoksa ortak bir para birimi üzerinden çapraz kur hesaplanır.
+type FXRates []FXRate
+
+// Rate from para biriminden to para birimine kuru döndürür
+func (r FXRates) Rate(from, to Currency) (Decimal, error) {
+	if from == to {
+		return NewDecimalFromInt(1), nil
+	}
+	if rate, ok := r.direct(from, to); ok {
+		return rate, nil
+	}
+	// Çapraz kur: from -> ara -> to
+	for _, via := range r.currencies() {
+		if via == from || via == to {
+			continue
+		}
+		first, ok := r.direct(from, via)
+		if !ok {
+			continue
+		}
+		if second, ok := r.direct(via, to); ok {
+			return first.Mul(second).Round(fxRateScale, RoundHalfEven), nil
+		}
+	}
+	return Decimal{}, fmt.Errorf("%w: %s -> %s", ErrNoFXRate, from, to)
+}
+
+// Convert tutarı to para birimine çevirir ve to'nun ondalık basamak sayısına
+// verilen kurala göre yuvarlar. Yuvarlama yalnızca bir kez, çevrimden sonra yapılır.
+func (r FXRates) Convert(m Money, to Currency, mode RoundingMode) (Money, error) {
+	rate, err := r.Rate(m.Currency, to)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: m.Amount.Mul(rate), Currency: to}.Round(mode), nil
+}
+
+// direct tablodaki doğrudan ya da ters kuru döndürür
+func (r FXRates) direct(from, to Currency) (Decimal, bool) {
+	for _, rate := range r {
+		if rate.From == from && rate.To == to {
+			return rate.Rate, true
+		}
+	}
+	for _, rate := range r {
+		if rate.From == to && rate.To == from && !rate.Rate.IsZero() {
+			return NewDecimalFromInt(1).Div(rate.Rate, fxRateScale, RoundHalfEven), true
+		}
+	}
+	return Decimal{}, false
+}
+
+// currencies tabloda geçen para birimlerini ilk görülme sırasıyla döndürür
+func (r FXRates) currencies() []Currency {
+	seen := make(map[Currency]bool)
+	var out []Currency
+	for _, rate := range r {
+		for _, c := range []Currency{rate.From, rate.To} {
+			if !seen[c] {
+				seen[c] = true
+				out = append(out, c)
+			}
+		}
+	}
+	return out
+}
+
+// CurrencySettings çok para birimli payload'ların ortak raporlama ayarları:
+// tutarların çevrileceği para birimi, kur tablosu ve yuvarlama kuralı
+type CurrencySettings struct {
+	ReportingCurrency Currency     `json:"reportingCurrency,omitempty"` // boşsa USD
+	FXRates           FXRates      `json:"fxRates,omitempty"`
+	Rounding          RoundingMode `json:"rounding,omitempty"` // boşsa half_up
+}
+
+// ReportingCurrencyOrDefault raporlama para birimini, boşsa DefaultCurrency döndürür
+func (s CurrencySettings) ReportingCurrencyOrDefault() Currency {
+	return s.ReportingCurrency.OrDefault()
+}
+
+// RoundingOrDefault yuvarlama kuralını, boşsa DefaultRoundingMode döndürür
+func (s CurrencySettings) RoundingOrDefault() RoundingMode {
+	if s.Rounding == "" {
+		return DefaultRoundingMode
+	}
+	return s.Rounding
+}
+
+// ToReporting tutarı raporlama para birimine çevirip yuvarlar
+func (s CurrencySettings) ToReporting(m Money) (Money, error) {
+	return s.FXRates.Convert(m, s.ReportingCurrencyOrDefault(), s.RoundingOrDefault())
+}
+
+// validate ayarları doğrular; used, payload'da geçen para birimleridir ve her
+// biri için raporlama para birimine bir kur bulunmalıdır
+func (s CurrencySettings) validate(add func(path, rule string, value interface{}, message string), used []Currency) {
+	checkCurrency(add, "reportingCurrency", s.ReportingCurrency)
+	if s.Rounding != "" && !s.Rounding.IsValid() {
+		add("rounding", RuleOneOf, s.Rounding, "must be one of half_up, half_even, down")
+	}
+	for i, rate := range s.FXRates {
+		path := fmt.Sprintf("fxRates[%d]", i)
+		if !rate.From.IsValid() {
+			add(path+".from", RuleOneOf, rate.From, "must be a supported ISO 4217 currency code")
+		}
+		if !rate.To.IsValid() {
+			add(path+".to", RuleOneOf, rate.To, "must be a supported ISO 4217 currency code")
+		}
+		if checkDecimal(add, path+".rate", rate.Rate) && rate.Rate.Sign() <= 0 {
+			add(path+".rate", RuleMin, rate.Rate.String(), "must be positive")
+		}
+	}
+
+	reporting := s.ReportingCurrencyOrDefault()
+	missing := make(map[Currency]bool)
+	for _, c := range used {
+		if !c.IsValid() || missing[c] {
+			continue
+		}
+		if _, err := s.FXRates.Rate(c, reporting); err != nil {
+			missing[c] = true
+			add("fxRates", RuleRequired, string(c), fmt.Sprintf("must contain a rate from %s to %s", c, reporting))
+		}
+	}
+}
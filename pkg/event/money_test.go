@@ -0,0 +1,68 @@
+package event
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCurrency(t *testing.T) {
+	if !Currency("USD").IsValid() || Currency("usd").IsValid() || Currency("XXX").IsValid() {
+		t.Error("Unexpected currency validity")
+	}
+	if Currency("JPY").MinorUnits() != 0 || Currency("KWD").MinorUnits() != 3 || Currency("EUR").MinorUnits() != 2 {
+		t.Error("Unexpected minor units")
+	}
+}
+
+func TestMoney_Add(t *testing.T) {
+	a := NewMoney(MustParseDecimal("10.10"), "USD")
+	sum, err := a.Add(NewMoney(MustParseDecimal("0.05"), "USD"))
+	if err != nil || sum.String() != "10.15 USD" {
+		t.Errorf("Add = %s, %v; want 10.15 USD", sum, err)
+	}
+	if _, err := a.Add(NewMoney(MustParseDecimal("1"), "EUR")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected ErrCurrencyMismatch, got %v", err)
+	}
+}
+
+func TestFXRates_Convert(t *testing.T) {
+	rates := FXRates{
+		{From: "EUR", To: "USD", Rate: MustParseDecimal("1.0825")},
+		{From: "USD", To: "JPY", Rate: MustParseDecimal("151.37")},
+	}
+
+	cases := []struct {
+		amount string
+		from   Currency
+		to     Currency
+		mode   RoundingMode
+		want   string
+	}{
+		{"100", "EUR", "USD", RoundHalfUp, "108.25 USD"},    // direct
+		{"108.25", "USD", "EUR", RoundHalfUp, "100.00 EUR"}, // inverse
+		{"100", "EUR", "JPY", RoundHalfUp, "16386 JPY"},     // cross via USD, no minor units
+		{"0.125", "USD", "USD", RoundHalfEven, "0.12 USD"},  // same currency, still rounded
+		{"0.125", "USD", "USD", RoundHalfUp, "0.13 USD"},
+	}
+	for _, c := range cases {
+		got, err := rates.Convert(NewMoney(MustParseDecimal(c.amount), c.from), c.to, c.mode)
+		if err != nil || got.String() != c.want {
+			t.Errorf("Convert(%s %s -> %s) = %s, %v; want %s", c.amount, c.from, c.to, got, err, c.want)
+		}
+	}
+
+	if _, err := rates.Convert(NewMoney(NewDecimalFromInt(1), "GBP"), "USD", RoundHalfUp); !errors.Is(err, ErrNoFXRate) {
+		t.Errorf("Expected ErrNoFXRate, got %v", err)
+	}
+}
+
+func TestCurrencySettings_Defaults(t *testing.T) {
+	var s CurrencySettings
+	if s.ReportingCurrencyOrDefault() != DefaultCurrency || s.RoundingOrDefault() != DefaultRoundingMode {
+		t.Error("Unexpected defaults")
+	}
+	got, err := s.ToReporting(NewMoney(MustParseDecimal("2.675"), "USD"))
+	if err != nil || got.String() != "2.68 USD" {
+		t.Errorf("ToReporting = %s, %v; want 2.68 USD", got, err)
+	}
+}
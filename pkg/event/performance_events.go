@@ -0,0 +1,106 @@
+package event
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Valuation bir portföyün belirli bir tarihteki toplam piyasa değeri
+type Valuation struct {
+	Date  Timestamp `json:"date"`
+	Value Decimal   `json:"value" jsonschema:"minimum=0"`
+}
+
+// CashFlow portföye dışarıdan giren (pozitif) ya da portföyden çıkan (negatif) nakit
+type CashFlow struct {
+	Date        Timestamp `json:"date"`
+	Amount      Decimal   `json:"amount"`
+	Description string    `json:"description,omitempty"`
+}
+
+// PortfolioPerformance bir portföyün değer zaman serisi ve dış nakit akışları
+type PortfolioPerformance struct {
+	PortID     int         `json:"portID" jsonschema:"minimum=1"`
+	Name       string      `json:"name" jsonschema:"minLength=1"`
+	UserID     string      `json:"userID" jsonschema:"minLength=1"`
+	Currency   Currency    `json:"currency,omitempty"` // değerleme ve akışların para birimi; boşsa USD
+	Valuations []Valuation `json:"valuations" jsonschema:"minItems=2"`
+	CashFlows  []CashFlow  `json:"cashFlows,omitempty"`
+}
+
+// PerformanceReportPayload performance.report olayının payload'ını tanımlar
+type PerformanceReportPayload struct {
+	Portfolios []PortfolioPerformance `json:"portfolios" jsonschema:"minItems=1"`
+}
+
+// NewPerformanceReportEvent yeni bir performance report event'i oluşturur
+func NewPerformanceReportEvent(portfolios []PortfolioPerformance) (BaseEvent, error) {
+	return NewBaseEvent(PerformanceReport, PerformanceReportPayload{Portfolios: portfolios})
+}
+
+// UserIDs payload'daki portföylerin kullanıcılarını portföy sırasıyla döndürür
+func (p PerformanceReportPayload) UserIDs() []string {
+	ids := make([]string, len(p.Portfolios))
+	for i, portfolio := range p.Portfolios {
+		ids[i] = portfolio.UserID
+	}
+	return ids
+}
+
+// Validate performance.report payload'ını doğrular ve tüm alan hatalarını birlikte döndürür
+func (p PerformanceReportPayload) Validate() error {
+	var errs ValidationErrors
+	add := func(path, rule string, value interface{}, message string) {
+		errs = append(errs, FieldError{Path: path, Rule: rule, Value: value, Message: message})
+	}
+
+	if len(p.Portfolios) == 0 {
+		add("portfolios", RuleRequired, nil, "must contain at least one portfolio")
+	}
+	seen := make(map[int]int)
+	for i, portfolio := range p.Portfolios {
+		path := fmt.Sprintf("portfolios[%d]", i)
+		if portfolio.PortID <= 0 {
+			add(path+".portID", RuleMin, portfolio.PortID, "must be a positive integer")
+		} else if first, dup := seen[portfolio.PortID]; dup {
+			add(path+".portID", RuleUnique, portfolio.PortID, fmt.Sprintf("duplicates portfolios[%d].portID", first))
+		} else {
+			seen[portfolio.PortID] = i
+		}
+		if strings.TrimSpace(portfolio.UserID) == "" {
+			add(path+".userID", RuleRequired, portfolio.UserID, "must not be blank")
+		}
+		checkCurrency(add, path+".currency", portfolio.Currency)
+
+		if len(portfolio.Valuations) < 2 {
+			add(path+".valuations", RuleMin, len(portfolio.Valuations), "must contain at least two valuations")
+		}
+		dates := make(map[string]int)
+		for j, v := range portfolio.Valuations {
+			vpath := fmt.Sprintf("%s.valuations[%d]", path, j)
+			if checkTimestamp(add, vpath+".date", v.Date, true) {
+				if first, dup := dates[v.Date.String()]; dup {
+					add(vpath+".date", RuleUnique, v.Date.String(), fmt.Sprintf("duplicates %s.valuations[%d].date", path, first))
+				} else {
+					dates[v.Date.String()] = j
+				}
+			}
+			if checkDecimal(add, vpath+".value", v.Value) && v.Value.Sign() < 0 {
+				add(vpath+".value", RuleMin, v.Value.String(), "must not be negative")
+			}
+		}
+
+		for j, f := range portfolio.CashFlows {
+			fpath := fmt.Sprintf("%s.cashFlows[%d]", path, j)
+			checkTimestamp(add, fpath+".date", f.Date, true)
+			if checkDecimal(add, fpath+".amount", f.Amount) && f.Amount.IsZero() {
+				add(fpath+".amount", RuleRequired, f.Amount.String(), "must not be zero")
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
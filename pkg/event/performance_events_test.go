@@ -0,0 +1,50 @@
+package event
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPerformanceReportPayload_Validate(t *testing.T) {
+	valid := PerformanceReportPayload{Portfolios: []PortfolioPerformance{{
+		PortID: 1, Name: "A", UserID: "u1",
+		Valuations: []Valuation{
+			{Date: MustParseTimestamp("2024-01-01"), Value: MustParseDecimal("100")},
+			{Date: MustParseTimestamp("2024-02-01"), Value: MustParseDecimal("105")},
+		},
+		CashFlows: []CashFlow{{Date: MustParseTimestamp("2024-01-15"), Amount: MustParseDecimal("-10")}},
+	}}}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate on valid payload returned %v", err)
+	}
+
+	invalid := PerformanceReportPayload{Portfolios: []PortfolioPerformance{
+		{PortID: 1, Name: "A", UserID: "u1",
+			Valuations: []Valuation{
+				{Date: MustParseTimestamp("2024-01-01"), Value: MustParseDecimal("100")},
+				{Date: MustParseTimestamp("2024-01-01"), Value: MustParseDecimal("-1")},
+			},
+			CashFlows: []CashFlow{{Amount: MustParseDecimal("0")}},
+		},
+		{PortID: 2, Name: "B", UserID: "u2", Valuations: []Valuation{{Date: MustParseTimestamp("2024-01-01")}}},
+	}}
+	var verrs ValidationErrors
+	if !errors.As(invalid.Validate(), &verrs) {
+		t.Fatal("Expected ValidationErrors")
+	}
+	want := map[string]string{
+		"portfolios[0].valuations[1].date":  RuleUnique,
+		"portfolios[0].valuations[1].value": RuleMin,
+		"portfolios[0].cashFlows[0].date":   RuleRequired,
+		"portfolios[0].cashFlows[0].amount": RuleRequired,
+		"portfolios[1].valuations":          RuleMin,
+	}
+	if len(verrs) != len(want) {
+		t.Errorf("Got %d errors, want %d: %v", len(verrs), len(want), verrs)
+	}
+	for _, fe := range verrs {
+		if rule, ok := want[fe.Path]; !ok || rule != fe.Rule {
+			t.Errorf("Unexpected error %s (%s)", fe.Path, fe.Rule)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package event
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReportPeriod rapor isteklerinin dönem parametreleri. Dönem ya adıyla (Period) ya da
+// başlangıç/bitiş tarihleriyle verilir; AsOf raporun hangi tarihteki durumu gösterdiğidir.
+// Hiçbiri verilmezse rapor dönemsizdir ve tüm veriyi kapsar.
+type ReportPeriod struct {
+	// Period adlandırılmış dönem: last-month, last-quarter, last-year, mtd, qtd, ytd,
+	// 2026, H1-2026, Q3-2026 ya da 2026-09
+	Period      string     `json:"period,omitempty"`
+	PeriodStart *Timestamp `json:"periodStart,omitempty"` // dahil
+	PeriodEnd   *Timestamp `json:"periodEnd,omitempty"`   // dahil; yalnızca tarih verilirse günün sonuna kadar
+	AsOf        *Timestamp `json:"asOf,omitempty"`        // boşsa dönem sonu ya da şu an (hangisi önceyse)
+}
+
+// IsSet herhangi bir dönem parametresinin verilip verilmediğini döndürür
+func (p ReportPeriod) IsSet() bool {
+	return p.Period != "" || p.PeriodStart != nil || p.PeriodEnd != nil || p.AsOf != nil
+}
+
+// ResolvedPeriod takvim kurallarıyla çözülmüş rapor dönemi. Sınırlar UTC'dir.
+type ResolvedPeriod struct {
+	Name  string    // adlandırılmış dönem, ör. "Q3-2026"; tarihlerle verildiyse boş
+	Start time.Time // dahil; sıfırsa alt sınır yok
+	End   time.Time // hariç; sıfırsa üst sınır yok
+	AsOf  time.Time // raporun gösterdiği an (dahil)
+}
+
+// IsZero dönemin çözülmemiş (dönemsiz rapor) olup olmadığını döndürür
+func (r ResolvedPeriod) IsZero() bool {
+	return r.AsOf.IsZero()
+}
+
+// Contains t'nin dönem içinde olup olmadığını döndürür
+func (r ResolvedPeriod) Contains(t time.Time) bool {
+	if !r.Start.IsZero() && t.Before(r.Start) {
+		return false
+	}
+	return r.End.IsZero() || t.Before(r.End)
+}
+
+// Label dönemi raporlarda gösterilecek biçimde döndürür,
+// ör. "Q3-2026 (2026-07-01 to 2026-09-30), as of 2026-09-30"
+func (r ResolvedPeriod) Label() string {
+	if r.IsZero() {
+		return ""
+	}
+	const layout = "2006-01-02"
+
+	var bounds string
+	switch {
+	case !r.Start.IsZero() && !r.End.IsZero():
+		bounds = fmt.Sprintf("%s to %s", r.Start.Format(layout), r.End.Add(-time.Nanosecond).Format(layout))
+	case !r.Start.IsZero():
+		bounds = "from " + r.Start.Format(layout)
+	case !r.End.IsZero():
+		bounds = "until " + r.End.Add(-time.Nanosecond).Format(layout)
+	}
+
+	asOf := r.AsOf.Format(layout)
+	switch {
+	case r.Name != "" && bounds != "":
+		return fmt.Sprintf("%s (%s), as of %s", r.Name, bounds, asOf)
+	case bounds != "":
+		return fmt.Sprintf("%s, as of %s", bounds, asOf)
+	default:
+		return "As of " + asOf
+	}
+}
+
+// Resolve dönem parametrelerini now anına göre çözer. Adlandırılmış dönemler AsOf
+// verilmişse ona, verilmemişse now'a göre hesaplanır. AsOf verilmezse dönem sonu ile
+// now'dan önce olanı kullanılır. Parametre verilmemişse sıfır değer döner.
+func (p ReportPeriod) Resolve(now time.Time) (ResolvedPeriod, error) {
+	if !p.IsSet() {
+		return ResolvedPeriod{}, nil
+	}
+	now = now.UTC()
+
+	var r ResolvedPeriod
+	ref := now
+	if p.AsOf != nil {
+		ref = p.AsOf.UTC()
+	}
+	if p.Period != "" {
+		start, end, err := ParseNamedPeriod(p.Period, ref)
+		if err != nil {
+			return ResolvedPeriod{}, err
+		}
+		r.Name, r.Start, r.End = canonicalPeriodName(p.Period), start, end
+	}
+	if p.PeriodStart != nil {
+		r.Start = p.PeriodStart.UTC()
+	}
+	if p.PeriodEnd != nil {
+		r.End = exclusiveEnd(p.PeriodEnd.UTC())
+	}
+
+	switch {
+	case p.AsOf != nil:
+		r.AsOf = p.AsOf.UTC()
+	case !r.End.IsZero() && r.End.Before(now):
+		r.AsOf = r.End.Add(-time.Nanosecond)
+	default:
+		r.AsOf = now
+	}
+	return r, nil
+}
+
+// exclusiveEnd dahil bir bitiş sınırını hariç sınıra çevirir: yalnızca tarih içeren
+// sınırlar günün sonuna kadar kapsanır
+func exclusiveEnd(t time.Time) time.Time {
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		return t.AddDate(0, 0, 1)
+	}
+	return t.Add(time.Nanosecond)
+}
+
+// Adlandırılmış mutlak dönem kalıpları
+var (
+	yearPattern    = regexp.MustCompile(`^(\d{4})$`)
+	halfPattern    = regexp.MustCompile(`^H([12])-(\d{4})$`)
+	quarterPattern = regexp.MustCompile(`^Q([1-4])-(\d{4})$`)
+	monthPattern   = regexp.MustCompile(`^(\d{4})-(\d{2})$`)
+)
+
+// canonicalPeriodName dönem adını raporlarda gösterilecek biçime getirir:
+// göreli dönemler küçük (last-month), mutlak dönemler büyük harfle (Q3-2026)
+func canonicalPeriodName(name string) string {
+	name = strings.TrimSpace(name)
+	switch lower := strings.ToLower(name); lower {
+	case "last-month", "last-quarter", "last-year", "mtd", "qtd", "ytd":
+		return lower
+	}
+	return strings.ToUpper(name)
+}
+
+// ParseNamedPeriod adlandırılmış bir dönemi [start, end) aralığına çevirir. Göreli
+// dönemler (last-month, ytd, ...) ref tarihine göre hesaplanır; "to-date" dönemleri
+// (mtd, qtd, ytd) ref gününün sonunda biter. Büyük/küçük harf duyarsızdır.
+func ParseNamedPeriod(name string, ref time.Time) (time.Time, time.Time, error) {
+	ref = ref.UTC()
+	year, month := ref.Year(), ref.Month()
+	quarterStart := time.Month((int(month)-1)/3*3 + 1)
+	endOfRefDay := time.Date(year, month, ref.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+
+	upper := strings.ToUpper(strings.TrimSpace(name))
+	switch strings.ToLower(upper) {
+	case "last-month":
+		end := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		return end.AddDate(0, -1, 0), end, nil
+	case "last-quarter":
+		end := time.Date(year, quarterStart, 1, 0, 0, 0, 0, time.UTC)
+		return end.AddDate(0, -3, 0), end, nil
+	case "last-year":
+		end := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		return end.AddDate(-1, 0, 0), end, nil
+	case "mtd":
+		return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC), endOfRefDay, nil
+	case "qtd":
+		return time.Date(year, quarterStart, 1, 0, 0, 0, 0, time.UTC), endOfRefDay, nil
+	case "ytd":
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC), endOfRefDay, nil
+	}
+
+	if m := yearPattern.FindStringSubmatch(upper); m != nil {
+		start := time.Date(atoi(m[1]), time.January, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(1, 0, 0), nil
+	}
+	if m := halfPattern.FindStringSubmatch(upper); m != nil {
+		start := time.Date(atoi(m[2]), time.Month((atoi(m[1])-1)*6+1), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 6, 0), nil
+	}
+	if m := quarterPattern.FindStringSubmatch(upper); m != nil {
+		start := time.Date(atoi(m[2]), time.Month((atoi(m[1])-1)*3+1), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 3, 0), nil
+	}
+	if m := monthPattern.FindStringSubmatch(upper); m != nil {
+		if mon := atoi(m[2]); mon >= 1 && mon <= 12 {
+			start := time.Date(atoi(m[1]), time.Month(mon), 1, 0, 0, 0, 0, time.UTC)
+			return start, start.AddDate(0, 1, 0), nil
+		}
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("unknown period %q", name)
+}
+
+// atoi kalıpla doğrulanmış rakamları tam sayıya çevirir
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// validate dönem parametrelerini doğrular
+func (p ReportPeriod) validate(add func(path, rule string, value interface{}, message string)) {
+	// Adlandırılmış dönemin başlangıcı, Resolve'da olduğu gibi asOf'a (yoksa şu ana) göre bulunur
+	var periodStart time.Time
+	if p.Period != "" {
+		ref := time.Now()
+		if p.AsOf != nil && !p.AsOf.IsZero() {
+			ref = p.AsOf.Time
+		}
+		start, _, err := ParseNamedPeriod(p.Period, ref)
+		if err != nil {
+			add("period", RuleOneOf, p.Period, "must be last-month, last-quarter, last-year, mtd, qtd, ytd, or a year (2026), half (H1-2026), quarter (Q3-2026) or month (2026-09)")
+		}
+		periodStart = start
+		if p.PeriodStart != nil {
+			add("periodStart", RuleOneOf, p.PeriodStart.String(), "must not be combined with period")
+		}
+		if p.PeriodEnd != nil {
+			add("periodEnd", RuleOneOf, p.PeriodEnd.String(), "must not be combined with period")
+		}
+	}
+	for _, field := range []struct {
+		path string
+		t    *Timestamp
+	}{{"periodStart", p.PeriodStart}, {"periodEnd", p.PeriodEnd}, {"asOf", p.AsOf}} {
+		if field.t == nil {
+			continue
+		}
+		if field.t.InvalidInput() == "" && field.t.IsZero() {
+			add(field.path, RuleRequired, nil, "must be set when present")
+		} else {
+			checkTimestamp(add, field.path, *field.t, true)
+		}
+	}
+	if p.PeriodStart != nil && p.PeriodEnd != nil && !p.PeriodStart.IsZero() && p.PeriodEnd.Before(p.PeriodStart.Time) {
+		add("periodEnd", RuleMin, p.PeriodEnd.String(), "must not be before periodStart")
+	}
+	if p.AsOf != nil && !p.AsOf.IsZero() {
+		switch {
+		case p.PeriodStart != nil && !p.PeriodStart.IsZero() && p.AsOf.Before(p.PeriodStart.Time):
+			add("asOf", RuleMin, p.AsOf.String(), "must not be before periodStart")
+		case !periodStart.IsZero() && p.AsOf.Before(periodStart):
+			add("asOf", RuleMin, p.AsOf.String(), fmt.Sprintf("must not be before the start of period %s (%s)",
+				canonicalPeriodName(p.Period), periodStart.Format("2006-01-02")))
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseNamedPeriod(t *testing.T) {
+	ref := time.Date(2026, time.January, 15, 10, 30, 0, 0, time.UTC)
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+	}{
+		{"last-month", day(2025, time.December, 1), day(2026, time.January, 1)},
+		{"Last-Quarter", day(2025, time.October, 1), day(2026, time.January, 1)},
+		{"last-year", day(2025, time.January, 1), day(2026, time.January, 1)},
+		{"mtd", day(2026, time.January, 1), day(2026, time.January, 16)},
+		{"qtd", day(2026, time.January, 1), day(2026, time.January, 16)},
+		{"ytd", day(2026, time.January, 1), day(2026, time.January, 16)},
+		{"2024", day(2024, time.January, 1), day(2025, time.January, 1)},
+		{"H2-2026", day(2026, time.July, 1), day(2027, time.January, 1)},
+		{"Q3-2026", day(2026, time.July, 1), day(2026, time.October, 1)},
+		{"q4-2025", day(2025, time.October, 1), day(2026, time.January, 1)},
+		{"2024-02", day(2024, time.February, 1), day(2024, time.March, 1)},
+	}
+	for _, tt := range tests {
+		start, end, err := ParseNamedPeriod(tt.name, ref)
+		if err != nil {
+			t.Errorf("ParseNamedPeriod(%q) error: %v", tt.name, err)
+			continue
+		}
+		if !start.Equal(tt.start) || !end.Equal(tt.end) {
+			t.Errorf("ParseNamedPeriod(%q) = [%s, %s); want [%s, %s)", tt.name, start, end, tt.start, tt.end)
+		}
+	}
+
+	for _, name := range []string{"", "next-month", "Q5-2026", "H3-2026", "2026-13", "26"} {
+		if _, _, err := ParseNamedPeriod(name, ref); err == nil {
+			t.Errorf("ParseNamedPeriod(%q) expected error", name)
+		}
+	}
+}
+
+func TestReportPeriod_Resolve(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	ts := func(s string) *Timestamp { v := MustParseTimestamp(s); return &v }
+
+	// Parametre yoksa dönemsiz rapor
+	if r, err := (ReportPeriod{}).Resolve(now); err != nil || !r.IsZero() {
+		t.Errorf("Resolve without parameters = %+v, %v; want zero", r, err)
+	}
+
+	// Geçmiş bir dönem: as-of dönemin son anıdır
+	r, err := ReportPeriod{Period: "q3-2026"}.Resolve(now)
+	if err != nil {
+		t.Fatalf("Resolve error: %v", err)
+	}
+	if got, want := r.Label(), "Q3-2026 (2026-07-01 to 2026-09-30), as of 2026-09-30"; got != want {
+		t.Errorf("Label = %q; want %q", got, want)
+	}
+	if !r.Contains(MustParseTimestamp("2026-09-30 23:59:59").Time) || r.Contains(MustParseTimestamp("2026-10-01").Time) {
+		t.Errorf("Q3-2026 must include September 30 and exclude October 1")
+	}
+
+	// Göreli dönemler as-of tarihine göre hesaplanır
+	r, _ = ReportPeriod{Period: "last-month", AsOf: ts("2026-03-10")}.Resolve(now)
+	if got, want := r.Label(), "last-month (2026-02-01 to 2026-02-28), as of 2026-03-10"; got != want {
+		t.Errorf("Label = %q; want %q", got, want)
+	}
+
+	// Tarihle verilen dönem sonu günün sonuna kadar kapsanır; gelecekteki sonlarda as-of şu andır
+	r, _ = ReportPeriod{PeriodStart: ts("2026-10-01"), PeriodEnd: ts("2026-12-31")}.Resolve(now)
+	if !r.AsOf.Equal(now) || !r.End.Equal(MustParseTimestamp("2027-01-01").Time) {
+		t.Errorf("Resolve = %+v; want as-of now and end 2027-01-01", r)
+	}
+	if got, want := r.Label(), "2026-10-01 to 2026-12-31, as of 2026-10-19"; got != want {
+		t.Errorf("Label = %q; want %q", got, want)
+	}
+
+	r, _ = ReportPeriod{AsOf: ts("2026-06-30")}.Resolve(now)
+	if got, want := r.Label(), "As of 2026-06-30"; got != want {
+		t.Errorf("Label = %q; want %q", got, want)
+	}
+}
+
+func TestReportPeriod_Validate(t *testing.T) {
+	ts := func(s string) *Timestamp { v := MustParseTimestamp(s); return &v }
+	portfolios := []Portfolio{{PortID: 1, Name: "A", UserID: "u1", CreatedAt: *ts("2024-01-01"), LastUpdate: *ts("2024-01-02")}}
+
+	for _, period := range []ReportPeriod{
+		{Period: "Q3-2026"},
+		{Period: "Q3-2026", AsOf: ts("2026-07-01")},
+		{Period: "last-quarter", AsOf: ts("2026-01-01")}, // göreli dönemler asOf'tan önce başlar
+	} {
+		valid := PortfolioReportPayload{ReportPeriod: period, Portfolios: portfolios}
+		if err := valid.Validate(); err != nil {
+			t.Fatalf("Validate(%+v) on valid payload returned %v", period, err)
+		}
+	}
+
+	tests := []struct {
+		period ReportPeriod
+		want   map[string]string
+	}{
+		{ReportPeriod{Period: "Q5-2026"}, map[string]string{"period": RuleOneOf}},
+		{ReportPeriod{Period: "ytd", PeriodStart: ts("2026-01-01")}, map[string]string{"periodStart": RuleOneOf}},
+		{ReportPeriod{PeriodStart: ts("2026-02-01"), PeriodEnd: ts("2026-01-01"), AsOf: ts("2025-12-31")},
+			map[string]string{"periodEnd": RuleMin, "asOf": RuleMin}},
+		{ReportPeriod{Period: "Q3-2026", AsOf: ts("2026-06-30")}, map[string]string{"asOf": RuleMin}},
+		{ReportPeriod{Period: "2026-09", AsOf: ts("2026-08-31T23:59:59Z")}, map[string]string{"asOf": RuleMin}},
+		{ReportPeriod{Period: "h1-2026", AsOf: ts("2025-12-31")}, map[string]string{"asOf": RuleMin}},
+	}
+	for _, tt := range tests {
+		payload := PortfolioReportPayload{ReportPeriod: tt.period, Portfolios: portfolios}
+		var verrs ValidationErrors
+		if !errors.As(payload.Validate(), &verrs) {
+			t.Errorf("Validate(%+v): expected ValidationErrors", tt.period)
+			continue
+		}
+		if len(verrs) != len(tt.want) {
+			t.Errorf("Got %d errors, want %d: %v", len(verrs), len(tt.want), verrs)
+		}
+		for _, fe := range verrs {
+			if rule, ok := tt.want[fe.Path]; !ok || rule != fe.Rule {
+				t.Errorf("Unexpected error %s (%s)", fe.Path, fe.Rule)
+			}
+		}
+	}
+
+	// Talep payload'ı da aynı dönem kurallarını uygular
+	request := PortfolioReportRequestPayload{ReportPeriod: ReportPeriod{Period: "someday"}, UserID: "u1"}
+	if err := request.Validate(); err == nil || !strings.Contains(err.Error(), "period") {
+		t.Errorf("Expected period validation error on request payload, got %v", err)
+	}
+}
+
+func TestReportPeriod_JSON(t *testing.T) {
+	// Dönemsiz payload'lar eskisi gibi yazılır
+	data, _ := json.Marshal(PortfolioReportPayload{Portfolios: []Portfolio{}})
+	if got, want := string(data), `{"portfolios":[]}`; got != want {
+		t.Errorf("Marshal = %s; want %s", got, want)
+	}
+
+	var payload PortfolioReportPayload
+	if err := json.Unmarshal([]byte(`{"period":"last-month","asOf":"2026-10-01","portfolios":[]}`), &payload); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if payload.Period != "last-month" || payload.AsOf == nil || payload.AsOf.Format("2006-01-02") != "2026-10-01" {
+		t.Errorf("Unexpected period parameters: %+v", payload.ReportPeriod)
+	}
+}
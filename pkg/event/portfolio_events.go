@@ -5,17 +5,22 @@ import (
 )
 
 // Portfolio bir portföyü temsil eder
+// jsonschema etiketleri JSON Schema kısıtlarını tanımlar (bkz. pkg/schema)
 type Portfolio struct {
-	PortID     int    `json:"portID"`
-	Name       string `json:"name"`
-	UserID     string `json:"userID"`
-	CreatedAt  string `json:"createdAt"`
-	LastUpdate string `json:"lastUpdate"`
+	PortID     int       `json:"portID" jsonschema:"minimum=1"`
+	Name       string    `json:"name" jsonschema:"minLength=1"`
+	UserID     string    `json:"userID" jsonschema:"minLength=1"`
+	CreatedAt  Timestamp `json:"createdAt"`
+	LastUpdate Timestamp `json:"lastUpdate"`
 }
 
-// PortfolioReportPayload portfolio.report olayının payload'ını tanımlar
+// PortfolioReportPayload portfolio.report olayının payload'ını tanımlar.
+// Dönem parametreleri verilirse rapor o döneme göre süzülür ve etiketlenir;
+// Filter verilirse yalnızca ifadeye uyan portföyler raporlanır.
 type PortfolioReportPayload struct {
-	Portfolios []Portfolio `json:"portfolios"`
+	ReportPeriod
+	Filter     string      `json:"filter,omitempty"`
+	Portfolios []Portfolio `json:"portfolios" jsonschema:"minItems=1"`
 }
 
 // NewPortfolioReportEvent yeni bir portfolio report event'i oluşturur
@@ -27,6 +32,11 @@ func NewPortfolioReportEvent(portfolios []Portfolio) (BaseEvent, error) {
 	return NewBaseEvent(PortfolioReport, payload)
 }
 
+// sampleTime şu andan verilen süre önceki zamanı saniye hassasiyetinde döndürür
+func sampleTime(years, months, days int) Timestamp {
+	return NewTimestamp(time.Now().AddDate(years, months, days).Truncate(time.Second))
+}
+
 // CreateSamplePortfolios örnek portföy verileri oluşturur
 func CreateSamplePortfolios() []Portfolio {
 	return []Portfolio{
@@ -34,22 +44,22 @@ func CreateSamplePortfolios() []Portfolio {
 			PortID:     1,
 			Name:       "My Tech Portfolio",
 			UserID:     "user123",
-			CreatedAt:  time.Now().AddDate(0, -6, 0).Format("2006-01-02 15:04:05"),
-			LastUpdate: time.Now().AddDate(0, 0, -10).Format("2006-01-02 15:04:05"),
+			CreatedAt:  sampleTime(0, -6, 0),
+			LastUpdate: sampleTime(0, 0, -10),
 		},
 		{
 			PortID:     2,
 			Name:       "Retirement Fund",
 			UserID:     "user456",
-			CreatedAt:  time.Now().AddDate(0, -4, 0).Format("2006-01-02 15:04:05"),
-			LastUpdate: time.Now().AddDate(0, 0, -5).Format("2006-01-02 15:04:05"),
+			CreatedAt:  sampleTime(0, -4, 0),
+			LastUpdate: sampleTime(0, 0, -5),
 		},
 		{
 			PortID:     3,
 			Name:       "Growth Portfolio",
 			UserID:     "user123",
-			CreatedAt:  time.Now().AddDate(0, -2, 0).Format("2006-01-02 15:04:05"),
-			LastUpdate: time.Now().AddDate(0, 0, -1).Format("2006-01-02 15:04:05"),
+			CreatedAt:  sampleTime(0, -2, 0),
+			LastUpdate: sampleTime(0, 0, -1),
 		},
 	}
-} 
\ No newline at end of file
+}
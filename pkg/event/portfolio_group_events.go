@@ -0,0 +1,91 @@
+package event
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GroupKind grup raporunun kapsadığı kullanıcı grubunun türü
+type GroupKind string
+
+// Grup türleri
+const (
+	GroupHousehold GroupKind = "household" // aynı hanedeki kullanıcılar
+	GroupAdvisor   GroupKind = "advisor"   // bir danışmanın müşteri portföyü
+)
+
+// GroupKinds desteklenen grup türleri
+var GroupKinds = []GroupKind{GroupHousehold, GroupAdvisor}
+
+// PortfolioGroupReportPayload portfolio.group.report olayının payload'ını tanımlar.
+// Gruptaki kullanıcıların portföyleri portföy deposundan yüklenir ve her kullanıcı
+// için ayrı bir bölüm ile grup toplamlarını içeren tek bir rapor oluşturulur.
+type PortfolioGroupReportPayload struct {
+	ReportPeriod
+	// Filter gruptaki portföylerden raporlanacakları seçen filtre ifadesi (opsiyonel)
+	Filter    string    `json:"filter,omitempty"`
+	GroupID   string    `json:"groupID" jsonschema:"minLength=1"`
+	GroupName string    `json:"groupName,omitempty"`
+	Kind      GroupKind `json:"kind"`
+	// UserIDs gruptaki kullanıcılar; raporda bu sırayla yer alırlar
+	UserIDs []string `json:"userIDs" jsonschema:"minItems=1"`
+}
+
+// NewPortfolioGroupReportEvent yeni bir portfolio group report event'i oluşturur
+func NewPortfolioGroupReportEvent(kind GroupKind, groupID string, userIDs ...string) (BaseEvent, error) {
+	return NewBaseEvent(PortfolioGroupReport, PortfolioGroupReportPayload{GroupID: groupID, Kind: kind, UserIDs: userIDs})
+}
+
+// DisplayName grubun raporlarda gösterilecek adını döndürür; ad verilmemişse grup kimliği kullanılır
+func (p PortfolioGroupReportPayload) DisplayName() string {
+	if name := strings.TrimSpace(p.GroupName); name != "" {
+		return name
+	}
+	return p.GroupID
+}
+
+// Validate portfolio.group.report payload'ını doğrular ve tüm alan hatalarını birlikte döndürür
+func (p PortfolioGroupReportPayload) Validate() error {
+	var errs ValidationErrors
+	add := func(path, rule string, value interface{}, message string) {
+		errs = append(errs, FieldError{Path: path, Rule: rule, Value: value, Message: message})
+	}
+
+	p.ReportPeriod.validate(add)
+	validateFilter(p.Filter, add)
+	if strings.TrimSpace(p.GroupID) == "" {
+		add("groupID", RuleRequired, p.GroupID, "must not be blank")
+	}
+	if !validGroupKind(p.Kind) {
+		add("kind", RuleOneOf, string(p.Kind), "must be household or advisor")
+	}
+	if len(p.UserIDs) == 0 {
+		add("userIDs", RuleRequired, nil, "must contain at least one user")
+	}
+	seen := make(map[string]int)
+	for i, userID := range p.UserIDs {
+		path := fmt.Sprintf("userIDs[%d]", i)
+		if strings.TrimSpace(userID) == "" {
+			add(path, RuleRequired, userID, "must not be blank")
+		} else if first, dup := seen[userID]; dup {
+			add(path, RuleUnique, userID, fmt.Sprintf("duplicates userIDs[%d]", first))
+		} else {
+			seen[userID] = i
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// validGroupKind grup türünün desteklenip desteklenmediğini döndürür
+func validGroupKind(kind GroupKind) bool {
+	for _, k := range GroupKinds {
+		if kind == k {
+			return true
+		}
+	}
+	return false
+}
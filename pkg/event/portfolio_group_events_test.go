@@ -0,0 +1,50 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestPortfolioGroupReportPayload_Validate(t *testing.T) {
+	evt, err := NewPortfolioGroupReportEvent(GroupHousehold, "hh-1", "u1", "u2")
+	if err != nil {
+		t.Fatalf("NewPortfolioGroupReportEvent error: %v", err)
+	}
+	var payload PortfolioGroupReportPayload
+	if err := json.Unmarshal(evt.Payload, &payload); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if err := payload.Validate(); err != nil {
+		t.Fatalf("Validate on valid payload returned %v", err)
+	}
+	if payload.DisplayName() != "hh-1" {
+		t.Errorf("DisplayName = %q; want group ID", payload.DisplayName())
+	}
+
+	invalid := PortfolioGroupReportPayload{Kind: "family", UserIDs: []string{"u1", " ", "u1"}, Filter: "userID ="}
+	var verrs ValidationErrors
+	if !errors.As(invalid.Validate(), &verrs) {
+		t.Fatalf("Expected ValidationErrors, got %v", invalid.Validate())
+	}
+	want := map[string]string{
+		"filter":     RuleSyntax,
+		"groupID":    RuleRequired,
+		"kind":       RuleOneOf,
+		"userIDs[1]": RuleRequired,
+		"userIDs[2]": RuleUnique,
+	}
+	if len(verrs) != len(want) {
+		t.Errorf("Got %d errors, want %d: %v", len(verrs), len(want), verrs)
+	}
+	for _, fe := range verrs {
+		if rule, ok := want[fe.Path]; !ok || rule != fe.Rule {
+			t.Errorf("Unexpected error %s (%s)", fe.Path, fe.Rule)
+		}
+	}
+
+	empty := PortfolioGroupReportPayload{GroupID: "b-1", Kind: GroupAdvisor}
+	if !errors.As(empty.Validate(), &verrs) || len(verrs) != 1 || verrs[0].Path != "userIDs" {
+		t.Errorf("Expected userIDs required error, got %v", empty.Validate())
+	}
+}
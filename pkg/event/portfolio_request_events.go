@@ -0,0 +1,52 @@
+package event
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PortfolioReportRequestPayload portfolio.report.request olayının payload'ını tanımlar.
+// Portföyler mesajda taşınmaz; servis bunları portföy deposundan yükler.
+type PortfolioReportRequestPayload struct {
+	ReportPeriod
+	// Filter yüklenen portföylerden raporlanacakları seçen filtre ifadesi (opsiyonel)
+	Filter string `json:"filter,omitempty"`
+	UserID string `json:"userID" jsonschema:"minLength=1"`
+	// PortIDs raporlanacak portföyler; boşsa kullanıcının tüm portföyleri raporlanır
+	PortIDs []int `json:"portIDs,omitempty"`
+}
+
+// NewPortfolioReportRequestEvent yeni bir portfolio report request event'i oluşturur
+func NewPortfolioReportRequestEvent(userID string, portIDs ...int) (BaseEvent, error) {
+	return NewBaseEvent(PortfolioReportRequest, PortfolioReportRequestPayload{UserID: userID, PortIDs: portIDs})
+}
+
+// Validate portfolio.report.request payload'ını doğrular ve tüm alan hatalarını birlikte döndürür
+func (p PortfolioReportRequestPayload) Validate() error {
+	var errs ValidationErrors
+	add := func(path, rule string, value interface{}, message string) {
+		errs = append(errs, FieldError{Path: path, Rule: rule, Value: value, Message: message})
+	}
+
+	p.ReportPeriod.validate(add)
+	validateFilter(p.Filter, add)
+	if strings.TrimSpace(p.UserID) == "" {
+		add("userID", RuleRequired, p.UserID, "must not be blank")
+	}
+	seen := make(map[int]int)
+	for i, id := range p.PortIDs {
+		path := fmt.Sprintf("portIDs[%d]", i)
+		if id <= 0 {
+			add(path, RuleMin, id, "must be a positive integer")
+		} else if first, dup := seen[id]; dup {
+			add(path, RuleUnique, id, fmt.Sprintf("duplicates portIDs[%d]", first))
+		} else {
+			seen[id] = i
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
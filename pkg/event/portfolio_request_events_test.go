@@ -0,0 +1,53 @@
+package event
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPortfolioReportRequestPayload_Validate(t *testing.T) {
+	for _, valid := range []PortfolioReportRequestPayload{
+		{UserID: "user123"},
+		{UserID: "user123", PortIDs: []int{1, 3}},
+	} {
+		if err := valid.Validate(); err != nil {
+			t.Errorf("Validate(%+v) returned %v", valid, err)
+		}
+	}
+
+	invalid := PortfolioReportRequestPayload{UserID: " ", PortIDs: []int{1, 0, 1}}
+	var verrs ValidationErrors
+	if !errors.As(invalid.Validate(), &verrs) {
+		t.Fatal("Expected ValidationErrors")
+	}
+	want := map[string]string{
+		"userID":     RuleRequired,
+		"portIDs[1]": RuleMin,
+		"portIDs[2]": RuleUnique,
+	}
+	if len(verrs) != len(want) {
+		t.Errorf("Got %d errors, want %d: %v", len(verrs), len(want), verrs)
+	}
+	for _, fe := range verrs {
+		if rule, ok := want[fe.Path]; !ok || rule != fe.Rule {
+			t.Errorf("Unexpected error %s (%s)", fe.Path, fe.Rule)
+		}
+	}
+}
+
+func TestNewPortfolioReportRequestEvent(t *testing.T) {
+	evt, err := NewPortfolioReportRequestEvent("user123", 2)
+	if err != nil {
+		t.Fatalf("NewPortfolioReportRequestEvent error: %v", err)
+	}
+	if evt.EventType != PortfolioReportRequest {
+		t.Errorf("EventType = %s; want %s", evt.EventType, PortfolioReportRequest)
+	}
+	var payload PortfolioReportRequestPayload
+	if err := evt.ParsePayload(&payload); err != nil {
+		t.Fatalf("ParsePayload error: %v", err)
+	}
+	if payload.UserID != "user123" || len(payload.PortIDs) != 1 || payload.PortIDs[0] != 2 {
+		t.Errorf("Unexpected payload: %+v", payload)
+	}
+}
@@ -0,0 +1,81 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// StreamPortfolios portfolio.report payload'ındaki portföyleri tek tek çözer ve her biri için fn'i çağırır.
+// Tüm liste hiçbir zaman bellekte tutulmaz; bellek kullanımı portföy sayısından bağımsızdır.
+// fn hata döndürürse okuma durur ve hata çağırana iletilir. İşlenen portföy sayısını döndürür.
+func StreamPortfolios(r io.Reader, fn func(Portfolio) error) (int, error) {
+	dec := json.NewDecoder(r)
+
+	// Payload bir JSON nesnesi olmalı
+	if err := expectDelim(dec, '{'); err != nil {
+		return 0, fmt.Errorf("portfolio payload must be a JSON object: %w", err)
+	}
+
+	count := 0
+	for dec.More() {
+		keyToken, err := dec.Token()
+		if err != nil {
+			return count, fmt.Errorf("failed to read payload key: %w", err)
+		}
+		key, _ := keyToken.(string)
+
+		// Diğer alanları atla
+		if key != "portfolios" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return count, fmt.Errorf("failed to skip payload field %q: %w", key, err)
+			}
+			continue
+		}
+
+		token, err := dec.Token()
+		if err != nil {
+			return count, fmt.Errorf("failed to read portfolios: %w", err)
+		}
+		if token == nil {
+			continue // "portfolios": null
+		}
+		if delim, ok := token.(json.Delim); !ok || delim != '[' {
+			return count, fmt.Errorf("portfolios must be a JSON array, got %v", token)
+		}
+
+		for dec.More() {
+			var portfolio Portfolio
+			if err := dec.Decode(&portfolio); err != nil {
+				return count, fmt.Errorf("failed to decode portfolio at index %d: %w", count, err)
+			}
+			if err := fn(portfolio); err != nil {
+				return count, err
+			}
+			count++
+		}
+
+		if err := expectDelim(dec, ']'); err != nil {
+			return count, fmt.Errorf("unterminated portfolios array: %w", err)
+		}
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return count, fmt.Errorf("unterminated portfolio payload: %w", err)
+	}
+
+	return count, nil
+}
+
+// expectDelim bir sonraki JSON token'ının beklenen ayraç olduğunu doğrular
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("expected %q, got %v", want, token)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package event
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestStreamPortfolios(t *testing.T) {
+	payload := `{"meta":{"x":[1,2]},"portfolios":[` +
+		`{"portID":1,"name":"A","userID":"u1","createdAt":"2023-01-01","lastUpdate":"2023-01-02"},` +
+		`{"portID":2,"name":"B","userID":"u2","createdAt":"2023-01-01","lastUpdate":"2023-01-02"}]}`
+
+	var ids []int
+	n, err := StreamPortfolios(strings.NewReader(payload), func(p Portfolio) error {
+		ids = append(ids, p.PortID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamPortfolios error: %v", err)
+	}
+	if n != 2 || len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("StreamPortfolios = %d, ids %v; want 2, [1 2]", n, ids)
+	}
+}
+
+func TestStreamPortfolios_Errors(t *testing.T) {
+	noop := func(Portfolio) error { return nil }
+	for name, payload := range map[string]string{
+		"not object":   `[1,2]`,
+		"not array":    `{"portfolios":{}}`,
+		"bad element":  `{"portfolios":[{"portID":"x"}]}`,
+		"unterminated": `{"portfolios":[{"portID":1}`,
+	} {
+		if _, err := StreamPortfolios(strings.NewReader(payload), noop); err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+
+	// Callback errors stop the stream
+	sentinel := errors.New("stop")
+	n, err := StreamPortfolios(strings.NewReader(`{"portfolios":[{"portID":1},{"portID":2}]}`), func(Portfolio) error {
+		return sentinel
+	})
+	if err != sentinel || n != 0 {
+		t.Errorf("StreamPortfolios = %d, %v; want 0, %v", n, err, sentinel)
+	}
+}
@@ -0,0 +1,97 @@
+package event
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Veri kalitesi denetiminin sınırları
+const (
+	// MaxQualityIssues bir payload için listelenen en fazla sorun sayısı; fazlası tek bir özet satırında sayılır
+	MaxQualityIssues = 500
+
+	// FutureDateTolerance gelecekteki tarih sayılmayan saat farkı payı
+	FutureDateTolerance = 5 * time.Minute
+)
+
+// QualityChecker işi durdurmadan yayıncıya bildirilen veri kalitesi sorunlarını
+// bulabilen payload'lar için arayüz. Sorunlar Validate hatalarıyla aynı biçimdedir.
+type QualityChecker interface {
+	// CheckQuality now anına göre bulunan sorunları döndürür; sorun yoksa nil döner
+	CheckQuality(now time.Time) []FieldError
+}
+
+// CheckQuality portfolio.report payload'ındaki veri kalitesi sorunlarını döndürür
+func (p PortfolioReportPayload) CheckQuality(now time.Time) []FieldError {
+	c := NewPortfolioQualityChecker(now)
+	for i, portfolio := range p.Portfolios {
+		c.Check(i, portfolio)
+	}
+	return c.Issues()
+}
+
+// PortfolioQualityChecker portföyleri rapor üretimini engellemeyen sorunlar için
+// tek tek denetler: tekrarlanan PortID, boş ad, createdAt'ten önceki lastUpdate ve
+// gelecekteki tarihler. Akış yolunda kullanılabilmesi için görülen ID'leri tutar.
+type PortfolioQualityChecker struct {
+	now     time.Time
+	seen    map[int]int // PortID -> ilk görüldüğü indeks
+	issues  []FieldError
+	omitted int
+}
+
+// NewPortfolioQualityChecker now anına göre denetim yapan yeni bir denetleyici oluşturur
+func NewPortfolioQualityChecker(now time.Time) *PortfolioQualityChecker {
+	return &PortfolioQualityChecker{now: now, seen: make(map[int]int)}
+}
+
+// Check i. sıradaki portföyü denetler
+func (c *PortfolioQualityChecker) Check(i int, p Portfolio) {
+	path := fmt.Sprintf("portfolios[%d]", i)
+
+	if p.PortID > 0 {
+		if first, dup := c.seen[p.PortID]; dup {
+			c.add(path+".portID", RuleUnique, p.PortID, fmt.Sprintf("duplicates portfolios[%d].portID", first))
+		} else {
+			c.seen[p.PortID] = i
+		}
+	}
+	if strings.TrimSpace(p.Name) == "" {
+		c.add(path+".name", RuleRequired, p.Name, "is blank")
+	}
+	if !p.CreatedAt.IsZero() && !p.LastUpdate.IsZero() && p.LastUpdate.Before(p.CreatedAt.Time) {
+		c.add(path+".lastUpdate", RuleMin, p.LastUpdate.String(), "is before createdAt "+p.CreatedAt.String())
+	}
+
+	limit := c.now.Add(FutureDateTolerance)
+	if p.CreatedAt.After(limit) {
+		c.add(path+".createdAt", RuleMax, p.CreatedAt.String(), "is in the future")
+	}
+	if p.LastUpdate.After(limit) {
+		c.add(path+".lastUpdate", RuleMax, p.LastUpdate.String(), "is in the future")
+	}
+}
+
+// Issues bulunan sorunları döndürür. MaxQualityIssues'tan fazla sorun varsa
+// listelenmeyenler son satırda sayılır.
+func (c *PortfolioQualityChecker) Issues() []FieldError {
+	if c.omitted == 0 {
+		return c.issues
+	}
+	return append(c.issues[:len(c.issues):len(c.issues)], FieldError{
+		Path:    "portfolios",
+		Rule:    RuleMax,
+		Value:   c.omitted,
+		Message: fmt.Sprintf("%d more data quality issues not listed", c.omitted),
+	})
+}
+
+// add yeni bir sorun ekler
+func (c *PortfolioQualityChecker) add(path, rule string, value interface{}, message string) {
+	if len(c.issues) >= MaxQualityIssues {
+		c.omitted++
+		return
+	}
+	c.issues = append(c.issues, FieldError{Path: path, Rule: rule, Value: value, Message: message})
+}
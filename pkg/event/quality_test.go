@@ -0,0 +1,59 @@
+package event
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPortfolioReportPayload_CheckQuality(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	created := NewTimestamp(time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC))
+	earlier := NewTimestamp(time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC))
+	future := NewTimestamp(now.Add(24 * time.Hour))
+	withinTolerance := NewTimestamp(now.Add(time.Minute))
+
+	payload := PortfolioReportPayload{Portfolios: []Portfolio{
+		{PortID: 1, Name: "A", UserID: "u1", CreatedAt: created, LastUpdate: created},
+		{PortID: 1, Name: " ", UserID: "u2", CreatedAt: created, LastUpdate: earlier},
+		{PortID: 2, Name: "C", UserID: "u3", CreatedAt: created, LastUpdate: future},
+		{PortID: 3, Name: "D", UserID: "u4", CreatedAt: created, LastUpdate: withinTolerance},
+	}}
+	issues := payload.CheckQuality(now)
+
+	want := []struct{ path, rule string }{
+		{"portfolios[1].portID", RuleUnique},
+		{"portfolios[1].name", RuleRequired},
+		{"portfolios[1].lastUpdate", RuleMin},
+		{"portfolios[2].lastUpdate", RuleMax},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("Got %d issues, want %d: %v", len(issues), len(want), issues)
+	}
+	for i, w := range want {
+		if issues[i].Path != w.path || issues[i].Rule != w.rule {
+			t.Errorf("issues[%d] = %s (%s); want %s (%s)", i, issues[i].Path, issues[i].Rule, w.path, w.rule)
+		}
+	}
+	if got := issues[0].Message; got != "duplicates portfolios[0].portID" {
+		t.Errorf("Duplicate message = %q", got)
+	}
+
+	if issues := (PortfolioReportPayload{Portfolios: CreateSamplePortfolios()}).CheckQuality(time.Now()); issues != nil {
+		t.Errorf("Sample portfolios have quality issues: %v", issues)
+	}
+}
+
+func TestPortfolioQualityChecker_Limit(t *testing.T) {
+	c := NewPortfolioQualityChecker(time.Now())
+	for i := 0; i < MaxQualityIssues+10; i++ {
+		c.Check(i, Portfolio{PortID: i + 1, UserID: "u"})
+	}
+	issues := c.Issues()
+	if len(issues) != MaxQualityIssues+1 {
+		t.Fatalf("Got %d issues; want %d", len(issues), MaxQualityIssues+1)
+	}
+	last := issues[len(issues)-1]
+	if last.Path != "portfolios" || last.Value != 10 {
+		t.Errorf("Overflow summary = %v", last)
+	}
+}
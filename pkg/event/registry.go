@@ -0,0 +1,135 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ErrUnsupportedSchemaVersion mesajın şema sürümü bu servisin bildiği en yeni
+// sürümden büyük olduğunda (ileride yayınlanacak bir sürüm) döner
+var ErrUnsupportedSchemaVersion = errors.New("unsupported schema version")
+
+// Upcaster bir payload'ı bir şema sürümünden bir sonrakine dönüştürür
+type Upcaster func(payload json.RawMessage) (json.RawMessage, error)
+
+// payloadRegistration bir event tipinin payload yapısı, güncel şema sürümü ve
+// eski sürümlerden yükseltme adımları
+type payloadRegistration struct {
+	typ       reflect.Type
+	version   int
+	upcasters map[int]Upcaster // kaynak sürüm -> bir sonraki sürüme dönüştürücü
+}
+
+// payloadTypes her event tipinin payload kaydını tutar; şema üretimi, sözleşme
+// kontrolü ve sürüm yükseltme bu kayıttan beslenir
+var payloadTypes = map[EventType]*payloadRegistration{}
+
+func init() {
+	registerBuiltinType(PortfolioReport, PortfolioReportPayload{})
+	registerBuiltinType(TransactionReport, TransactionReportPayload{})
+	registerBuiltinType(HoldingsReport, HoldingsReportPayload{})
+	registerBuiltinType(PerformanceReport, PerformanceReportPayload{})
+	registerBuiltinType(RealizedGainsReport, RealizedGainsReportPayload{})
+	registerBuiltinType(IncomeReport, IncomeReportPayload{})
+	registerBuiltinType(PortfolioReportRequest, PortfolioReportRequestPayload{})
+	registerBuiltinType(PortfolioGroupReport, PortfolioGroupReportPayload{})
+}
+
+// registerBuiltinType servisin kendi event tiplerini güncel zarf sürümüyle kaydeder.
+// Sürüm alanı olmayan mesajlar v1 sayıldığından v1 payload'ları aynen kabul edilir.
+func registerBuiltinType(eventType EventType, payload interface{}) {
+	RegisterPayloadType(eventType, payload, CurrentSchemaVersion)
+	RegisterUpcaster(eventType, SchemaVersionV1, upcastEnvelopeV1)
+}
+
+// RegisterPayloadType bir event tipinin payload yapısını ve güncel şema sürümünü kaydeder
+func RegisterPayloadType(eventType EventType, payload interface{}, version int) {
+	reg, ok := payloadTypes[eventType]
+	if !ok {
+		reg = &payloadRegistration{upcasters: make(map[int]Upcaster)}
+		payloadTypes[eventType] = reg
+	}
+	reg.typ = reflect.TypeOf(payload)
+	reg.version = version
+}
+
+// RegisterUpcaster event tipinin from sürümündeki payload'ını from+1 sürümüne
+// dönüştüren adımı kaydeder. Eski bir mesaj, güncel sürüme ulaşana kadar
+// adım adım yükseltilir.
+func RegisterUpcaster(eventType EventType, from int, upcaster Upcaster) {
+	reg, ok := payloadTypes[eventType]
+	if !ok {
+		panic(fmt.Sprintf("event: RegisterUpcaster for unregistered event type %q", eventType))
+	}
+	reg.upcasters[from] = upcaster
+}
+
+// PayloadType event tipinin kayıtlı payload yapısını döndürür
+func PayloadType(eventType EventType) (reflect.Type, bool) {
+	reg, ok := payloadTypes[eventType]
+	if !ok {
+		return nil, false
+	}
+	return reg.typ, true
+}
+
+// SchemaVersionFor event tipinin güncel şema sürümünü döndürür.
+// Kayıtlı olmayan tipler için zarfın güncel sürümü kullanılır.
+func SchemaVersionFor(eventType EventType) int {
+	if reg, ok := payloadTypes[eventType]; ok {
+		return reg.version
+	}
+	return CurrentSchemaVersion
+}
+
+// EventTypes payload yapısı kayıtlı tüm event tiplerini sıralı döndürür
+func EventTypes() []EventType {
+	types := make([]EventType, 0, len(payloadTypes))
+	for t := range payloadTypes {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// Upcast event'in payload'ını kayıtlı dönüştürücülerle event tipinin güncel
+// şema sürümüne yükseltir. Handler'lar böylece yalnızca en yeni yapıyı görür.
+// Bilinmeyen (daha yeni) sürümler ErrUnsupportedSchemaVersion ile reddedilir.
+func Upcast(evt BaseEvent) (BaseEvent, error) {
+	reg, ok := payloadTypes[evt.EventType]
+	if !ok {
+		return evt, nil
+	}
+
+	version := evt.SchemaVersion
+	if version == 0 {
+		version = SchemaVersionV1
+	}
+	if version > reg.version {
+		return evt, fmt.Errorf("%w: %s v%d (latest supported v%d)", ErrUnsupportedSchemaVersion, evt.EventType, version, reg.version)
+	}
+
+	for ; version < reg.version; version++ {
+		upcaster, ok := reg.upcasters[version]
+		if !ok {
+			return evt, fmt.Errorf("no upcaster for %s from schema version %d", evt.EventType, version)
+		}
+		payload, err := upcaster(evt.Payload)
+		if err != nil {
+			return evt, fmt.Errorf("upcasting %s from schema version %d: %w", evt.EventType, version, err)
+		}
+		evt.Payload = payload
+	}
+
+	evt.SchemaVersion = version
+	return evt, nil
+}
+
+// upcastEnvelopeV1 v1 payload'ını v2'ye yükseltir. v2 yalnızca zarfa kimlik
+// alanları ekledi; payload yapısı aynı kaldı.
+func upcastEnvelopeV1(payload json.RawMessage) (json.RawMessage, error) {
+	return payload, nil
+}
@@ -0,0 +1,94 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// widgetV3 test için üç sürümlü bir payload: v1 {"name"}, v2 {"title"}, v3 {"title","tags"}
+type widgetV3 struct {
+	Title string   `json:"title"`
+	Tags  []string `json:"tags"`
+}
+
+const testWidget EventType = "test.widget"
+
+func registerTestWidget(t *testing.T) {
+	RegisterPayloadType(testWidget, widgetV3{}, 3)
+	RegisterUpcaster(testWidget, 1, func(p json.RawMessage) (json.RawMessage, error) {
+		var v1 struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(p, &v1); err != nil {
+			return nil, err
+		}
+		return json.Marshal(map[string]string{"title": v1.Name})
+	})
+	RegisterUpcaster(testWidget, 2, func(p json.RawMessage) (json.RawMessage, error) {
+		var w widgetV3
+		if err := json.Unmarshal(p, &w); err != nil {
+			return nil, err
+		}
+		w.Tags = []string{}
+		return json.Marshal(w)
+	})
+	t.Cleanup(func() { delete(payloadTypes, testWidget) })
+}
+
+func TestUpcast_Chain(t *testing.T) {
+	registerTestWidget(t)
+
+	evt := BaseEvent{EventType: testWidget, SchemaVersion: 1, Payload: json.RawMessage(`{"name":"gear"}`)}
+	up, err := Upcast(evt)
+	if err != nil {
+		t.Fatalf("Upcast error: %v", err)
+	}
+	if up.SchemaVersion != 3 {
+		t.Errorf("SchemaVersion = %d; want 3", up.SchemaVersion)
+	}
+	if string(up.Payload) != `{"title":"gear","tags":[]}` {
+		t.Errorf("Payload = %s", up.Payload)
+	}
+
+	// Current messages pass through unchanged
+	current := BaseEvent{EventType: testWidget, SchemaVersion: 3, Payload: json.RawMessage(`{"title":"x","tags":["a"]}`)}
+	if got, err := Upcast(current); err != nil || string(got.Payload) != string(current.Payload) {
+		t.Errorf("Upcast(current) = %s, %v", got.Payload, err)
+	}
+}
+
+func TestUpcast_Errors(t *testing.T) {
+	registerTestWidget(t)
+
+	_, err := Upcast(BaseEvent{EventType: testWidget, SchemaVersion: 4})
+	if !errors.Is(err, ErrUnsupportedSchemaVersion) {
+		t.Errorf("Future version error = %v; want ErrUnsupportedSchemaVersion", err)
+	}
+
+	_, err = Upcast(BaseEvent{EventType: testWidget, SchemaVersion: 1, Payload: json.RawMessage(`[]`)})
+	if err == nil || !strings.Contains(err.Error(), "from schema version 1") {
+		t.Errorf("Failing upcaster error = %v", err)
+	}
+
+	delete(payloadTypes[testWidget].upcasters, 2)
+	if _, err = Upcast(BaseEvent{EventType: testWidget, SchemaVersion: 2}); err == nil {
+		t.Error("Expected error for missing upcaster")
+	}
+}
+
+func TestUpcast_PortfolioReportV1(t *testing.T) {
+	evt, err := ParseEvent([]byte(`{"event_type":"portfolio.report","timestamp":"2024-01-01","payload":{"portfolios":[]}}`))
+	if err != nil {
+		t.Fatalf("ParseEvent error: %v", err)
+	}
+	up, err := Upcast(evt)
+	if err != nil || up.SchemaVersion != SchemaVersionFor(PortfolioReport) {
+		t.Errorf("Upcast v1 portfolio.report = v%d, %v", up.SchemaVersion, err)
+	}
+
+	if _, err := Upcast(BaseEvent{EventType: PortfolioReport, SchemaVersion: 99}); !errors.Is(err, ErrUnsupportedSchemaVersion) {
+		t.Errorf("Expected future portfolio.report version to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimestampLayouts Timestamp'in kabul ettiği formatlar, deneme sırasına göre.
+// Saat dilimi içermeyen formatlar UTC olarak yorumlanır.
+var TimestampLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02"}
+
+// Timestamp event'lerdeki tarih/saat alanları için ortak tip. Birden fazla
+// formatta okunur, her zaman RFC3339 olarak yazılır. Sıfır değer "" olarak yazılır
+// ve alanın boş olduğunu gösterir. JSON'dan okunan ve hiçbir formata uymayan
+// değerler payload'ın çözülmesini engellemez; girdi saklanır (bkz. InvalidInput) ve
+// doğrulama sırasında alan yoluyla RuleDate hatası olarak bildirilir.
+type Timestamp struct {
+	time.Time
+	invalid string
+}
+
+// NewTimestamp verilen zamandan bir Timestamp oluşturur
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{Time: t}
+}
+
+// Now şu anın UTC Timestamp'ini döndürür (saniye hassasiyetinde)
+func Now() Timestamp {
+	return Timestamp{Time: time.Now().UTC().Truncate(time.Second)}
+}
+
+// ParseTimestamp metni kabul edilen formatlardan biriyle çözer. Boş metin sıfır
+// değer döndürür; hiçbir formata uymayan değerler için hata döner.
+func ParseTimestamp(s string) (Timestamp, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Timestamp{}, nil
+	}
+	for _, layout := range TimestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return Timestamp{Time: t}, nil
+		}
+	}
+	return Timestamp{}, fmt.Errorf("invalid timestamp %q: expected RFC3339, 2006-01-02 15:04:05 or 2006-01-02", s)
+}
+
+// MustParseTimestamp ParseTimestamp gibidir ancak hata durumunda panic eder;
+// sabit değerli örnek ve test verileri için kullanılır
+func MustParseTimestamp(s string) Timestamp {
+	ts, err := ParseTimestamp(s)
+	if err != nil {
+		panic(err)
+	}
+	return ts
+}
+
+// InvalidInput JSON'dan okunup çözülemeyen girdiyi döndürür; değer geçerliyse boş metin
+func (t Timestamp) InvalidInput() string {
+	return t.invalid
+}
+
+// String zamanı kanonik biçimde (RFC3339) döndürür; sıfır değer için boş metin
+func (t Timestamp) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Time.Format(time.RFC3339)
+}
+
+// MarshalText Timestamp'i kanonik biçimde yazar. Çözülemeyen girdiler olduğu gibi
+// geri yazılır, böylece yeniden okunduğunda da geçersiz kalır.
+func (t Timestamp) MarshalText() ([]byte, error) {
+	if t.invalid != "" {
+		return []byte(t.invalid), nil
+	}
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText Timestamp'i kabul edilen formatlardan biriyle okur
+func (t *Timestamp) UnmarshalText(data []byte) error {
+	parsed, err := ParseTimestamp(string(data))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// MarshalJSON gömülü time.Time'ın RFC3339Nano yazımının yerine kanonik biçimi kullanır
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	text, _ := t.MarshalText()
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON Timestamp'i JSON metninden okur; null sıfır değer bırakır. Metin
+// olmayan değerler hata döndürür; hiçbir formata uymayan metinler ise hata yerine
+// InvalidInput'ta saklanır ki payload'daki diğer alan hataları da birlikte bildirilebilsin.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid timestamp %s: must be a string", data)
+	}
+	parsed, err := ParseTimestamp(s)
+	if err != nil {
+		*t = Timestamp{invalid: s}
+		return nil
+	}
+	*t = parsed
+	return nil
+}
@@ -0,0 +1,63 @@
+package event
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	cases := map[string]string{
+		"2024-03-01T10:30:00Z":      "2024-03-01T10:30:00Z",
+		"2024-03-01T10:30:00+03:00": "2024-03-01T10:30:00+03:00",
+		"2024-03-01 10:30:00":       "2024-03-01T10:30:00Z",
+		"2024-03-01":                "2024-03-01T00:00:00Z",
+		"":                          "",
+	}
+	for in, want := range cases {
+		ts, err := ParseTimestamp(in)
+		if err != nil {
+			t.Errorf("ParseTimestamp(%q) error: %v", in, err)
+			continue
+		}
+		if got := ts.String(); got != want {
+			t.Errorf("ParseTimestamp(%q) = %q; want %q", in, got, want)
+		}
+	}
+
+	for _, garbage := range []string{"yesterday", "2024-13-01", "01/03/2024"} {
+		if _, err := ParseTimestamp(garbage); err == nil || !strings.Contains(err.Error(), garbage) {
+			t.Errorf("ParseTimestamp(%q) error = %v; want error naming the value", garbage, err)
+		}
+	}
+}
+
+func TestTimestamp_JSON(t *testing.T) {
+	var p Portfolio
+	if err := json.Unmarshal([]byte(`{"createdAt":"2024-03-01 10:30:00","lastUpdate":null}`), &p); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if !p.CreatedAt.Equal(time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)) || !p.LastUpdate.IsZero() {
+		t.Errorf("Unexpected timestamps %v / %v", p.CreatedAt, p.LastUpdate)
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if !strings.Contains(string(data), `"createdAt":"2024-03-01T10:30:00Z"`) || !strings.Contains(string(data), `"lastUpdate":""`) {
+		t.Errorf("Timestamps not marshalled canonically: %s", data)
+	}
+
+	// Unparseable dates are kept for validation instead of failing the whole payload
+	if err := json.Unmarshal([]byte(`{"createdAt":"soon"}`), &p); err != nil || p.CreatedAt.InvalidInput() != "soon" || !p.CreatedAt.IsZero() {
+		t.Errorf("Unmarshal invalid timestamp = %v, %q; want no error and recorded input", err, p.CreatedAt.InvalidInput())
+	}
+	if data, _ := json.Marshal(p); !strings.Contains(string(data), `"createdAt":"soon"`) {
+		t.Errorf("Invalid timestamp not written back as given: %s", data)
+	}
+	if err := json.Unmarshal([]byte(`{"createdAt":20240301}`), &p); err == nil {
+		t.Error("Expected error for non-string timestamp")
+	}
+}
@@ -0,0 +1,153 @@
+package event
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TransactionType bir işlemin türü
+type TransactionType string
+
+// İşlem türleri
+const (
+	TransactionBuy        TransactionType = "buy"
+	TransactionSell       TransactionType = "sell"
+	TransactionDeposit    TransactionType = "deposit"
+	TransactionWithdrawal TransactionType = "withdrawal"
+	TransactionFee        TransactionType = "fee"
+)
+
+// TransactionTypes tüm işlem türleri, raporlardaki sıralarıyla
+var TransactionTypes = []TransactionType{
+	TransactionDeposit, TransactionWithdrawal, TransactionBuy, TransactionSell, TransactionFee,
+}
+
+// Transaction bir portföydeki tek bir işlem. Alım/satımlarda Amount boşsa
+// Quantity * Price kullanılır; nakit işlemlerinde yalnızca Amount anlamlıdır.
+// Tutarlar portföyün para birimindedir.
+type Transaction struct {
+	TransactionID string          `json:"transactionID,omitempty"`
+	Date          Timestamp       `json:"date"`
+	Type          TransactionType `json:"type"`
+	Instrument    string          `json:"instrument,omitempty"` // alım/satımda enstrüman (ör. AAPL)
+	Quantity      Decimal         `json:"quantity,omitempty"`
+	Price         Decimal         `json:"price,omitempty"`
+	Amount        Decimal         `json:"amount,omitempty" jsonschema:"minimum=0"` // işlemin pozitif nakit tutarı
+	Description   string          `json:"description,omitempty"`
+}
+
+// CashAmount işlemin işaretsiz nakit tutarını döndürür
+func (t Transaction) CashAmount() Decimal {
+	if t.Amount.IsZero() && (t.Type == TransactionBuy || t.Type == TransactionSell) {
+		return t.Quantity.Mul(t.Price)
+	}
+	return t.Amount
+}
+
+// CashFlow işlemin nakit bakiyesine etkisini döndürür: yatırma ve satış
+// bakiyeyi artırır, çekme, alım ve ücretler azaltır
+func (t Transaction) CashFlow() Decimal {
+	switch t.Type {
+	case TransactionDeposit, TransactionSell:
+		return t.CashAmount()
+	default:
+		return t.CashAmount().Neg()
+	}
+}
+
+// PortfolioTransactions bir portföyün işlem geçmişi
+type PortfolioTransactions struct {
+	PortID         int           `json:"portID" jsonschema:"minimum=1"`
+	Name           string        `json:"name" jsonschema:"minLength=1"`
+	UserID         string        `json:"userID" jsonschema:"minLength=1"`
+	Currency       Currency      `json:"currency,omitempty"`       // tutarların para birimi; boşsa USD
+	OpeningBalance Decimal       `json:"openingBalance,omitempty"` // ilk işlemden önceki nakit bakiye
+	Transactions   []Transaction `json:"transactions"`
+}
+
+// TransactionReportPayload transaction.report olayının payload'ını tanımlar
+type TransactionReportPayload struct {
+	Portfolios []PortfolioTransactions `json:"portfolios" jsonschema:"minItems=1"`
+}
+
+// NewTransactionReportEvent yeni bir transaction report event'i oluşturur
+func NewTransactionReportEvent(portfolios []PortfolioTransactions) (BaseEvent, error) {
+	return NewBaseEvent(TransactionReport, TransactionReportPayload{Portfolios: portfolios})
+}
+
+// UserIDs payload'daki portföylerin kullanıcılarını portföy sırasıyla döndürür
+func (p TransactionReportPayload) UserIDs() []string {
+	ids := make([]string, len(p.Portfolios))
+	for i, portfolio := range p.Portfolios {
+		ids[i] = portfolio.UserID
+	}
+	return ids
+}
+
+// Validate transaction.report payload'ını doğrular ve tüm alan hatalarını birlikte döndürür
+func (p TransactionReportPayload) Validate() error {
+	if errs := validateTransactionPortfolios(p.Portfolios); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateTransactionPortfolios işlem geçmişi taşıyan portföyleri doğrular; işlem
+// geçmişini kullanan tüm payload'lar aynı kuralları paylaşır
+func validateTransactionPortfolios(portfolios []PortfolioTransactions) ValidationErrors {
+	var errs ValidationErrors
+	add := func(path, rule string, value interface{}, message string) {
+		errs = append(errs, FieldError{Path: path, Rule: rule, Value: value, Message: message})
+	}
+
+	if len(portfolios) == 0 {
+		add("portfolios", RuleRequired, nil, "must contain at least one portfolio")
+	}
+	seen := make(map[int]int)
+	for i, portfolio := range portfolios {
+		path := fmt.Sprintf("portfolios[%d]", i)
+		if portfolio.PortID <= 0 {
+			add(path+".portID", RuleMin, portfolio.PortID, "must be a positive integer")
+		} else if first, dup := seen[portfolio.PortID]; dup {
+			add(path+".portID", RuleUnique, portfolio.PortID, fmt.Sprintf("duplicates portfolios[%d].portID", first))
+		} else {
+			seen[portfolio.PortID] = i
+		}
+		if strings.TrimSpace(portfolio.UserID) == "" {
+			add(path+".userID", RuleRequired, portfolio.UserID, "must not be blank")
+		}
+		checkCurrency(add, path+".currency", portfolio.Currency)
+		checkDecimal(add, path+".openingBalance", portfolio.OpeningBalance)
+
+		for j, t := range portfolio.Transactions {
+			tpath := fmt.Sprintf("%s.transactions[%d]", path, j)
+			checkTimestamp(add, tpath+".date", t.Date, true)
+			quantityOK := checkDecimal(add, tpath+".quantity", t.Quantity)
+			priceOK := checkDecimal(add, tpath+".price", t.Price)
+			amountOK := checkDecimal(add, tpath+".amount", t.Amount)
+			switch t.Type {
+			case TransactionBuy, TransactionSell:
+				if strings.TrimSpace(t.Instrument) == "" {
+					add(tpath+".instrument", RuleRequired, t.Instrument, "must not be blank for "+string(t.Type))
+				}
+				if quantityOK && t.Quantity.Sign() <= 0 {
+					add(tpath+".quantity", RuleMin, t.Quantity.String(), "must be positive for "+string(t.Type))
+				}
+				if priceOK && t.Price.Sign() < 0 {
+					add(tpath+".price", RuleMin, t.Price.String(), "must not be negative")
+				}
+			case TransactionDeposit, TransactionWithdrawal, TransactionFee:
+				if amountOK && t.Amount.Sign() <= 0 {
+					add(tpath+".amount", RuleMin, t.Amount.String(), "must be positive for "+string(t.Type))
+				}
+			default:
+				add(tpath+".type", RuleOneOf, t.Type, "must be one of buy, sell, deposit, withdrawal, fee")
+			}
+			if amountOK && t.Amount.Sign() < 0 {
+				add(tpath+".amount", RuleMin, t.Amount.String(), "must not be negative")
+			}
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,99 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestTransactionReportPayload_Validate(t *testing.T) {
+	date := MustParseTimestamp("2024-01-01")
+	valid := TransactionReportPayload{Portfolios: []PortfolioTransactions{{
+		PortID: 1, Name: "A", UserID: "u1",
+		Transactions: []Transaction{
+			{Date: date, Type: TransactionDeposit, Amount: MustParseDecimal("100")},
+			{Date: date, Type: TransactionBuy, Instrument: "AAPL", Quantity: MustParseDecimal("1"), Price: MustParseDecimal("90")},
+		},
+	}}}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate on valid payload returned %v", err)
+	}
+
+	invalid := TransactionReportPayload{Portfolios: []PortfolioTransactions{{
+		PortID: 1, Name: "A", UserID: "u1",
+		Transactions: []Transaction{
+			{Type: TransactionDeposit, Amount: MustParseDecimal("100")},
+			{Date: date, Type: TransactionSell, Quantity: MustParseDecimal("0")},
+			{Date: date, Type: TransactionFee},
+			{Date: date, Type: "dividend", Amount: MustParseDecimal("5")},
+		},
+	}}}
+	var verrs ValidationErrors
+	if !errors.As(invalid.Validate(), &verrs) {
+		t.Fatal("Expected ValidationErrors")
+	}
+	want := map[string]string{
+		"portfolios[0].transactions[0].date":       RuleRequired,
+		"portfolios[0].transactions[1].instrument": RuleRequired,
+		"portfolios[0].transactions[1].quantity":   RuleMin,
+		"portfolios[0].transactions[2].amount":     RuleMin,
+		"portfolios[0].transactions[3].type":       RuleOneOf,
+	}
+	if len(verrs) != len(want) {
+		t.Errorf("Got %d errors, want %d: %v", len(verrs), len(want), verrs)
+	}
+	for _, fe := range verrs {
+		if rule, ok := want[fe.Path]; !ok || rule != fe.Rule {
+			t.Errorf("Unexpected error %s (%s)", fe.Path, fe.Rule)
+		}
+	}
+}
+
+func TestTransactionReportPayload_JSON(t *testing.T) {
+	data := []byte(`{"portfolios":[{"portID":1,"name":"A","userID":"u1","currency":"XXX","openingBalance":"0.10",
+		"transactions":[
+			{"date":"2024-01-01","type":"buy","instrument":"AAPL","quantity":3,"price":"0.1"},
+			{"date":"2024-01-02","type":"deposit","amount":"1e99"}]}]}`)
+
+	var p TransactionReportPayload
+	if err := json.Unmarshal(data, &p); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	// 3 * 0.1 is exact, unlike with floats
+	if got := p.Portfolios[0].Transactions[0].CashFlow(); got.String() != "-0.3" {
+		t.Errorf("CashFlow = %s; want -0.3", got)
+	}
+
+	var verrs ValidationErrors
+	if !errors.As(p.Validate(), &verrs) {
+		t.Fatal("Expected ValidationErrors")
+	}
+	want := map[string]string{
+		"portfolios[0].currency":               RuleOneOf,
+		"portfolios[0].transactions[1].amount": RuleDecimal,
+	}
+	if len(verrs) != len(want) {
+		t.Errorf("Got %d errors, want %d: %v", len(verrs), len(want), verrs)
+	}
+	for _, fe := range verrs {
+		if rule, ok := want[fe.Path]; !ok || rule != fe.Rule {
+			t.Errorf("Unexpected error %s (%s)", fe.Path, fe.Rule)
+		}
+	}
+}
+
+func TestRealizedGainsReportPayload_Validate(t *testing.T) {
+	p := RealizedGainsReportPayload{Method: "lifo", Portfolios: []PortfolioTransactions{{PortID: 1, Name: "A", UserID: "u1"}}}
+	var verrs ValidationErrors
+	if !errors.As(p.Validate(), &verrs) || len(verrs) != 1 || verrs[0].Path != "method" || verrs[0].Rule != RuleOneOf {
+		t.Errorf("Expected a single method oneOf error, got %v", verrs)
+	}
+
+	p.Method = ""
+	if err := p.Validate(); err != nil {
+		t.Errorf("Validate with default method returned %v", err)
+	}
+	if p.LotMethodOrDefault() != LotFIFO {
+		t.Errorf("LotMethodOrDefault = %q; want fifo", p.LotMethodOrDefault())
+	}
+}
@@ -0,0 +1,175 @@
+package event
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Doğrulama kuralları
+const (
+	RuleRequired = "required"
+	RuleMin      = "min"
+	RuleMax      = "max"
+	RuleUnique   = "unique"
+	RuleOneOf    = "oneOf"
+	RuleSyntax   = "syntax"
+	RuleDate     = "date"
+	RuleDecimal  = "decimal"
+)
+
+// MaxValidationErrors bir payload için listelenen en fazla doğrulama hatası; fazlası
+// tek bir özet satırında sayılır. Hatalar iş kaydına ve loglara yazıldığından sınırsız
+// bir liste, akış yolunun sabit bellek kullanımını boşa çıkarır.
+const MaxValidationErrors = MaxQualityIssues
+
+// FieldError tek bir alanın doğrulama hatasını tanımlar
+type FieldError struct {
+	Path    string      `json:"path"`            // ör. portfolios[2].portID
+	Rule    string      `json:"rule"`            // ihlal edilen kural
+	Value   interface{} `json:"value,omitempty"` // hatalı değer
+	Message string      `json:"message"`
+}
+
+// Error FieldError'u okunabilir bir metne dönüştürür
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidationErrors bir payload'daki tüm alan hatalarını birlikte taşır
+type ValidationErrors []FieldError
+
+// Error tüm alan hatalarını tek bir metinde birleştirir
+func (v ValidationErrors) Error() string {
+	msgs := make([]string, len(v))
+	for i, e := range v {
+		msgs[i] = e.Error()
+	}
+	return fmt.Sprintf("validation failed with %d error(s): %s", len(v), strings.Join(msgs, "; "))
+}
+
+// Truncate listeyi en fazla max hataya indirir; atılan hatalar path'e yazılan tek bir
+// özet satırında sayılır. Liste sınırı aşmıyorsa olduğu gibi döner.
+func (v ValidationErrors) Truncate(max int, path string) ValidationErrors {
+	if len(v) <= max {
+		return v
+	}
+	return append(v[:max:max], omittedErrors(path, len(v)-max))
+}
+
+// omittedErrors listelenmeyen doğrulama hatalarını sayan özet satırı
+func omittedErrors(path string, omitted int) FieldError {
+	return FieldError{
+		Path:    path,
+		Rule:    RuleMax,
+		Value:   omitted,
+		Message: fmt.Sprintf("%d more validation errors not listed", omitted),
+	}
+}
+
+// Validator kendi alanlarını doğrulayabilen payload'lar için arayüz
+type Validator interface {
+	// Validate geçersiz alanlar varsa ValidationErrors döndürür
+	Validate() error
+}
+
+// Validate portfolio.report payload'ını doğrular ve tüm alan hatalarını birlikte döndürür
+func (p PortfolioReportPayload) Validate() error {
+	v := NewPortfolioValidator()
+	v.CheckPeriod(p.ReportPeriod)
+	v.CheckFilter(p.Filter)
+	for i, portfolio := range p.Portfolios {
+		v.Check(i, portfolio)
+	}
+	return v.Err()
+}
+
+// PortfolioValidator portföyleri tek tek doğrular; akış yolunda tüm liste belleğe
+// alınmadan kullanılabilir. Tekrarlanan PortID ve boş ad gibi raporu engellemeyen
+// sorunlar burada değil PortfolioQualityChecker'da bulunur. En fazla MaxValidationErrors
+// hata tutulur; fazlası yalnızca sayılır.
+type PortfolioValidator struct {
+	count   int
+	errors  ValidationErrors
+	omitted int
+}
+
+// NewPortfolioValidator yeni bir portföy doğrulayıcı oluşturur
+func NewPortfolioValidator() *PortfolioValidator {
+	return &PortfolioValidator{}
+}
+
+// Check i. sıradaki portföyü doğrular
+func (v *PortfolioValidator) Check(i int, p Portfolio) {
+	v.count++
+	path := fmt.Sprintf("portfolios[%d]", i)
+
+	if p.PortID <= 0 {
+		v.add(path+".portID", RuleMin, p.PortID, "must be a positive integer")
+	}
+	if strings.TrimSpace(p.UserID) == "" {
+		v.add(path+".userID", RuleRequired, p.UserID, "must not be blank")
+	}
+	checkTimestamp(v.add, path+".createdAt", p.CreatedAt, true)
+	checkTimestamp(v.add, path+".lastUpdate", p.LastUpdate, true)
+}
+
+// CheckPeriod payload'ın dönem parametrelerini doğrular
+func (v *PortfolioValidator) CheckPeriod(period ReportPeriod) {
+	period.validate(v.add)
+}
+
+// CheckFilter payload'ın filtre ifadesini doğrular
+func (v *PortfolioValidator) CheckFilter(src string) {
+	validateFilter(src, v.add)
+}
+
+// Err toplanan hataları döndürür; hata yoksa nil döner. MaxValidationErrors'tan fazla
+// hata varsa listelenmeyenler son satırda sayılır.
+func (v *PortfolioValidator) Err() error {
+	if v.count == 0 {
+		v.add("portfolios", RuleRequired, nil, "must contain at least one portfolio")
+	}
+	if len(v.errors) == 0 {
+		return nil
+	}
+	if v.omitted > 0 {
+		return append(v.errors[:len(v.errors):len(v.errors)], omittedErrors("portfolios", v.omitted))
+	}
+	return v.errors
+}
+
+// add yeni bir alan hatası ekler
+func (v *PortfolioValidator) add(path, rule string, value interface{}, message string) {
+	if len(v.errors) >= MaxValidationErrors {
+		v.omitted++
+		return
+	}
+	v.errors = append(v.errors, FieldError{Path: path, Rule: rule, Value: value, Message: message})
+}
+
+// checkTimestamp bir tarih alanını doğrular: çözülemeyen değerler için RuleDate,
+// required ise eksik değerler için RuleRequired hatası ekler. Alan geçerli ve
+// doluysa true döner.
+func checkTimestamp(add func(path, rule string, value interface{}, message string), path string, t Timestamp, required bool) bool {
+	if input := t.InvalidInput(); input != "" {
+		add(path, RuleDate, input, "must be a date (RFC3339, 2006-01-02 15:04:05 or 2006-01-02)")
+		return false
+	}
+	if t.IsZero() {
+		if required {
+			add(path, RuleRequired, nil, "must be set")
+		}
+		return false
+	}
+	return true
+}
+
+// checkDecimal bir ondalık alanı doğrular: çözülemeyen ya da sınırları aşan değerler
+// için RuleDecimal hatası ekler. Alan geçerliyse true döner.
+func checkDecimal(add func(path, rule string, value interface{}, message string), path string, d Decimal) bool {
+	if input := d.InvalidInput(); input != "" {
+		add(path, RuleDecimal, input, fmt.Sprintf("must be a decimal number with at most %d decimal places and an exponent of at most %d", MaxDecimalScale, MaxDecimalExponent))
+		return false
+	}
+	return true
+}
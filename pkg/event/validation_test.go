@@ -0,0 +1,85 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestPortfolioReportPayload_Validate(t *testing.T) {
+	valid := PortfolioReportPayload{Portfolios: CreateSamplePortfolios()}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate on sample portfolios returned %v", err)
+	}
+
+	raw := []byte(`{"portfolios":[
+		{"portID":1,"name":"A","userID":"u1","createdAt":"2023-01-01","lastUpdate":"2023-01-02T10:00:00Z"},
+		{"portID":0,"name":" ","userID":"u2","createdAt":"2023-01-01 00:00:00","lastUpdate":"yesterday"},
+		{"portID":1,"name":"C","userID":"","createdAt":"2023-01-01","lastUpdate":"2023-01-01"}]}`)
+	var invalid PortfolioReportPayload
+	if err := json.Unmarshal(raw, &invalid); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	err := invalid.Validate()
+
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("Validate error = %v; want ValidationErrors", err)
+	}
+
+	// Tekrarlanan PortID ve boş ad işi durdurmaz; bunlar veri kalitesi sorunlarıdır (bkz. quality_test.go)
+	want := map[string]string{
+		"portfolios[1].portID":     RuleMin,
+		"portfolios[1].lastUpdate": RuleDate,
+		"portfolios[2].userID":     RuleRequired,
+	}
+	if len(verrs) != len(want) {
+		t.Errorf("Got %d errors, want %d: %v", len(verrs), len(want), verrs)
+	}
+	for _, fe := range verrs {
+		if rule, ok := want[fe.Path]; !ok || rule != fe.Rule {
+			t.Errorf("Unexpected error %s (%s)", fe.Path, fe.Rule)
+		}
+	}
+}
+
+func TestPortfolioReportPayload_ValidateEmpty(t *testing.T) {
+	err := PortfolioReportPayload{}.Validate()
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) || len(verrs) != 1 || verrs[0].Path != "portfolios" || verrs[0].Rule != RuleRequired {
+		t.Errorf("Validate on empty payload = %v; want single required error on portfolios", err)
+	}
+}
+
+func TestPortfolioValidator_Limit(t *testing.T) {
+	v := NewPortfolioValidator()
+	for i := 0; i < MaxValidationErrors; i++ {
+		// Her satırda iki hata: eksik createdAt ve lastUpdate
+		v.Check(i, Portfolio{PortID: i + 1, UserID: "u"})
+	}
+	var verrs ValidationErrors
+	if !errors.As(v.Err(), &verrs) {
+		t.Fatal("Expected ValidationErrors")
+	}
+	if len(verrs) != MaxValidationErrors+1 {
+		t.Fatalf("Got %d errors; want %d", len(verrs), MaxValidationErrors+1)
+	}
+	last := verrs[len(verrs)-1]
+	if last.Path != "portfolios" || last.Rule != RuleMax || last.Value != MaxValidationErrors {
+		t.Errorf("Overflow summary = %v", last)
+	}
+}
+
+func TestValidationErrors_Truncate(t *testing.T) {
+	errs := ValidationErrors{{Path: "a"}, {Path: "b"}, {Path: "c"}}
+	if got := errs.Truncate(3, "payload"); len(got) != 3 {
+		t.Errorf("Truncate within the limit changed the list: %v", got)
+	}
+	got := errs.Truncate(1, "payload")
+	if len(got) != 2 || got[0].Path != "a" || got[1].Path != "payload" || got[1].Value != 2 {
+		t.Errorf("Truncate(1) = %v", got)
+	}
+	if len(errs) != 3 || errs[1].Path != "b" {
+		t.Errorf("Truncate modified the original list: %v", errs)
+	}
+}
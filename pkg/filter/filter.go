@@ -0,0 +1,236 @@
+// Package filter rapor isteklerinde veri alt kümesi seçmek için küçük ve güvenli bir
+// filtre ifadesi dili sağlar:
+//
+//	userID in ("u1", "u2") and lastUpdate >= 2026-01-01
+//	name like "Growth*" or not portID in (3, 4)
+//
+// İfadeler yalnızca bir şemada tanımlı alanları karşılaştırabilir; fonksiyon çağrısı,
+// aritmetik ya da döngü yoktur. Uzunluk ve iç içe geçme derinliği sınırlıdır, bu yüzden
+// değerlendirme süresi ifadenin boyutuyla doğrusal kalır.
+package filter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// İfade sınırları
+const (
+	MaxLength = 1024 // karakter
+	MaxDepth  = 32   // iç içe parantez / not
+)
+
+// Kind bir alanın ya da değerin türü
+type Kind int
+
+// Alan türleri
+const (
+	Number Kind = iota + 1
+	String
+	Time
+)
+
+// String türün adını döndürür
+func (k Kind) String() string {
+	switch k {
+	case Number:
+		return "number"
+	case String:
+		return "string"
+	case Time:
+		return "date"
+	}
+	return "unknown"
+}
+
+// Schema ifadelerde kullanılabilecek alanları ve türlerini tanımlar. Alan adları
+// büyük/küçük harf duyarsız eşleştirilir.
+type Schema map[string]Kind
+
+// lookup alanı büyük/küçük harf duyarsız arar ve şemadaki adıyla döndürür
+func (s Schema) lookup(name string) (string, Kind, bool) {
+	if kind, ok := s[name]; ok {
+		return name, kind, true
+	}
+	for field, kind := range s {
+		if strings.EqualFold(field, name) {
+			return field, kind, true
+		}
+	}
+	return "", 0, false
+}
+
+// fieldList şemadaki alanları sıralı ve virgülle ayrılmış döndürür
+func (s Schema) fieldList() string {
+	fields := make([]string, 0, len(s))
+	for field := range s {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return strings.Join(fields, ", ")
+}
+
+// Value bir alanın değeri
+type Value struct {
+	Kind Kind
+	Num  float64
+	Str  string
+	Time time.Time
+}
+
+// NumberValue sayı değeri oluşturur
+func NumberValue(n float64) Value { return Value{Kind: Number, Num: n} }
+
+// StringValue metin değeri oluşturur
+func StringValue(s string) Value { return Value{Kind: String, Str: s} }
+
+// TimeValue tarih değeri oluşturur
+func TimeValue(t time.Time) Value { return Value{Kind: Time, Time: t} }
+
+// compare aynı türdeki iki değeri karşılaştırır: a < b ise -1, eşitse 0, büyükse 1
+func compare(a, b Value) int {
+	switch a.Kind {
+	case Number:
+		switch {
+		case a.Num < b.Num:
+			return -1
+		case a.Num > b.Num:
+			return 1
+		}
+	case String:
+		return strings.Compare(a.Str, b.Str)
+	case Time:
+		return a.Time.Compare(b.Time)
+	}
+	return 0
+}
+
+// Record değerlendirilen kaydın alanlarını döndürür. Alan adı şemadaki adıdır.
+type Record func(field string) Value
+
+// Expr derlenmiş bir filtre ifadesi
+type Expr interface {
+	// Match kaydın ifadeyi sağlayıp sağlamadığını döndürür
+	Match(r Record) bool
+}
+
+// SyntaxError ifadenin çözülemediği yeri ve nedenini bildirir
+type SyntaxError struct {
+	Pos int // 1'den başlayan karakter konumu
+	Msg string
+}
+
+// Error SyntaxError'u okunabilir bir metne dönüştürür
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("at position %d: %s", e.Pos, e.Msg)
+}
+
+// andExpr tüm alt ifadeler sağlandığında eşleşir
+type andExpr []Expr
+
+func (e andExpr) Match(r Record) bool {
+	for _, x := range e {
+		if !x.Match(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// orExpr alt ifadelerden biri sağlandığında eşleşir
+type orExpr []Expr
+
+func (e orExpr) Match(r Record) bool {
+	for _, x := range e {
+		if x.Match(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// notExpr alt ifadenin tersidir
+type notExpr struct{ x Expr }
+
+func (e notExpr) Match(r Record) bool { return !e.x.Match(r) }
+
+// Karşılaştırma operatörleri
+const (
+	opEq   = "="
+	opNe   = "!="
+	opLt   = "<"
+	opLe   = "<="
+	opGt   = ">"
+	opGe   = ">="
+	opIn   = "in"
+	opLike = "like"
+)
+
+// compareExpr bir alanı bir ya da daha fazla sabit değerle karşılaştırır
+type compareExpr struct {
+	field  string
+	op     string
+	values []Value
+}
+
+func (e compareExpr) Match(r Record) bool {
+	v := r(e.field)
+	switch e.op {
+	case opIn:
+		for _, want := range e.values {
+			if compare(v, want) == 0 {
+				return true
+			}
+		}
+		return false
+	case opLike:
+		return matchPattern(strings.ToLower(e.values[0].Str), strings.ToLower(v.Str))
+	}
+
+	c := compare(v, e.values[0])
+	switch e.op {
+	case opEq:
+		return c == 0
+	case opNe:
+		return c != 0
+	case opLt:
+		return c < 0
+	case opLe:
+		return c <= 0
+	case opGt:
+		return c > 0
+	default: // opGe
+		return c >= 0
+	}
+}
+
+// matchPattern s'nin glob kalıbına uyup uymadığını döndürür: * herhangi bir metne,
+// ? tek bir karaktere uyar. Geri izleme en son * ile sınırlıdır, bu yüzden süre
+// len(pattern)*len(s) ile sınırlıdır.
+func matchPattern(pattern, s string) bool {
+	p, t := []rune(pattern), []rune(s)
+	pi, ti := 0, 0
+	star, mark := -1, 0
+	for ti < len(t) {
+		switch {
+		case pi < len(p) && (p[pi] == '?' || p[pi] == t[ti]):
+			pi++
+			ti++
+		case pi < len(p) && p[pi] == '*':
+			star, mark = pi, ti
+			pi++
+		case star >= 0:
+			pi = star + 1
+			mark++
+			ti = mark
+		default:
+			return false
+		}
+	}
+	for pi < len(p) && p[pi] == '*' {
+		pi++
+	}
+	return pi == len(p)
+}
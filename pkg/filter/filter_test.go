@@ -0,0 +1,139 @@
+package filter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSchema = Schema{"id": Number, "name": String, "user": String, "updated": Time}
+
+// testRecord test kaydı
+type testRecord struct {
+	id      float64
+	name    string
+	user    string
+	updated time.Time
+}
+
+func (r testRecord) get(field string) Value {
+	switch field {
+	case "id":
+		return NumberValue(r.id)
+	case "name":
+		return StringValue(r.name)
+	case "user":
+		return StringValue(r.user)
+	}
+	return TimeValue(r.updated)
+}
+
+func TestParse_Match(t *testing.T) {
+	day := func(s string) time.Time { v, _ := time.Parse("2006-01-02", s); return v }
+	records := []testRecord{
+		{1, "Growth Fund", "u1", day("2025-12-31")},
+		{2, "Income", "u2", day("2026-01-01")},
+		{3, "growth-2", "u3", day("2026-03-15")},
+	}
+
+	tests := []struct {
+		expr string
+		want []float64
+	}{
+		{`id = 2`, []float64{2}},
+		{`id == 2`, []float64{2}},
+		{`id != 2`, []float64{1, 3}},
+		{`id >= 2 and id < 3`, []float64{2}},
+		{`user in ("u1", 'u3')`, []float64{1, 3}},
+		{`user not in ("u1")`, []float64{2, 3}},
+		{`updated >= 2026-01-01`, []float64{2, 3}},
+		{`updated < "2026-01-01 00:00:01"`, []float64{1, 2}},
+		{`updated > 2026-03-15T00:00:00Z`, nil},
+		{`name like "growth*"`, []float64{1, 3}},
+		{`name like "gr?wth-*"`, []float64{3}},
+		{`name not like "*fund"`, []float64{2, 3}},
+		{`id = 1 or id = 2 and user = "u3"`, []float64{1}},
+		{`(id = 1 or id = 2) and user = "u2"`, []float64{2}},
+		{`not (id = 1 or id = 3)`, []float64{2}},
+		{`NAME LIKE "income" AND Updated >= 2026-01-01`, []float64{2}},
+		{`id in (-1, 3.0)`, []float64{3}},
+	}
+	for _, tt := range tests {
+		expr, err := Parse(tt.expr, testSchema)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", tt.expr, err)
+			continue
+		}
+		var got []float64
+		for _, r := range records {
+			if expr.Match(r.get) {
+				got = append(got, r.id)
+			}
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%q matched %v; want %v", tt.expr, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%q matched %v; want %v", tt.expr, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		expr string
+		pos  int
+		msg  string
+	}{
+		{``, 1, "empty expression"},
+		{`owner = "x"`, 1, `unknown field "owner"`},
+		{`id = "x"`, 6, "expected a number value"},
+		{`name > 5`, 8, "expected a string value"},
+		{`updated >= 2026-13-01`, 12, "invalid date"},
+		{`id like "1*"`, 4, "like requires a string field"},
+		{`name = "x`, 8, "unterminated string"},
+		{`id = 1 and`, 11, "unexpected end of expression"},
+		{`id = 1 id = 2`, 8, "expected and, or or end of expression"},
+		{`(id = 1`, 8, `expected ")"`},
+		{`user in ("a" "b")`, 14, `expected "," or ")"`},
+		{`id ! 1`, 4, "use != or not"},
+		{`id = 1; drop`, 7, "unexpected character"},
+		{`name not = "x"`, 10, "expected in or like"},
+		{strings.Repeat("(", 40) + "id = 1" + strings.Repeat(")", 40), 33, "nested deeper than 32"},
+		{strings.Repeat(" ", MaxLength+1), MaxLength + 1, "longer than"},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.expr, testSchema)
+		var serr *SyntaxError
+		if !errors.As(err, &serr) {
+			t.Errorf("Parse(%q) = %v; want SyntaxError", tt.expr, err)
+			continue
+		}
+		if serr.Pos != tt.pos || !strings.Contains(serr.Msg, tt.msg) {
+			t.Errorf("Parse(%q) error = %q at %d; want %q at %d", tt.expr, serr.Msg, serr.Pos, tt.msg, tt.pos)
+		}
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		want       bool
+	}{
+		{"*", "", true},
+		{"a*b*c", "aXbYc", true},
+		{"a*b*c", "aXbY", false},
+		{"?", "ğ", true},
+		{"*" + strings.Repeat("a", 20) + "b", strings.Repeat("a", 100), false},
+	}
+	for _, tt := range tests {
+		if got := matchPattern(tt.pattern, tt.s); got != tt.want {
+			t.Errorf("matchPattern(%q, %q) = %v; want %v", tt.pattern, tt.s, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,365 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// TimeLayouts tarih sabitlerinin kabul edilen formatları, deneme sırasına göre.
+// Boşluk içeren format yalnızca tırnak içinde yazılabilir. Saat dilimi içermeyen
+// değerler UTC olarak yorumlanır.
+var TimeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02"}
+
+// tokenKind sözcük türü
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokDate
+	tokOp
+	tokLParen
+	tokRParen
+	tokComma
+)
+
+// token ifadedeki bir sözcük; pos 1'den başlayan karakter konumudur
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+var numberPattern = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+
+// lex ifadeyi sözcüklere ayırır
+func lex(src string) ([]token, error) {
+	var tokens []token
+	runes := []rune(src)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{tokLParen, "(", pos})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokRParen, ")", pos})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{tokComma, ",", pos})
+			i++
+		case r == '=' || r == '!' || r == '<' || r == '>':
+			op, width := string(r), 1
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				width = 2
+				if r != '=' { // == ile = aynıdır
+					op += "="
+				}
+			}
+			if op == "!" {
+				return nil, &SyntaxError{Pos: pos, Msg: `unexpected "!" (use != or not)`}
+			}
+			tokens = append(tokens, token{tokOp, op, pos})
+			i += width
+		case r == '"' || r == '\'':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != r; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) {
+					j++
+				}
+				sb.WriteRune(runes[j])
+			}
+			if j >= len(runes) {
+				return nil, &SyntaxError{Pos: pos, Msg: "unterminated string"}
+			}
+			tokens = append(tokens, token{tokString, sb.String(), pos})
+			i = j + 1
+		case unicode.IsDigit(r) || r == '-':
+			j := i + 1
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || strings.ContainsRune("-:.+TZ", runes[j])) {
+				j++
+			}
+			text := string(runes[i:j])
+			switch {
+			case numberPattern.MatchString(text):
+				tokens = append(tokens, token{tokNumber, text, pos})
+			case r != '-':
+				tokens = append(tokens, token{tokDate, text, pos})
+			default:
+				return nil, &SyntaxError{Pos: pos, Msg: fmt.Sprintf("invalid number %q", text)}
+			}
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i + 1
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_' || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{tokIdent, string(runes[i:j]), pos})
+			i = j
+		default:
+			return nil, &SyntaxError{Pos: pos, Msg: fmt.Sprintf("unexpected character %q", r)}
+		}
+	}
+	return append(tokens, token{tokEOF, "", len(runes) + 1}), nil
+}
+
+// parser sözcükleri özyinelemeli iniş ile ifade ağacına çevirir:
+//
+//	or         = and { "or" and }
+//	and        = unary { "and" unary }
+//	unary      = "not" unary | "(" or ")" | comparison
+//	comparison = field op literal | field ["not"] "in" "(" literal { "," literal } ")"
+//	           | field ["not"] "like" string
+type parser struct {
+	schema Schema
+	tokens []token
+	pos    int
+	depth  int
+}
+
+// Parse ifadeyi şemaya göre çözer ve türlerini denetler. Hatalar *SyntaxError'dur.
+func Parse(src string, schema Schema) (Expr, error) {
+	if len(src) > MaxLength {
+		return nil, &SyntaxError{Pos: MaxLength + 1, Msg: fmt.Sprintf("expression longer than %d characters", MaxLength)}
+	}
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{schema: schema, tokens: tokens}
+	if p.peek().kind == tokEOF {
+		return nil, &SyntaxError{Pos: 1, Msg: "empty expression"}
+	}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.unexpected(tok, "and, or or end of expression")
+	}
+	return expr, nil
+}
+
+// peek sıradaki sözcüğü döndürür
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+// next sıradaki sözcüğü tüketir
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// keyword sıradaki sözcük verilen anahtar kelimeyse tüketir
+func (p *parser) keyword(kw string) bool {
+	if tok := p.peek(); tok.kind == tokIdent && strings.EqualFold(tok.text, kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// unexpected beklenmeyen sözcük hatası oluşturur
+func (p *parser) unexpected(tok token, want string) error {
+	if tok.kind == tokEOF {
+		return &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("unexpected end of expression, expected %s", want)}
+	}
+	return &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("unexpected %q, expected %s", tok.text, want)}
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	exprs := orExpr{left}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, right)
+	}
+	if len(exprs) == 1 {
+		return left, nil
+	}
+	return exprs, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	exprs := andExpr{left}
+	for p.keyword("and") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, right)
+	}
+	if len(exprs) == 1 {
+		return left, nil
+	}
+	return exprs, nil
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	tok := p.peek()
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > MaxDepth {
+		return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("expression nested deeper than %d levels", MaxDepth)}
+	}
+
+	if p.keyword("not") {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{x}, nil
+	}
+	if tok.kind == tokLParen {
+		p.next()
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, p.unexpected(closing, `")"`)
+		}
+		return x, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Expr, error) {
+	tok := p.next()
+	if tok.kind != tokIdent {
+		return nil, p.unexpected(tok, "field name")
+	}
+	field, kind, ok := p.schema.lookup(tok.text)
+	if !ok {
+		return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("unknown field %q (known: %s)", tok.text, p.schema.fieldList())}
+	}
+
+	negate := p.keyword("not")
+	opTok := p.peek()
+	var expr Expr
+	switch {
+	case p.keyword(opIn):
+		values, err := p.parseList(kind)
+		if err != nil {
+			return nil, err
+		}
+		expr = compareExpr{field: field, op: opIn, values: values}
+	case p.keyword(opLike):
+		if kind != String {
+			return nil, &SyntaxError{Pos: opTok.pos, Msg: fmt.Sprintf("like requires a string field, %s is a %s", field, kind)}
+		}
+		lit := p.next()
+		if lit.kind != tokString {
+			return nil, p.unexpected(lit, "quoted pattern")
+		}
+		expr = compareExpr{field: field, op: opLike, values: []Value{StringValue(lit.text)}}
+	case negate:
+		return nil, p.unexpected(opTok, "in or like")
+	case opTok.kind == tokOp:
+		p.next()
+		value, err := p.parseLiteral(kind)
+		if err != nil {
+			return nil, err
+		}
+		expr = compareExpr{field: field, op: opTok.text, values: []Value{value}}
+	default:
+		return nil, p.unexpected(opTok, "comparison operator")
+	}
+
+	if negate {
+		return notExpr{expr}, nil
+	}
+	return expr, nil
+}
+
+// parseList "in" listesini çözer
+func (p *parser) parseList(kind Kind) ([]Value, error) {
+	if tok := p.next(); tok.kind != tokLParen {
+		return nil, p.unexpected(tok, `"("`)
+	}
+	var values []Value
+	for {
+		value, err := p.parseLiteral(kind)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		tok := p.next()
+		if tok.kind == tokRParen {
+			return values, nil
+		}
+		if tok.kind != tokComma {
+			return nil, p.unexpected(tok, `"," or ")"`)
+		}
+	}
+}
+
+// parseLiteral alanın türüne uygun bir sabit değer çözer. Tarih alanları için tarih
+// sabiti ya da tırnak içinde tarih metni kabul edilir.
+func (p *parser) parseLiteral(kind Kind) (Value, error) {
+	tok := p.next()
+	mismatch := func(got string) error {
+		return &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("expected a %s value, got %s %q", kind, got, tok.text)}
+	}
+
+	switch tok.kind {
+	case tokNumber:
+		if kind != Number {
+			return Value{}, mismatch("number")
+		}
+		n, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return Value{}, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("invalid number %q", tok.text)}
+		}
+		return NumberValue(n), nil
+	case tokString:
+		switch kind {
+		case String:
+			return StringValue(tok.text), nil
+		case Time:
+			return parseTime(tok)
+		}
+		return Value{}, mismatch("string")
+	case tokDate:
+		if kind != Time {
+			return Value{}, mismatch("date")
+		}
+		return parseTime(tok)
+	}
+	return Value{}, p.unexpected(tok, kind.String()+" value")
+}
+
+// parseTime tarih sabitini TimeLayouts formatlarıyla çözer
+func parseTime(tok token) (Value, error) {
+	for _, layout := range TimeLayouts {
+		if t, err := time.Parse(layout, strings.TrimSpace(tok.text)); err == nil {
+			return TimeValue(t), nil
+		}
+	}
+	return Value{}, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("invalid date %q: expected 2006-01-02, 2006-01-02 15:04:05 or RFC3339", tok.text)}
+}
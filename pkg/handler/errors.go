@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"errors"
+)
+
+// PermanentError yeniden denendiğinde de başarısız olacak hataları işaretler
+// (ör. doğrulama veya çözme hataları). Bu hatalara yol açan mesajlar kuyruğa geri konmaz.
+type PermanentError struct {
+	Err error
+}
+
+// Error altta yatan hatanın metnini döndürür
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap altta yatan hatayı döndürür
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// Permanent hatayı kalıcı olarak işaretler; nil için nil döner
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+// IsPermanent hata zincirinde kalıcı bir hata olup olmadığını döndürür
+func IsPermanent(err error) bool {
+	var perm *PermanentError
+	return errors.As(err, &perm)
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/burakmike/report-export-service/pkg/analytics"
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/burakmike/report-export-service/pkg/report"
+)
+
+// RealizedGainsReportHandler realized_gains.report olaylarını işler: her portföy için
+// alım/satımları lotlara ayırarak gerçekleşen kazanç ve zararları hesaplar ve PDF olarak
+// raporlar. Eldeki lotlardan fazla satış (eksik alım geçmişi) kalıcı hatadır.
+type RealizedGainsReportHandler = ReportHandler[event.RealizedGainsReportPayload, []analytics.RealizedGains]
+
+// NewRealizedGainsReportHandler yeni bir realized gains report handler oluşturur
+func NewRealizedGainsReportHandler(db *sql.DB, pdfGenerator *report.PDFGenerator) *RealizedGainsReportHandler {
+	return NewReportHandler(db, pdfGenerator, ReportSpec[event.RealizedGainsReportPayload, []analytics.RealizedGains]{
+		EventType: event.RealizedGainsReport,
+		Name:      "realized gains",
+		Build:     analytics.BuildAllRealizedGains,
+		Render:    (*report.PDFGenerator).GenerateRealizedGainsReport,
+		Describe: func(all []analytics.RealizedGains) {
+			for _, g := range all {
+				log.Printf("Realized gains: PortID=%d, Method=%s, Lots=%d, Years=%d",
+					g.PortID, g.Method, len(g.Lots), len(g.Years))
+			}
+		},
+	})
+}
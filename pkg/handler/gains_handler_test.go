@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+func TestRealizedGainsReportHandler_OverSoldIsPermanent(t *testing.T) {
+	registry := NewHandlerRegistry()
+	NewRealizedGainsReportHandler(nil, nil).Register(registry)
+
+	evt, _ := event.NewRealizedGainsReportEvent(event.LotFIFO, []event.PortfolioTransactions{{
+		PortID: 1, Name: "A", UserID: "u1",
+		Transactions: []event.Transaction{
+			{Date: event.MustParseTimestamp("2024-01-01"), Type: event.TransactionSell, Instrument: "AAPL", Quantity: event.MustParseDecimal("1"), Price: event.MustParseDecimal("10")},
+		},
+	}})
+
+	if err := registry.HandleEvent(context.Background(), evt); !IsPermanent(err) {
+		t.Errorf("Expected permanent error for a sale without lots, got %v", err)
+	}
+}
@@ -2,8 +2,14 @@ package handler
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
 
+	"github.com/burakmike/report-export-service/pkg/chunk"
 	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/burakmike/report-export-service/pkg/job"
 )
 
 // EventHandler bir olayı işleyen arayüz
@@ -18,12 +24,19 @@ type EventHandler interface {
 // HandlerRegistry farklı event tipleri için farklı işleyicileri yönetir
 type HandlerRegistry struct {
 	handlers map[event.EventType]EventHandler
+
+	// Jobs her olayın işlenme durumunu kaydeder (opsiyonel)
+	Jobs job.Recorder
+
+	// Chunks parçalı mesajları birleştirir; varsayılan olarak parçalar bellekte bekletilir
+	Chunks *chunk.Assembler
 }
 
 // NewHandlerRegistry yeni bir işleyici kaydı oluşturur
 func NewHandlerRegistry() *HandlerRegistry {
 	return &HandlerRegistry{
 		handlers: make(map[event.EventType]EventHandler),
+		Chunks:   chunk.NewAssembler(chunk.NewMemoryStore(), chunk.DefaultTimeout),
 	}
 }
 
@@ -47,6 +60,105 @@ func (r *HandlerRegistry) HandleEvent(ctx context.Context, evt event.BaseEvent)
 	if handler == nil {
 		return nil // İlgili olay tipi için işleyici bulunamadı
 	}
-	
-	return handler.Handle(ctx, evt)
-} 
\ No newline at end of file
+
+	// Parçalı mesajlar tüm parçalar gelene kadar bekletilir
+	if evt.Chunk != nil {
+		return r.handleChunk(ctx, evt)
+	}
+
+	// Handler'ların bildirdiği veri kalitesi sorunları iş kaydına yazılır
+	ctx = job.WithIssues(ctx)
+	r.recordJob(ctx, evt, job.StatusProcessing, nil)
+
+	// Eski sürümdeki payload'ları güncel yapıya yükselt; bilinmeyen sürümler kalıcı hatadır
+	current, err := event.Upcast(evt)
+	if err != nil {
+		err = Permanent(err)
+		r.recordJob(ctx, evt, job.StatusRejected, err)
+		return err
+	}
+
+	err = handler.Handle(ctx, current)
+	switch {
+	case err == nil:
+		r.recordJob(ctx, current, job.StatusCompleted, nil)
+	case IsPermanent(err):
+		r.recordJob(ctx, current, job.StatusRejected, err)
+	default:
+		r.recordJob(ctx, current, job.StatusFailed, err)
+	}
+
+	return err
+}
+
+// handleChunk parçayı birleştiriciye ekler; son parça geldiğinde birleştirilen event'i
+// işler. Parçalar event başarıyla ya da kalıcı bir hatayla işlendikten sonra silinir;
+// geçici hatalarda saklanır, böylece yeniden teslim edilen son parça işlemi tekrarlar.
+func (r *HandlerRegistry) handleChunk(ctx context.Context, evt event.BaseEvent) error {
+	if r.Chunks == nil {
+		return Permanent(fmt.Errorf("chunked %s message received but chunk reassembly is disabled", evt.EventType))
+	}
+
+	complete, ok, err := r.Chunks.Add(ctx, evt)
+	if err != nil {
+		if errors.Is(err, chunk.ErrInvalidChunk) {
+			return Permanent(err)
+		}
+		return fmt.Errorf("failed to buffer chunk: %w", err)
+	}
+	if !ok {
+		log.Printf("Buffered chunk %d/%d of request %s (%s)", evt.Chunk.Seq, evt.Chunk.Total, evt.Chunk.RequestID, evt.LogContext())
+		return nil
+	}
+
+	log.Printf("Reassembled %d chunks of request %s (%d bytes)", evt.Chunk.Total, complete.EventID, len(complete.Payload))
+	err = r.HandleEvent(withoutRawMessage(ctx), complete)
+	if err == nil || IsPermanent(err) {
+		if relErr := r.Chunks.Release(ctx, complete.EventID); relErr != nil {
+			log.Printf("Warning: failed to release chunks of request %s: %v", complete.EventID, relErr)
+		}
+	}
+	return err
+}
+
+// recordJob iş kaydedici yapılandırılmışsa olayın durumunu kaydeder.
+// Kayıt hataları olayın işlenmesini etkilemez, yalnızca loglanır.
+func (r *HandlerRegistry) recordJob(ctx context.Context, evt event.BaseEvent, status job.Status, err error) {
+	if r.Jobs == nil {
+		return
+	}
+	if recErr := r.Jobs.Record(ctx, evt, status, err); recErr != nil {
+		log.Printf("Warning: %v (%s)", recErr, evt.LogContext())
+	}
+} 
+
+// saveReportRecord bir kullanıcı için oluşturulan raporun kaydını veritabanına yazar.
+// Kayıt hataları raporun üretilmesini etkilemez, yalnızca loglanır.
+func saveReportRecord(ctx context.Context, db *sql.DB, evt event.BaseEvent, userID string) {
+	if db == nil {
+		return
+	}
+	_, err := db.ExecContext(ctx,
+		"INSERT INTO reports(user_id, type, event_id, correlation_id, requested_by) VALUES($1, $2, $3, $4, $5)",
+		userID, string(evt.EventType), evt.EventID, evt.CorrelationID, evt.RequestedBy,
+	)
+	if err != nil {
+		log.Printf("Error saving report record to database (%s): %v", evt.LogContext(), err)
+	}
+}
+
+// saveGroupReportRecord bir grup için oluşturulan raporun kaydını group_id ile yazar.
+// Rapor tek bir kullanıcıya ait olmadığından user_id boş (NULL) bırakılır; böylece
+// üyelerin rapor geçmişinde diğer üyelerin verisini içeren raporlar görünmez.
+func saveGroupReportRecord(ctx context.Context, db *sql.DB, evt event.BaseEvent, groupID string) {
+	if db == nil {
+		return
+	}
+	_, err := db.ExecContext(ctx,
+		"INSERT INTO reports(group_id, type, event_id, correlation_id, requested_by) VALUES($1, $2, $3, $4, $5)",
+		groupID, string(evt.EventType), evt.EventID, evt.CorrelationID, evt.RequestedBy,
+	)
+	if err != nil {
+		log.Printf("Error saving group report record to database (%s): %v", evt.LogContext(), err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/burakmike/report-export-service/pkg/job"
 )
 
 // stubHandler is a test double for EventHandler
@@ -49,7 +50,7 @@ func TestHandleEvent_Route(t *testing.T) {
 	registry.RegisterHandler(h1)
 	registry.RegisterHandler(h2)
 
-	evt := event.BaseEvent{EventType: event.PortfolioReport, Timestamp: "x", Payload: nil}
+	evt := event.BaseEvent{EventType: event.PortfolioReport, Payload: nil}
 	err := registry.HandleEvent(context.Background(), evt)
 	if err != nil {
 		t.Fatalf("HandleEvent returned unexpected error: %v", err)
@@ -68,9 +69,116 @@ func TestHandleEvent_Error(t *testing.T) {
 	h := &stubHandler{Et: event.PortfolioReport, RetErr: sentinel}
 	registry.RegisterHandler(h)
 
-	evt := event.BaseEvent{EventType: event.PortfolioReport, Timestamp: "x", Payload: nil}
+	evt := event.BaseEvent{EventType: event.PortfolioReport, Payload: nil}
 	err := registry.HandleEvent(context.Background(), evt)
 	if err != sentinel {
 		t.Errorf("HandleEvent error = %v, want %v", err, sentinel)
 	}
-} 
\ No newline at end of file
+} 
+
+// stubRecorder records job status transitions in memory
+type stubRecorder struct {
+	Statuses []job.Status
+	LastErr  error
+}
+
+func (s *stubRecorder) Record(ctx context.Context, evt event.BaseEvent, status job.Status, err error) error {
+	s.Statuses = append(s.Statuses, status)
+	s.LastErr = err
+	return nil
+}
+
+func TestHandleEvent_RecordsJobStatus(t *testing.T) {
+	cases := []struct {
+		name   string
+		retErr error
+		want   job.Status
+	}{
+		{"success", nil, job.StatusCompleted},
+		{"transient", errors.New("db down"), job.StatusFailed},
+		{"permanent", Permanent(errors.New("bad payload")), job.StatusRejected},
+	}
+	for _, c := range cases {
+		registry := NewHandlerRegistry()
+		recorder := &stubRecorder{}
+		registry.Jobs = recorder
+		registry.RegisterHandler(&stubHandler{Et: event.PortfolioReport, RetErr: c.retErr})
+
+		registry.HandleEvent(context.Background(), event.BaseEvent{EventType: event.PortfolioReport})
+
+		if len(recorder.Statuses) != 2 || recorder.Statuses[0] != job.StatusProcessing || recorder.Statuses[1] != c.want {
+			t.Errorf("%s: statuses = %v; want [processing %s]", c.name, recorder.Statuses, c.want)
+		}
+		if recorder.LastErr != c.retErr {
+			t.Errorf("%s: recorded error = %v; want %v", c.name, recorder.LastErr, c.retErr)
+		}
+	}
+}
+
+func TestHandleEvent_RejectsFutureSchemaVersion(t *testing.T) {
+	registry := NewHandlerRegistry()
+	recorder := &stubRecorder{}
+	registry.Jobs = recorder
+	stub := &stubHandler{Et: event.PortfolioReport}
+	registry.RegisterHandler(stub)
+
+	err := registry.HandleEvent(context.Background(), event.BaseEvent{EventType: event.PortfolioReport, SchemaVersion: 99})
+	if !IsPermanent(err) || !errors.Is(err, event.ErrUnsupportedSchemaVersion) {
+		t.Errorf("Expected permanent unsupported version error, got %v", err)
+	}
+	if stub.Handled {
+		t.Error("Handler should not see events with an unknown schema version")
+	}
+	if got := recorder.Statuses[len(recorder.Statuses)-1]; got != job.StatusRejected {
+		t.Errorf("Last job status = %s; want rejected", got)
+	}
+}
+
+func TestHandleEvent_Chunks(t *testing.T) {
+	registry := NewHandlerRegistry()
+	h := &stubHandler{Et: event.PortfolioReport, RetErr: errors.New("temporary")}
+	registry.RegisterHandler(h)
+
+	evt, _ := event.NewPortfolioReportEvent(event.CreateSamplePortfolios())
+	parts, err := event.SplitEvent(evt, 200)
+	if err != nil {
+		t.Fatalf("SplitEvent error: %v", err)
+	}
+
+	ctx := context.Background()
+	for _, part := range parts[:len(parts)-1] {
+		if err := registry.HandleEvent(ctx, part); err != nil {
+			t.Fatalf("HandleEvent on a buffered chunk returned %v", err)
+		}
+	}
+	if h.Handled {
+		t.Fatal("Handler must not run before all chunks arrive")
+	}
+
+	// Geçici hata: parçalar saklanır, son parçanın yeniden teslimi işlemi tekrarlar
+	last := parts[len(parts)-1]
+	if err := registry.HandleEvent(ctx, last); err == nil || IsPermanent(err) {
+		t.Fatalf("Expected the temporary handler error, got %v", err)
+	}
+	h.RetErr = nil
+	h.Handled = false
+	if err := registry.HandleEvent(ctx, last); err != nil {
+		t.Fatalf("HandleEvent on the redelivered last chunk returned %v", err)
+	}
+	if !h.Handled || h.Recv.EventID != evt.EventID || h.Recv.Chunk != nil || string(h.Recv.Payload) != string(evt.Payload) {
+		t.Errorf("Handler received %+v; want the reassembled event", h.Recv)
+	}
+	if n, _ := registry.Chunks.Store.Count(ctx, evt.EventID); n != 0 {
+		t.Errorf("Expected chunks to be released after handling, %d left", n)
+	}
+}
+
+func TestHandleEvent_InvalidChunkIsPermanent(t *testing.T) {
+	registry := NewHandlerRegistry()
+	registry.RegisterHandler(&stubHandler{Et: event.PortfolioReport})
+
+	evt := event.BaseEvent{EventType: event.PortfolioReport, Chunk: &event.ChunkInfo{RequestID: "r1", Seq: 3, Total: 2}}
+	if err := registry.HandleEvent(context.Background(), evt); !IsPermanent(err) {
+		t.Errorf("Expected permanent error for an invalid chunk, got %v", err)
+	}
+}
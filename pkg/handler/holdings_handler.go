@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/burakmike/report-export-service/pkg/analytics"
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/burakmike/report-export-service/pkg/report"
+)
+
+// HoldingsReportHandler holdings.report olaylarını işler: her portföy için
+// piyasa değerli pozisyon dökümü ve varlık dağılımı oluşturur ve PDF olarak raporlar.
+// Eksik döviz kurları kalıcı hatadır.
+type HoldingsReportHandler = ReportHandler[event.HoldingsReportPayload, []analytics.HoldingsStatement]
+
+// NewHoldingsReportHandler yeni bir holdings report handler oluşturur
+func NewHoldingsReportHandler(db *sql.DB, pdfGenerator *report.PDFGenerator) *HoldingsReportHandler {
+	return NewReportHandler(db, pdfGenerator, ReportSpec[event.HoldingsReportPayload, []analytics.HoldingsStatement]{
+		EventType: event.HoldingsReport,
+		Name:      "holdings",
+		Build:     analytics.BuildHoldingsStatements,
+		Render:    (*report.PDFGenerator).GenerateHoldingsReport,
+		Describe: func(statements []analytics.HoldingsStatement) {
+			for _, s := range statements {
+				log.Printf("Holdings: PortID=%d, Positions=%d, MarketValue=%s",
+					s.PortID, len(s.Positions), s.TotalValue)
+			}
+		},
+	})
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/burakmike/report-export-service/pkg/analytics"
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/burakmike/report-export-service/pkg/report"
+)
+
+// IncomeReportHandler income.report olaylarını işler: her portföy için
+// dönem içindeki temettü, kupon ve faiz gelirlerini özetler ve PDF olarak raporlar
+type IncomeReportHandler = ReportHandler[event.IncomeReportPayload, []analytics.IncomeStatement]
+
+// NewIncomeReportHandler yeni bir income report handler oluşturur
+func NewIncomeReportHandler(db *sql.DB, pdfGenerator *report.PDFGenerator) *IncomeReportHandler {
+	return NewReportHandler(db, pdfGenerator, ReportSpec[event.IncomeReportPayload, []analytics.IncomeStatement]{
+		EventType: event.IncomeReport,
+		Name:      "income",
+		Build: func(p event.IncomeReportPayload) ([]analytics.IncomeStatement, error) {
+			period, err := p.ReportPeriod.Resolve(time.Now())
+			if err != nil {
+				return nil, err
+			}
+			return analytics.BuildIncomeStatements(p, period), nil
+		},
+		Render: (*report.PDFGenerator).GenerateIncomeReport,
+		Describe: func(statements []analytics.IncomeStatement) {
+			for _, s := range statements {
+				log.Printf("Income: PortID=%d, Payments=%d, Gross=%s, Net=%s",
+					s.PortID, s.Total.Payments, s.Total.Gross, s.Total.Net)
+			}
+		},
+	})
+}
@@ -25,10 +25,18 @@ func TestIntegration_Handler_DB_and_PDF(t *testing.T) {
 	portfolios := event.CreateSamplePortfolios()
 
 	// Expect one Exec per portfolio
-	sql := "INSERT INTO reports(user_id, type) VALUES($1, $2)"
+	// Create an event for portfolios
+	evt, err := event.NewPortfolioReportEvent(portfolios)
+	if err != nil {
+		t.Fatalf("NewPortfolioReportEvent error: %v", err)
+	}
+	evt.RequestedBy = "advisor42"
+
+	// Expect one Exec per portfolio, carrying the envelope metadata
+	sql := "INSERT INTO reports(user_id, type, event_id, correlation_id, requested_by) VALUES($1, $2, $3, $4, $5)"
 	for _, p := range portfolios {
 		mock.ExpectExec(regexp.QuoteMeta(sql)).
-			WithArgs(p.UserID, string(event.PortfolioReport)).
+			WithArgs(p.UserID, string(event.PortfolioReport), evt.EventID, evt.CorrelationID, "advisor42").
 			WillReturnResult(sqlmock.NewResult(1, 1))
 	}
 
@@ -42,12 +50,6 @@ func TestIntegration_Handler_DB_and_PDF(t *testing.T) {
 	// Initialize the handler
 	h := handler.NewPortfolioReportHandler(db, pdfGen)
 
-	// Create an event for portfolios
-	evt, err := event.NewPortfolioReportEvent(portfolios)
-	if err != nil {
-		t.Fatalf("NewPortfolioReportEvent error: %v", err)
-	}
-
 	// Handle the event
 	if err := h.Handle(context.Background(), evt); err != nil {
 		t.Fatalf("Handle error: %v", err)
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/burakmike/report-export-service/pkg/analytics"
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/burakmike/report-export-service/pkg/report"
+)
+
+// PerformanceReportHandler performance.report olaylarını işler: her portföy için
+// standart dönemlerin zaman ve para ağırlıklı getirilerini hesaplar ve PDF olarak raporlar
+type PerformanceReportHandler = ReportHandler[event.PerformanceReportPayload, []analytics.Performance]
+
+// NewPerformanceReportHandler yeni bir performance report handler oluşturur
+func NewPerformanceReportHandler(db *sql.DB, pdfGenerator *report.PDFGenerator) *PerformanceReportHandler {
+	return NewReportHandler(db, pdfGenerator, ReportSpec[event.PerformanceReportPayload, []analytics.Performance]{
+		EventType: event.PerformanceReport,
+		Name:      "performance",
+		Build: func(p event.PerformanceReportPayload) ([]analytics.Performance, error) {
+			return analytics.BuildPerformances(p), nil
+		},
+		Render: (*report.PDFGenerator).GeneratePerformanceReport,
+		Describe: func(performances []analytics.Performance) {
+			for _, p := range performances {
+				log.Printf("Performance: PortID=%d, Valuations=%d, AsOf=%s",
+					p.PortID, len(p.Series), p.AsOf.Format("2006-01-02"))
+			}
+		},
+	})
+}
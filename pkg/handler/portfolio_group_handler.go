@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/burakmike/report-export-service/pkg/analytics"
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/burakmike/report-export-service/pkg/portfolio"
+	"github.com/burakmike/report-export-service/pkg/report"
+)
+
+// PortfolioGroupReportHandler portfolio.group.report olaylarını işler: gruptaki
+// kullanıcıların portföylerini depodan yükler ve kullanıcı bazında bölümlerle
+// grup toplamlarını içeren birleşik bir PDF raporu oluşturur
+type PortfolioGroupReportHandler struct {
+	DB           *sql.DB
+	PDFGenerator *report.PDFGenerator
+	Portfolios   portfolio.Repository
+}
+
+// NewPortfolioGroupReportHandler yeni bir portfolio group report handler oluşturur
+func NewPortfolioGroupReportHandler(db *sql.DB, pdfGenerator *report.PDFGenerator, portfolios portfolio.Repository) *PortfolioGroupReportHandler {
+	return &PortfolioGroupReportHandler{
+		DB:           db,
+		PDFGenerator: pdfGenerator,
+		Portfolios:   portfolios,
+	}
+}
+
+// Register handler'ı kayıt sistemine typed handler olarak ekler
+func (h *PortfolioGroupReportHandler) Register(registry *HandlerRegistry) {
+	RegisterTyped(registry, event.PortfolioGroupReport, h.Handle)
+}
+
+// Handle çözülmüş ve doğrulanmış portfolio.group.report payload'ını işler. Rapor
+// kaydı üyeler adına değil grup adına tutulur; böylece üyelerin kendi rapor
+// geçmişinde diğer üyelerin verisini içeren raporlar yer almaz.
+func (h *PortfolioGroupReportHandler) Handle(ctx context.Context, evt event.BaseEvent, payload event.PortfolioGroupReportPayload) error {
+	log.Printf("Processing %s group report for %s with %d users (%s)", payload.Kind, payload.GroupID, len(payload.UserIDs), evt.LogContext())
+
+	if h.Portfolios == nil {
+		// Mesaj kuyruğa geri konursa sonsuz bir döngüde yeniden teslim edilir
+		return Permanent(fmt.Errorf("%w for %s", ErrStoreNotConfigured, evt.EventType))
+	}
+
+	portfolios, err := h.Portfolios.FindByUsers(ctx, payload.UserIDs)
+	if err != nil {
+		// Veritabanı hataları geçicidir, mesaj yeniden denenir
+		return fmt.Errorf("failed to load portfolios for group %s: %w", payload.GroupID, err)
+	}
+	if len(portfolios) == 0 {
+		return Permanent(fmt.Errorf("invalid %s payload: %w: no user in group %s has portfolios", evt.EventType, ErrPortfolioNotFound, payload.GroupID))
+	}
+
+	portfolioFilter, err := event.ParsePortfolioFilter(payload.Filter)
+	if err != nil {
+		return Permanent(fmt.Errorf("invalid %s payload: %w", evt.EventType, err))
+	}
+	period, err := payload.ReportPeriod.Resolve(time.Now())
+	if err != nil {
+		return Permanent(fmt.Errorf("invalid %s payload: %w", evt.EventType, err))
+	}
+
+	group := analytics.BuildGroupReport(payload.UserIDs, portfolioFilter.Apply(portfolios), period)
+	for _, m := range group.Members {
+		log.Printf("Group member: UserID=%s, Portfolios=%d", m.UserID, m.Summary.Total)
+	}
+	if empty := group.EmptyMembers(); len(empty) > 0 {
+		log.Printf("Group %s members without portfolios in report: %v (%s)", payload.GroupID, empty, evt.LogContext())
+	}
+
+	if h.PDFGenerator != nil {
+		filePath, err := h.PDFGenerator.GenerateGroupReport(payload.Kind, payload.DisplayName(), group, period)
+		if err != nil {
+			log.Printf("Error generating group report (%s): %v", evt.LogContext(), err)
+		} else {
+			log.Printf("Group report successfully generated at: %s (%s)", filePath, evt.LogContext())
+		}
+	} else {
+		log.Println("PDF generator not available, skipping report generation")
+	}
+
+	saveGroupReportRecord(ctx, h.DB, evt, payload.GroupID)
+	return nil
+}
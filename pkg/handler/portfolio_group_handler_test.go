@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/burakmike/report-export-service/pkg/report"
+)
+
+func TestPortfolioGroupReportHandler_RecordsUnderGroup(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	defer db.Close()
+
+	dir := t.TempDir()
+	pdfGen, err := report.NewPDFGenerator(dir)
+	if err != nil {
+		t.Fatalf("NewPDFGenerator error: %v", err)
+	}
+
+	evt, _ := event.NewPortfolioGroupReportEvent(event.GroupHousehold, "household-7", "user123", "user456")
+	// Grup raporu üyeler adına değil, bir kez group_id ile kaydedilir; user_id boş kalır
+	mock.ExpectExec(`INSERT INTO reports\(group_id, type,`).
+		WithArgs("household-7", "portfolio.group.report", evt.EventID, evt.CorrelationID, evt.RequestedBy).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	registry := NewHandlerRegistry()
+	NewPortfolioGroupReportHandler(db, pdfGen, newTestPortfolioRepository()).Register(registry)
+	if err := registry.HandleEvent(context.Background(), evt); err != nil {
+		t.Fatalf("HandleEvent error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("DB expectations not met: %v", err)
+	}
+	if files, _ := os.ReadDir(dir); len(files) != 1 {
+		t.Errorf("Expected one group report in %s, found %d files", dir, len(files))
+	}
+}
+
+func TestPortfolioGroupReportHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		repo      *fakePortfolioRepository
+		userIDs   []string
+		permanent bool
+		want      error
+	}{
+		{"no member has portfolios", newTestPortfolioRepository(), []string{"nobody", "ghost"}, true, ErrPortfolioNotFound},
+		{"store failure", &fakePortfolioRepository{err: errors.New("db down")}, []string{"user123"}, false, nil},
+		{"store not configured", nil, []string{"user123"}, true, ErrStoreNotConfigured},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewPortfolioGroupReportHandler(nil, nil, nil)
+			if tt.repo != nil {
+				h.Portfolios = tt.repo
+			}
+			registry := NewHandlerRegistry()
+			h.Register(registry)
+
+			evt, _ := event.NewPortfolioGroupReportEvent(event.GroupAdvisor, "book-1", tt.userIDs...)
+			err := registry.HandleEvent(context.Background(), evt)
+			if err == nil {
+				t.Fatal("Expected error")
+			}
+			if IsPermanent(err) != tt.permanent {
+				t.Errorf("IsPermanent(%v) = %v; want %v", err, IsPermanent(err), tt.permanent)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -1,13 +1,17 @@
 package handler
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"log"
 	"time"
 
+	"github.com/burakmike/report-export-service/pkg/analytics"
 	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/burakmike/report-export-service/pkg/job"
 	"github.com/burakmike/report-export-service/pkg/report"
 )
 
@@ -15,6 +19,11 @@ import (
 type PortfolioReportHandler struct {
 	DB           *sql.DB
 	PDFGenerator *report.PDFGenerator
+
+	// StreamThreshold bu boyutu (byte) aşan payload'lar akış yoluyla işlenir; 0 ise kapalı
+	StreamThreshold int
+	// VolumeRows akış yolunda bir PDF cildine yazılacak en fazla satır sayısı
+	VolumeRows int
 }
 
 // NewPortfolioReportHandler yeni bir portfolio report handler oluşturur
@@ -32,17 +41,46 @@ func (h *PortfolioReportHandler) EventType() event.EventType {
 
 // Handle portfolio.report olayını işler
 func (h *PortfolioReportHandler) Handle(ctx context.Context, evt event.BaseEvent) error {
-	// Payload'ı doğru tipe dönüştür
-	var payload event.PortfolioReportPayload
-	if err := evt.ParsePayload(&payload); err != nil {
-		return fmt.Errorf("failed to parse portfolio report payload: %w", err)
+	// Çok büyük payload'lar tüm listeyi belleğe almadan işlenir
+	if h.StreamThreshold > 0 && len(evt.Payload) > h.StreamThreshold {
+		return h.handleStream(ctx, evt)
+	}
+
+	// Payload'ı çöz ve alan bazında doğrula; geçersiz payload'lar yeniden denenmez
+	payload, err := DecodePayload[event.PortfolioReportPayload](evt)
+	if err != nil {
+		return err
 	}
 
 	// Log işlemi
-	log.Printf("Processing portfolio report event with %d portfolios", len(payload.Portfolios))
+	log.Printf("Processing portfolio report event with %d portfolios (%s)", len(payload.Portfolios), evt.LogContext())
+
+	// Veri kalitesi sorunları işi durdurmaz; rapora ek olarak basılır ve iş durumuna yazılır
+	issues := payload.CheckQuality(time.Now())
+	reportQualityIssues(ctx, evt, issues)
+
+	// Filtre verilmişse yalnızca ifadeye uyan portföyler raporlanır
+	portfolioFilter, err := event.ParsePortfolioFilter(payload.Filter)
+	if err != nil {
+		return Permanent(fmt.Errorf("invalid %s payload: %w", evt.EventType, err))
+	}
+	matched := portfolioFilter.Apply(payload.Portfolios)
+	if portfolioFilter != nil {
+		log.Printf("Report filter %q matched %d of %d portfolios (%s)", payload.Filter, len(matched), len(payload.Portfolios), evt.LogContext())
+	}
+
+	// Dönem verilmişse as-of tarihinde henüz var olmayan portföyleri çıkar
+	period, err := payload.ReportPeriod.Resolve(time.Now())
+	if err != nil {
+		return Permanent(fmt.Errorf("invalid %s payload: %w", evt.EventType, err))
+	}
+	portfolios, summary := analytics.FilterPortfolios(matched, period)
+	if !period.IsZero() {
+		log.Printf("Report period: %s; %d portfolios excluded (%s)", period.Label(), summary.Excluded, evt.LogContext())
+	}
 	
 	// Her bir portföy için ayrıntı loglar
-	for _, portfolio := range payload.Portfolios {
+	for _, portfolio := range portfolios {
 		log.Printf("Portfolio details: ID=%d, Name=%s, UserID=%s, CreatedAt=%s, LastUpdate=%s",
 			portfolio.PortID, portfolio.Name, portfolio.UserID, portfolio.CreatedAt, portfolio.LastUpdate)
 	}
@@ -54,32 +92,129 @@ func (h *PortfolioReportHandler) Handle(ctx context.Context, evt event.BaseEvent
 		// İşlemin biraz zaman aldığını simüle etmek için
 		time.Sleep(200 * time.Millisecond)
 		
-		// PDF oluştur
-		filePath, err := h.PDFGenerator.GeneratePortfolioReport(payload.Portfolios)
+		// PDF oluştur (kaynak veri gömülecekse raporu tetikleyen mesaj da iletilir)
+		var source *report.SourceEvent
+		if h.PDFGenerator.EmbedSourceData {
+			if source, err = sourceEvent(ctx, evt); err != nil {
+				log.Printf("Warning: %v; embedding portfolios only (%s)", err, evt.LogContext())
+			}
+		}
+		filePath, err := h.PDFGenerator.GeneratePortfolioPeriodReport(portfolios, period, issues, source)
 		if err != nil {
-			log.Printf("Error generating PDF report: %v", err)
+			log.Printf("Error generating PDF report (%s): %v", evt.LogContext(), err)
 		} else {
-			log.Printf("PDF report successfully generated at: %s", filePath)
+			log.Printf("PDF report successfully generated at: %s (%s)", filePath, evt.LogContext())
 		}
 	} else {
 		log.Println("PDF generator not available, skipping report generation")
 	}
 	
 	// Rapor oluşturma işleminin tamamlandığını belirt
-	log.Printf("Portfolio report processing completed for %d portfolios", len(payload.Portfolios))
+	log.Printf("Portfolio report processing completed for %d portfolios (%s)", len(portfolios), evt.LogContext())
 	
 	// Save report records in database
-	if h.DB != nil {
-		for _, portfolio := range payload.Portfolios {
-			_, err := h.DB.ExecContext(ctx,
-				"INSERT INTO reports(user_id, type) VALUES($1, $2)",
-				portfolio.UserID, string(evt.EventType),
-			)
-			if err != nil {
-				log.Printf("Error saving report record to database: %v", err)
+	for _, portfolio := range portfolios {
+		saveReportRecord(ctx, h.DB, evt, portfolio.UserID)
+	}
+	
+	return nil
+}
+
+// handleStream büyük payload'ları portföyleri tek tek çözerek işler.
+// CSV/NDJSON çıktıları diske akar, PDF ise VolumeRows satırlık ciltlere bölünür.
+func (h *PortfolioReportHandler) handleStream(ctx context.Context, evt event.BaseEvent) error {
+	log.Printf("Processing large portfolio report event (%d bytes) in streaming mode (%s)", len(evt.Payload), evt.LogContext())
+
+	// Dönem ve filtre parametreleri küçük üst düzey alanlardır; portföy listesi ayrıca akışla okunur
+	var params struct {
+		event.ReportPeriod
+		Filter string `json:"filter"`
+	}
+	if err := json.Unmarshal(evt.Payload, &params); err != nil {
+		return Permanent(fmt.Errorf("failed to parse %s payload: %w", evt.EventType, err))
+	}
+
+	// İlk geçiş: hiçbir yan etki oluşmadan önce payload'ın tamamını çöz ve doğrula
+	validator := event.NewPortfolioValidator()
+	validator.CheckPeriod(params.ReportPeriod)
+	validator.CheckFilter(params.Filter)
+	quality := event.NewPortfolioQualityChecker(time.Now())
+	index := 0
+	count, err := event.StreamPortfolios(bytes.NewReader(evt.Payload), func(portfolio event.Portfolio) error {
+		validator.Check(index, portfolio)
+		quality.Check(index, portfolio)
+		index++
+		return nil
+	})
+	if err != nil {
+		return Permanent(fmt.Errorf("failed to parse %s payload: %w", evt.EventType, err))
+	}
+	if err := validator.Err(); err != nil {
+		return Permanent(fmt.Errorf("invalid %s payload: %w", evt.EventType, err))
+	}
+	period, err := params.ReportPeriod.Resolve(time.Now())
+	if err != nil {
+		return Permanent(fmt.Errorf("invalid %s payload: %w", evt.EventType, err))
+	}
+	portfolioFilter, err := event.ParsePortfolioFilter(params.Filter)
+	if err != nil {
+		return Permanent(fmt.Errorf("invalid %s payload: %w", evt.EventType, err))
+	}
+	issues := quality.Issues()
+	reportQualityIssues(ctx, evt, issues)
+
+	var stream *report.PortfolioStream
+	if h.PDFGenerator != nil {
+		stream, err = h.PDFGenerator.NewPortfolioStream(h.VolumeRows)
+		if err != nil {
+			log.Printf("Error opening report stream: %v", err)
+		} else {
+			stream.Period = period
+			stream.QualityIssues = issues
+		}
+	} else {
+		log.Println("PDF generator not available, skipping report generation")
+	}
+
+	// İkinci geçiş: raporları yaz ve kayıtları kaydet
+	_, err = event.StreamPortfolios(bytes.NewReader(evt.Payload), func(portfolio event.Portfolio) error {
+		if !portfolioFilter.Match(portfolio) || !analytics.InPeriod(portfolio, period) {
+			return nil
+		}
+		if stream != nil {
+			if err := stream.Write(portfolio); err != nil {
+				log.Printf("Error writing report stream, skipping remaining rows: %v", err)
+				if _, closeErr := stream.Close(); closeErr != nil {
+					log.Printf("Error closing report stream (%s): %v", evt.LogContext(), closeErr)
+				}
+				stream = nil
 			}
 		}
+		saveReportRecord(ctx, h.DB, evt, portfolio.UserID)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to stream portfolio report payload: %w", err)
 	}
-	
+
+	if stream != nil {
+		paths, err := stream.Close()
+		if err != nil {
+			log.Printf("Error generating streamed report (%s): %v", evt.LogContext(), err)
+		} else {
+			log.Printf("Streamed report successfully generated: %v (%s)", paths, evt.LogContext())
+		}
+	}
+
+	log.Printf("Portfolio report processing completed for %d portfolios (%s)", count, evt.LogContext())
 	return nil
-} 
\ No newline at end of file
+}
+
+// reportQualityIssues veri kalitesi sorunlarını loglar ve iş durumuna ekler
+func reportQualityIssues(ctx context.Context, evt event.BaseEvent, issues []event.FieldError) {
+	if len(issues) == 0 {
+		return
+	}
+	log.Printf("Portfolio data has %d quality issues, first: %v (%s)", len(issues), issues[0], evt.LogContext())
+	job.AddIssues(ctx, issues...)
+}
@@ -3,29 +3,74 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
+
 	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/burakmike/report-export-service/pkg/job"
 	"github.com/burakmike/report-export-service/pkg/report"
 )
 
 func TestPortfolioReportHandler_InvalidPayload(t *testing.T) {
 	h := NewPortfolioReportHandler(nil, nil)
 	// Invalid JSON payload
-	evt := event.BaseEvent{EventType: event.PortfolioReport, Timestamp: "x", Payload: json.RawMessage("invalid")}
+	evt := event.BaseEvent{EventType: event.PortfolioReport, Payload: json.RawMessage("invalid")}
 	err := h.Handle(context.Background(), evt)
 	if err == nil {
 		t.Fatal("Expected error for invalid payload, got nil")
 	}
+	if !IsPermanent(err) {
+		t.Errorf("Decoding errors should be permanent, got %v", err)
+	}
+}
+
+func TestPortfolioReportHandler_InvalidDate(t *testing.T) {
+	raw := json.RawMessage(`{"portfolios":[{"portID":1,"name":"A","userID":"u","createdAt":"yesterday","lastUpdate":"2023-01-01"}]}`)
+	evt := event.BaseEvent{EventType: event.PortfolioReport, Payload: raw}
+
+	h := NewPortfolioReportHandler(nil, nil)
+	err := h.Handle(context.Background(), evt)
+	var verrs event.ValidationErrors
+	if !IsPermanent(err) || !errors.As(err, &verrs) || len(verrs) != 1 ||
+		verrs[0].Path != "portfolios[0].createdAt" || verrs[0].Rule != event.RuleDate || verrs[0].Value != "yesterday" {
+		t.Errorf("Expected permanent date error on createdAt, got %v", err)
+	}
+}
+
+func TestPortfolioReportHandler_ValidationErrors(t *testing.T) {
+	payload := event.PortfolioReportPayload{Portfolios: []event.Portfolio{
+		{PortID: 1, Name: "A", UserID: "u1", CreatedAt: event.MustParseTimestamp("2023-01-01"), LastUpdate: event.MustParseTimestamp("2023-01-01")},
+		{PortID: 1, Name: "", UserID: "u2", LastUpdate: event.MustParseTimestamp("2023-01-01")},
+	}}
+	raw, _ := json.Marshal(payload)
+	evt := event.BaseEvent{EventType: event.PortfolioReport, Payload: raw}
+
+	// Both the in-memory and the streaming path report every field error at once.
+	// The duplicate portID and the blank name are only data quality issues.
+	for _, threshold := range []int{0, 1} {
+		h := NewPortfolioReportHandler(nil, nil)
+		h.StreamThreshold = threshold
+		err := h.Handle(context.Background(), evt)
+		if !IsPermanent(err) {
+			t.Fatalf("threshold %d: expected permanent error, got %v", threshold, err)
+		}
+		var verrs event.ValidationErrors
+		if !errors.As(err, &verrs) || len(verrs) != 1 || verrs[0].Path != "portfolios[1].createdAt" {
+			t.Errorf("threshold %d: expected a single createdAt error, got %v", threshold, err)
+		}
+	}
 }
 
 func TestPortfolioReportHandler_NoPDFNoDB(t *testing.T) {
 	// Valid payload, but no PDFGenerator and no DB
 	payload := event.PortfolioReportPayload{Portfolios: event.CreateSamplePortfolios()}
 	raw, _ := json.Marshal(payload)
-	evt := event.BaseEvent{EventType: event.PortfolioReport, Timestamp: "x", Payload: raw}
+	evt := event.BaseEvent{EventType: event.PortfolioReport, Payload: raw}
 	h := NewPortfolioReportHandler(nil, nil)
 	err := h.Handle(context.Background(), evt)
 	if err != nil {
@@ -41,10 +86,10 @@ func TestPortfolioReportHandler_WithPDF(t *testing.T) {
 		t.Fatalf("NewPDFGenerator error: %v", err)
 	}
 	payload := event.PortfolioReportPayload{Portfolios: []event.Portfolio{
-		{PortID: 1, Name: "n", UserID: "u", CreatedAt: "c", LastUpdate: "l"},
+		{PortID: 1, Name: "n", UserID: "u", CreatedAt: event.MustParseTimestamp("2023-01-01 00:00:00"), LastUpdate: event.MustParseTimestamp("2023-01-02 00:00:00")},
 	}}
 	raw, _ := json.Marshal(payload)
-	evt := event.BaseEvent{EventType: event.PortfolioReport, Timestamp: "x", Payload: raw}
+	evt := event.BaseEvent{EventType: event.PortfolioReport, Payload: raw}
 	h := NewPortfolioReportHandler(nil, pdfGen)
 	err = h.Handle(context.Background(), evt)
 	if err != nil {
@@ -65,4 +110,223 @@ func TestPortfolioReportHandler_WithPDF(t *testing.T) {
 	if !found {
 		t.Errorf("Expected a PDF file in %s, found none", dir)
 	}
-} 
\ No newline at end of file
+} 
+
+func TestPortfolioReportHandler_EmbedSourceData(t *testing.T) {
+	evt, err := event.NewPortfolioReportEvent(event.CreateSamplePortfolios())
+	if err != nil {
+		t.Fatalf("NewPortfolioReportEvent error: %v", err)
+	}
+
+	// The original event is only attached when the generator embeds source data
+	for _, embed := range []bool{false, true} {
+		dir := t.TempDir()
+		pdfGen, err := report.NewPDFGenerator(dir)
+		if err != nil {
+			t.Fatalf("NewPDFGenerator error: %v", err)
+		}
+		pdfGen.EmbedSourceData = embed
+		if err := NewPortfolioReportHandler(nil, pdfGen).Handle(context.Background(), evt); err != nil {
+			t.Fatalf("embed=%v: expected no error, got %v", embed, err)
+		}
+
+		files, _ := filepath.Glob(filepath.Join(dir, "*.pdf"))
+		if len(files) != 1 {
+			t.Fatalf("embed=%v: expected one PDF, got %v", embed, files)
+		}
+		data, err := os.ReadFile(files[0])
+		if err != nil {
+			t.Fatalf("ReadFile error: %v", err)
+		}
+		want := 0
+		if embed {
+			want = 3 // portfolios.csv, portfolios.json, event.json
+		}
+		if n := strings.Count(string(data), "/Type /Filespec"); n != want {
+			t.Errorf("embed=%v: got %d embedded files; want %d", embed, n, want)
+		}
+	}
+}
+
+func TestPortfolioReportHandler_Streaming(t *testing.T) {
+	dir := t.TempDir()
+	pdfGen, err := report.NewPDFGenerator(dir)
+	if err != nil {
+		t.Fatalf("NewPDFGenerator error: %v", err)
+	}
+	h := NewPortfolioReportHandler(nil, pdfGen)
+	h.StreamThreshold = 1
+	h.VolumeRows = 2
+
+	evt, err := event.NewPortfolioReportEvent(event.CreateSamplePortfolios())
+	if err != nil {
+		t.Fatalf("NewPortfolioReportEvent error: %v", err)
+	}
+	if err := h.Handle(context.Background(), evt); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir error: %v", err)
+	}
+	counts := map[string]int{}
+	for _, f := range files {
+		switch {
+		case strings.HasSuffix(f.Name(), ".pdf"):
+			counts["pdf"]++
+		case strings.HasSuffix(f.Name(), ".csv"):
+			counts["csv"]++
+		case strings.HasSuffix(f.Name(), ".ndjson"):
+			counts["ndjson"]++
+		}
+	}
+	// 3 sample portfolios with 2 rows per volume -> 2 PDF volumes
+	if counts["pdf"] != 2 || counts["csv"] != 1 || counts["ndjson"] != 1 {
+		t.Errorf("Unexpected streamed artifacts: %v", counts)
+	}
+
+	// Invalid payloads are rejected before anything is written
+	bad := event.BaseEvent{EventType: event.PortfolioReport, Payload: json.RawMessage(`{"portfolios":[{"portID":"x"}]}`)}
+	if err := h.Handle(context.Background(), bad); err == nil {
+		t.Error("Expected error for invalid streamed payload, got nil")
+	}
+}
+
+func TestPortfolioReportHandler_QualityIssues(t *testing.T) {
+	date := event.MustParseTimestamp("2023-01-01")
+	payload := event.PortfolioReportPayload{Portfolios: []event.Portfolio{
+		{PortID: 1, Name: "A", UserID: "u1", CreatedAt: date, LastUpdate: date},
+		{PortID: 1, Name: "", UserID: "u2", CreatedAt: date, LastUpdate: date},
+	}}
+	raw, _ := json.Marshal(payload)
+	evt := event.BaseEvent{EventType: event.PortfolioReport, Payload: raw}
+
+	// Quality issues do not fail the job on either path; they are collected for the job status
+	for _, threshold := range []int{0, 1} {
+		pdfGen, err := report.NewPDFGenerator(t.TempDir())
+		if err != nil {
+			t.Fatalf("NewPDFGenerator error: %v", err)
+		}
+		h := NewPortfolioReportHandler(nil, pdfGen)
+		h.StreamThreshold = threshold
+
+		ctx := job.WithIssues(context.Background())
+		if err := h.Handle(ctx, evt); err != nil {
+			t.Fatalf("threshold %d: expected no error, got %v", threshold, err)
+		}
+		issues := job.Issues(ctx)
+		if len(issues) != 2 || issues[0].Path != "portfolios[1].portID" || issues[1].Path != "portfolios[1].name" {
+			t.Errorf("threshold %d: unexpected quality issues %v", threshold, issues)
+		}
+	}
+}
+
+func TestPortfolioReportHandler_Period(t *testing.T) {
+	asOf := event.MustParseTimestamp("2026-06-30")
+	payload := event.PortfolioReportPayload{
+		ReportPeriod: event.ReportPeriod{Period: "H1-2026", AsOf: &asOf},
+		Portfolios: []event.Portfolio{
+			{PortID: 1, Name: "Kept", UserID: "u1", CreatedAt: event.MustParseTimestamp("2026-02-01"), LastUpdate: event.MustParseTimestamp("2026-03-01")},
+			{PortID: 2, Name: "Later", UserID: "u2", CreatedAt: event.MustParseTimestamp("2026-08-01"), LastUpdate: event.MustParseTimestamp("2026-08-02")},
+		},
+	}
+	evt, err := event.NewBaseEvent(event.PortfolioReport, payload)
+	if err != nil {
+		t.Fatalf("NewBaseEvent error: %v", err)
+	}
+
+	// Both paths leave out portfolios created after the as-of date
+	for _, threshold := range []int{0, 1} {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock.New error: %v", err)
+		}
+		mock.ExpectExec("INSERT INTO reports").
+			WithArgs("u1", "portfolio.report", evt.EventID, evt.CorrelationID, evt.RequestedBy).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		pdfGen, err := report.NewPDFGenerator(t.TempDir())
+		if err != nil {
+			t.Fatalf("NewPDFGenerator error: %v", err)
+		}
+		h := NewPortfolioReportHandler(db, pdfGen)
+		h.StreamThreshold = threshold
+		if err := h.Handle(context.Background(), evt); err != nil {
+			t.Fatalf("threshold %d: expected no error, got %v", threshold, err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("threshold %d: DB expectations not met: %v", threshold, err)
+		}
+		db.Close()
+	}
+
+	// Unknown period names are rejected as validation errors on both paths
+	bad := event.BaseEvent{EventType: event.PortfolioReport, Payload: json.RawMessage(`{"period":"Q5-2026","portfolios":[]}`)}
+	for _, threshold := range []int{0, 1} {
+		h := NewPortfolioReportHandler(nil, nil)
+		h.StreamThreshold = threshold
+		var verrs event.ValidationErrors
+		err := h.Handle(context.Background(), bad)
+		if !IsPermanent(err) || !errors.As(err, &verrs) {
+			t.Fatalf("threshold %d: expected permanent validation error, got %v", threshold, err)
+		}
+		found := false
+		for _, fe := range verrs {
+			found = found || (fe.Path == "period" && fe.Rule == event.RuleOneOf)
+		}
+		if !found {
+			t.Errorf("threshold %d: expected period error, got %v", threshold, verrs)
+		}
+	}
+}
+
+func TestPortfolioReportHandler_Filter(t *testing.T) {
+	payload := event.PortfolioReportPayload{
+		Filter: `userID in ("u1", "u3") and lastUpdate >= 2026-01-01`,
+		Portfolios: []event.Portfolio{
+			{PortID: 1, Name: "Kept", UserID: "u1", CreatedAt: event.MustParseTimestamp("2025-02-01"), LastUpdate: event.MustParseTimestamp("2026-03-01")},
+			{PortID: 2, Name: "Other user", UserID: "u2", CreatedAt: event.MustParseTimestamp("2025-02-01"), LastUpdate: event.MustParseTimestamp("2026-03-01")},
+			{PortID: 3, Name: "Stale", UserID: "u3", CreatedAt: event.MustParseTimestamp("2025-02-01"), LastUpdate: event.MustParseTimestamp("2025-03-01")},
+		},
+	}
+	evt, err := event.NewBaseEvent(event.PortfolioReport, payload)
+	if err != nil {
+		t.Fatalf("NewBaseEvent error: %v", err)
+	}
+
+	// Both paths only record the matching portfolios
+	for _, threshold := range []int{0, 1} {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock.New error: %v", err)
+		}
+		mock.ExpectExec("INSERT INTO reports").
+			WithArgs("u1", "portfolio.report", evt.EventID, evt.CorrelationID, evt.RequestedBy).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		h := NewPortfolioReportHandler(db, nil)
+		h.StreamThreshold = threshold
+		if err := h.Handle(context.Background(), evt); err != nil {
+			t.Fatalf("threshold %d: expected no error, got %v", threshold, err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("threshold %d: DB expectations not met: %v", threshold, err)
+		}
+		db.Close()
+	}
+
+	// Parse errors are validation failures and are not retried
+	payload.Filter = `userID in ("u1"`
+	raw, _ := json.Marshal(payload)
+	bad := event.BaseEvent{EventType: event.PortfolioReport, Payload: raw}
+	for _, threshold := range []int{0, 1} {
+		h := NewPortfolioReportHandler(nil, nil)
+		h.StreamThreshold = threshold
+		var verrs event.ValidationErrors
+		err := h.Handle(context.Background(), bad)
+		if !IsPermanent(err) || !errors.As(err, &verrs) || len(verrs) != 1 || verrs[0].Path != "filter" {
+			t.Errorf("threshold %d: expected permanent filter validation error, got %v", threshold, err)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/burakmike/report-export-service/pkg/portfolio"
+)
+
+// ErrPortfolioNotFound istenen portföyler depoda bulunamadığında döner
+var ErrPortfolioNotFound = errors.New("portfolio not found")
+
+// ErrStoreNotConfigured portföy deposu gerektiren bir olay depo olmadan alındığında döner.
+// Yapılandırma hatası yeniden denemeyle düzelmeyeceğinden mesaj kalıcı olarak reddedilir.
+var ErrStoreNotConfigured = errors.New("portfolio store not configured")
+
+// PortfolioReportRequestHandler portfolio.report.request olaylarını işler: kullanıcının
+// portföylerini portföy deposundan yükler ve portfolio.report işleyicisiyle raporlar
+type PortfolioReportRequestHandler struct {
+	Portfolios portfolio.Repository
+	Reports    *PortfolioReportHandler
+}
+
+// NewPortfolioReportRequestHandler yeni bir portfolio report request handler oluşturur
+func NewPortfolioReportRequestHandler(portfolios portfolio.Repository, reports *PortfolioReportHandler) *PortfolioReportRequestHandler {
+	return &PortfolioReportRequestHandler{
+		Portfolios: portfolios,
+		Reports:    reports,
+	}
+}
+
+// Register handler'ı kayıt sistemine typed handler olarak ekler
+func (h *PortfolioReportRequestHandler) Register(registry *HandlerRegistry) {
+	RegisterTyped(registry, event.PortfolioReportRequest, h.Handle)
+}
+
+// Handle çözülmüş ve doğrulanmış portfolio.report.request payload'ını işler. Yüklenen
+// portföyler, isteğin sonucu olan bir portfolio.report event'i olarak raporlanır; böylece
+// rapora gömülen kaynak veri depodaki güncel veridir ve korelasyon kimliği korunur.
+func (h *PortfolioReportRequestHandler) Handle(ctx context.Context, evt event.BaseEvent, payload event.PortfolioReportRequestPayload) error {
+	log.Printf("Processing portfolio report request for user %s with %d portfolio IDs (%s)", payload.UserID, len(payload.PortIDs), evt.LogContext())
+
+	if h.Portfolios == nil || h.Reports == nil {
+		// Mesaj kuyruğa geri konursa sonsuz bir döngüde yeniden teslim edilir
+		return Permanent(fmt.Errorf("%w for %s", ErrStoreNotConfigured, evt.EventType))
+	}
+
+	portfolios, err := h.Portfolios.FindByUser(ctx, payload.UserID, payload.PortIDs)
+	if err != nil {
+		// Veritabanı hataları geçicidir, mesaj yeniden denenir
+		return fmt.Errorf("failed to load portfolios for user %s: %w", payload.UserID, err)
+	}
+	if err := checkRequestedPortfolios(payload, portfolios); err != nil {
+		return Permanent(fmt.Errorf("invalid %s payload: %w", evt.EventType, err))
+	}
+
+	reportEvt, err := event.NewCausedEvent(evt, event.PortfolioReport, event.PortfolioReportPayload{
+		ReportPeriod: payload.ReportPeriod,
+		Filter:       payload.Filter,
+		Portfolios:   portfolios,
+	})
+	if err != nil {
+		return Permanent(fmt.Errorf("failed to create %s event: %w", event.PortfolioReport, err))
+	}
+	log.Printf("Resolved %d portfolios for user %s, generating report (%s)", len(portfolios), payload.UserID, reportEvt.LogContext())
+	return h.Reports.Handle(ctx, reportEvt)
+}
+
+// checkRequestedPortfolios istenen tüm portföylerin yüklendiğini doğrular. Başka bir
+// kullanıcıya ait kimlikler de bulunamamış sayılır.
+func checkRequestedPortfolios(payload event.PortfolioReportRequestPayload, portfolios []event.Portfolio) error {
+	if len(payload.PortIDs) == 0 {
+		if len(portfolios) == 0 {
+			return fmt.Errorf("%w: user %s has no portfolios", ErrPortfolioNotFound, payload.UserID)
+		}
+		return nil
+	}
+
+	found := make(map[int]bool, len(portfolios))
+	for _, p := range portfolios {
+		found[p.PortID] = true
+	}
+	var missing []int
+	for _, id := range payload.PortIDs {
+		if !found[id] {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %v for user %s", ErrPortfolioNotFound, missing, payload.UserID)
+	}
+	return nil
+}
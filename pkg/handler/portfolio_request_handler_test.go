@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+// fakePortfolioRepository bellekteki portföyleri kullanıcı ve kimliğe göre döndürür
+type fakePortfolioRepository struct {
+	portfolios []event.Portfolio
+	err        error
+}
+
+func (r *fakePortfolioRepository) FindByUser(ctx context.Context, userID string, portIDs []int) ([]event.Portfolio, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	wanted := make(map[int]bool)
+	for _, id := range portIDs {
+		wanted[id] = true
+	}
+	var out []event.Portfolio
+	for _, p := range r.portfolios {
+		if p.UserID == userID && (len(portIDs) == 0 || wanted[p.PortID]) {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakePortfolioRepository) FindByUsers(ctx context.Context, userIDs []string) ([]event.Portfolio, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	var out []event.Portfolio
+	for _, userID := range userIDs {
+		found, _ := r.FindByUser(ctx, userID, nil)
+		out = append(out, found...)
+	}
+	return out, nil
+}
+
+func newTestPortfolioRepository() *fakePortfolioRepository {
+	ts := event.MustParseTimestamp("2024-01-01")
+	return &fakePortfolioRepository{portfolios: []event.Portfolio{
+		{PortID: 1, Name: "Tech", UserID: "user123", CreatedAt: ts, LastUpdate: ts},
+		{PortID: 2, Name: "Retirement", UserID: "user456", CreatedAt: ts, LastUpdate: ts},
+		{PortID: 3, Name: "Growth", UserID: "user123", CreatedAt: ts, LastUpdate: ts},
+	}}
+}
+
+func TestPortfolioReportRequestHandler_ResolvesPortfolios(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	defer db.Close()
+
+	evt, _ := event.NewPortfolioReportRequestEvent("user123")
+	evt.RequestedBy = "ops"
+
+	// Her çözülen portföy için, istekle aynı korelasyonlu bir portfolio.report kaydı yazılır
+	for i := 0; i < 2; i++ {
+		mock.ExpectExec("INSERT INTO reports").
+			WithArgs("user123", "portfolio.report", sqlmock.AnyArg(), evt.CorrelationID, "ops").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+
+	registry := NewHandlerRegistry()
+	NewPortfolioReportRequestHandler(newTestPortfolioRepository(), NewPortfolioReportHandler(db, nil)).Register(registry)
+	if err := registry.HandleEvent(context.Background(), evt); err != nil {
+		t.Fatalf("HandleEvent error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("DB expectations not met: %v", err)
+	}
+}
+
+func TestPortfolioReportRequestHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		repo      *fakePortfolioRepository
+		userID    string
+		portIDs   []int
+		permanent bool
+	}{
+		{"unknown user", newTestPortfolioRepository(), "nobody", nil, true},
+		{"portfolio of another user", newTestPortfolioRepository(), "user123", []int{1, 2}, true},
+		{"store failure", &fakePortfolioRepository{err: errors.New("db down")}, "user123", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewHandlerRegistry()
+			NewPortfolioReportRequestHandler(tt.repo, NewPortfolioReportHandler(nil, nil)).Register(registry)
+
+			evt, _ := event.NewPortfolioReportRequestEvent(tt.userID, tt.portIDs...)
+			err := registry.HandleEvent(context.Background(), evt)
+			if err == nil {
+				t.Fatal("Expected error")
+			}
+			if IsPermanent(err) != tt.permanent {
+				t.Errorf("IsPermanent(%v) = %v; want %v", err, IsPermanent(err), tt.permanent)
+			}
+			if tt.permanent && !errors.Is(err, ErrPortfolioNotFound) {
+				t.Errorf("Expected ErrPortfolioNotFound, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPortfolioReportRequestHandler_StoreNotConfigured(t *testing.T) {
+	registry := NewHandlerRegistry()
+	NewPortfolioReportRequestHandler(nil, NewPortfolioReportHandler(nil, nil)).Register(registry)
+
+	evt, _ := event.NewPortfolioReportRequestEvent("user123")
+	if err := registry.HandleEvent(context.Background(), evt); !IsPermanent(err) || !errors.Is(err, ErrStoreNotConfigured) {
+		t.Errorf("Expected a permanent ErrStoreNotConfigured without a portfolio store, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/burakmike/report-export-service/pkg/report"
+)
+
+// RawMessage bir event'in alındığı haliyle mesaj gövdesi. Gövde sıkıştırılmış ya da
+// gob olabilir; EventType ve Timestamp gövdeden çözülen zarftan alınır.
+type RawMessage struct {
+	Body            []byte
+	ContentType     string
+	ContentEncoding string
+	EventType       event.EventType
+	Timestamp       event.Timestamp
+}
+
+// rawMessageKey alınan mesajın bağlamdaki anahtarı
+type rawMessageKey struct{}
+
+// WithRawMessage olayın alındığı mesajı taşıyan bir bağlam döndürür. Handler'lar
+// mesajı (ör. kaynak verinin PDF'e gömülmesi için) RawMessageFrom ile okur.
+func WithRawMessage(ctx context.Context, msg RawMessage) context.Context {
+	return context.WithValue(ctx, rawMessageKey{}, &msg)
+}
+
+// withoutRawMessage bağlamdaki mesajı kaldırır; birden fazla mesajdan birleştirilen
+// olaylar tek bir alınan mesaja karşılık gelmez
+func withoutRawMessage(ctx context.Context) context.Context {
+	return context.WithValue(ctx, rawMessageKey{}, (*RawMessage)(nil))
+}
+
+// RawMessageFrom bağlamdaki alınan mesajı döndürür; mesaj yoksa false döner
+func RawMessageFrom(ctx context.Context) (RawMessage, bool) {
+	msg, ok := ctx.Value(rawMessageKey{}).(*RawMessage)
+	if !ok || msg == nil {
+		return RawMessage{}, false
+	}
+	return *msg, true
+}
+
+// sourceEvent raporlara gömülecek kaynak mesajı döndürür. Olay tek bir mesajdan
+// alındıysa mesaj alındığı haliyle (sıkıştırılmış ya da gob olabilir) gömülür; aksi
+// halde (parçalı mesajlar, doğrudan çağrılar) çözülmüş event'in JSON'u kullanılır.
+// portfolio.report.request için alınan mesaj, rapor event'ini tetikleyen istektir.
+func sourceEvent(ctx context.Context, evt event.BaseEvent) (*report.SourceEvent, error) {
+	msg, ok := RawMessageFrom(ctx)
+	if !ok {
+		return report.NormalizedSourceEvent(evt)
+	}
+
+	filename, format := "event.json", "JSON"
+	if strings.Contains(msg.ContentType, "gob") {
+		filename, format = "event.gob", "gob"
+	}
+	switch encoding := strings.ToLower(strings.TrimSpace(msg.ContentEncoding)); encoding {
+	case "", "identity":
+	case "gzip":
+		filename, format = filename+".gz", format+", gzip"
+	default:
+		filename, format = filename+"."+encoding, format+", "+encoding
+	}
+	return &report.SourceEvent{
+		Data:        msg.Body,
+		Filename:    filename,
+		Description: fmt.Sprintf("Original event message (%s)", format),
+		EventType:   msg.EventType,
+		Timestamp:   msg.Timestamp,
+	}, nil
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+func TestSourceEvent(t *testing.T) {
+	evt, _ := event.NewPortfolioReportEvent(event.CreateSamplePortfolios())
+
+	tests := []struct {
+		name        string
+		msg         *RawMessage
+		filename    string
+		description string
+	}{
+		{"no received message", nil, "event.json", fmt.Sprintf("Normalized event (schema v%d)", event.CurrentSchemaVersion)},
+		{"json", &RawMessage{Body: []byte(`{}`), ContentType: "application/json"}, "event.json", "Original event message (JSON)"},
+		{"cloudevents", &RawMessage{Body: []byte(`{}`), ContentType: "application/cloudevents+json"}, "event.json", "Original event message (JSON)"},
+		{"gzip", &RawMessage{Body: []byte{0x1f, 0x8b}, ContentType: "application/json", ContentEncoding: "gzip"}, "event.json.gz", "Original event message (JSON, gzip)"},
+		{"gob", &RawMessage{Body: []byte{0x01}, ContentType: "application/x-gob"}, "event.gob", "Original event message (gob)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.msg != nil {
+				ctx = WithRawMessage(ctx, *tt.msg)
+			}
+			source, err := sourceEvent(ctx, evt)
+			if err != nil {
+				t.Fatalf("sourceEvent error: %v", err)
+			}
+			if source.Filename != tt.filename || source.Description != tt.description {
+				t.Errorf("Got %s (%s); want %s (%s)", source.Filename, source.Description, tt.filename, tt.description)
+			}
+			if tt.msg != nil && string(source.Data) != string(tt.msg.Body) {
+				t.Errorf("Expected the received bytes to be embedded unchanged")
+			}
+		})
+	}
+
+	// Birleştirilen parçalı mesajlar tek bir alınan mesaja karşılık gelmez
+	ctx := withoutRawMessage(WithRawMessage(context.Background(), RawMessage{Body: []byte(`{}`)}))
+	if _, ok := RawMessageFrom(ctx); ok {
+		t.Error("Expected no received message after withoutRawMessage")
+	}
+}
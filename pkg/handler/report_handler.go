@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/burakmike/report-export-service/pkg/report"
+)
+
+// ReportPayload ReportHandler'ın işleyebildiği payload'lar. Rapor kayıtları
+// UserIDs'in döndürdüğü her kullanıcı için tutulur.
+type ReportPayload interface {
+	UserIDs() []string
+}
+
+// ReportSpec bir rapor türünün kendine özgü kısımlarını tanımlar: payload'dan rapor
+// verisini oluşturan analiz adımı ve bu veriyi PDF'e dönüştüren renderer. Payload'ın
+// çözülmesi, doğrulanması, PDF'in oluşturulması ve rapor kayıtları ReportHandler'da ortaktır.
+type ReportSpec[P ReportPayload, R any] struct {
+	EventType event.EventType
+	Name      string // log satırlarındaki rapor adı, ör. "transaction"
+
+	// Build payload'dan rapor verisini oluşturur. Hatalar payload'daki eksik veriden
+	// (ör. kur ya da alım geçmişi) kaynaklanır ve kalıcı hata sayılır.
+	Build func(payload P) (R, error)
+	// Render rapor verisinden PDF oluşturur ve dosya yolunu döndürür
+	Render func(g *report.PDFGenerator, data R) (string, error)
+	// Describe rapor verisinin özetini loglar (opsiyonel)
+	Describe func(data R)
+}
+
+// ReportHandler ReportSpec ile tanımlanan bir rapor olayını işleyen genel handler
+type ReportHandler[P ReportPayload, R any] struct {
+	DB           *sql.DB
+	PDFGenerator *report.PDFGenerator
+	Spec         ReportSpec[P, R]
+}
+
+// NewReportHandler verilen rapor tanımı için yeni bir handler oluşturur
+func NewReportHandler[P ReportPayload, R any](db *sql.DB, pdfGenerator *report.PDFGenerator, spec ReportSpec[P, R]) *ReportHandler[P, R] {
+	return &ReportHandler[P, R]{
+		DB:           db,
+		PDFGenerator: pdfGenerator,
+		Spec:         spec,
+	}
+}
+
+// Register handler'ı kayıt sistemine typed handler olarak ekler
+func (h *ReportHandler[P, R]) Register(registry *HandlerRegistry) {
+	RegisterTyped(registry, h.Spec.EventType, h.Handle)
+}
+
+// Handle çözülmüş ve doğrulanmış payload'dan raporu oluşturur ve her portföyün
+// kullanıcısı için bir rapor kaydı tutar
+func (h *ReportHandler[P, R]) Handle(ctx context.Context, evt event.BaseEvent, payload P) error {
+	users := payload.UserIDs()
+	log.Printf("Processing %s report event with %d portfolios (%s)", h.Spec.Name, len(users), evt.LogContext())
+
+	data, err := h.Spec.Build(payload)
+	if err != nil {
+		// Eksik veri yeniden denemeyle düzelmez
+		return Permanent(fmt.Errorf("invalid %s payload: %w", evt.EventType, err))
+	}
+	if h.Spec.Describe != nil {
+		h.Spec.Describe(data)
+	}
+
+	if h.PDFGenerator != nil {
+		filePath, err := h.Spec.Render(h.PDFGenerator, data)
+		if err != nil {
+			log.Printf("Error generating %s report (%s): %v", h.Spec.Name, evt.LogContext(), err)
+		} else {
+			log.Printf("The %s report was successfully generated at: %s (%s)", h.Spec.Name, filePath, evt.LogContext())
+		}
+	} else {
+		log.Println("PDF generator not available, skipping report generation")
+	}
+
+	for _, userID := range users {
+		saveReportRecord(ctx, h.DB, evt, userID)
+	}
+	return nil
+}
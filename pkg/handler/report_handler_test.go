@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/burakmike/report-export-service/pkg/report"
+)
+
+type stubReportPayload struct {
+	Users []string
+}
+
+func (p stubReportPayload) UserIDs() []string { return p.Users }
+
+func TestReportHandler_BuildErrorIsPermanent(t *testing.T) {
+	h := NewReportHandler(nil, nil, ReportSpec[stubReportPayload, int]{
+		EventType: "stub.report",
+		Name:      "stub",
+		Build: func(p stubReportPayload) (int, error) {
+			return 0, errors.New("missing data")
+		},
+		Render: func(g *report.PDFGenerator, n int) (string, error) {
+			t.Error("Render must not be called after a build error")
+			return "", nil
+		},
+	})
+
+	evt := event.BaseEvent{EventType: "stub.report"}
+	if err := h.Handle(context.Background(), evt, stubReportPayload{Users: []string{"u1"}}); !IsPermanent(err) {
+		t.Errorf("Expected permanent error, got %v", err)
+	}
+}
+
+func TestReportHandlers_Register(t *testing.T) {
+	tests := []struct {
+		eventType event.EventType
+		register  func(db *sql.DB, registry *HandlerRegistry)
+		payload   string
+	}{
+		{
+			event.TransactionReport,
+			func(db *sql.DB, r *HandlerRegistry) { NewTransactionReportHandler(db, nil).Register(r) },
+			`{"portfolios":[{"portID":1,"name":"A","userID":"u1","transactions":[{"date":"2024-01-01","type":"deposit","amount":"100"}]}]}`,
+		},
+		{
+			event.HoldingsReport,
+			func(db *sql.DB, r *HandlerRegistry) { NewHoldingsReportHandler(db, nil).Register(r) },
+			`{"portfolios":[{"portID":1,"name":"A","userID":"u1","holdings":[{"instrument":"AAPL","assetClass":"equity","quantity":10,"price":"150.25"}]}]}`,
+		},
+		{
+			event.PerformanceReport,
+			func(db *sql.DB, r *HandlerRegistry) { NewPerformanceReportHandler(db, nil).Register(r) },
+			`{"portfolios":[{"portID":1,"name":"A","userID":"u1","valuations":[{"date":"2024-01-01","value":100},{"date":"2024-06-30","value":110}]}]}`,
+		},
+		{
+			event.IncomeReport,
+			func(db *sql.DB, r *HandlerRegistry) { NewIncomeReportHandler(db, nil).Register(r) },
+			`{"period":"2024","portfolios":[{"portID":1,"name":"A","userID":"u1","payments":[{"date":"2024-03-15","type":"dividend","instrument":"AAPL","gross":25,"withholdingTax":3.75}]}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.eventType), func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New error: %v", err)
+			}
+			defer db.Close()
+
+			registry := NewHandlerRegistry()
+			tt.register(db, registry)
+
+			evt, _ := event.NewBaseEvent(tt.eventType, json.RawMessage(tt.payload))
+			mock.ExpectExec("INSERT INTO reports").
+				WithArgs("u1", string(tt.eventType), evt.EventID, evt.CorrelationID, evt.RequestedBy).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			if err := registry.HandleEvent(context.Background(), evt); err != nil {
+				t.Fatalf("HandleEvent error: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Unmet expectations: %v", err)
+			}
+
+			// Geçersiz payload'lar handler'a ulaşmadan kalıcı olarak reddedilir
+			bad := event.BaseEvent{EventType: tt.eventType, Payload: json.RawMessage(`{"portfolios":[]}`)}
+			if err := registry.HandleEvent(context.Background(), bad); !IsPermanent(err) {
+				t.Errorf("Expected permanent error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"database/sql"
+	"log"
+
+	"github.com/burakmike/report-export-service/pkg/analytics"
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/burakmike/report-export-service/pkg/report"
+)
+
+// TransactionReportHandler transaction.report olaylarını işler: her portföy için
+// yürüyen bakiyeli bir işlem defteri oluşturur ve PDF olarak raporlar
+type TransactionReportHandler = ReportHandler[event.TransactionReportPayload, []analytics.Ledger]
+
+// NewTransactionReportHandler yeni bir transaction report handler oluşturur
+func NewTransactionReportHandler(db *sql.DB, pdfGenerator *report.PDFGenerator) *TransactionReportHandler {
+	return NewReportHandler(db, pdfGenerator, ReportSpec[event.TransactionReportPayload, []analytics.Ledger]{
+		EventType: event.TransactionReport,
+		Name:      "transaction",
+		Build: func(p event.TransactionReportPayload) ([]analytics.Ledger, error) {
+			return analytics.BuildLedgers(p), nil
+		},
+		Render: (*report.PDFGenerator).GenerateTransactionReport,
+		Describe: func(ledgers []analytics.Ledger) {
+			for _, ledger := range ledgers {
+				log.Printf("Ledger: PortID=%d, Transactions=%d, Opening=%s, Closing=%s",
+					ledger.PortID, len(ledger.Entries), ledger.OpeningBalance, ledger.ClosingBalance)
+			}
+		},
+	})
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+// TypedHandlerFunc payload'ı çözülmüş ve doğrulanmış bir olayı işleyen fonksiyon.
+// evt zarf bilgilerini (kimlikler, zaman, sürüm) taşır.
+type TypedHandlerFunc[T any] func(ctx context.Context, evt event.BaseEvent, payload T) error
+
+// typedHandler bir TypedHandlerFunc'ı EventHandler arayüzüne uyarlar
+type typedHandler[T any] struct {
+	eventType event.EventType
+	fn        TypedHandlerFunc[T]
+}
+
+// EventType bu işleyicinin hangi event tipini işlediğini belirtir
+func (h *typedHandler[T]) EventType() event.EventType {
+	return h.eventType
+}
+
+// Handle payload'ı T tipine çözer, doğrular ve fonksiyonu çağırır
+func (h *typedHandler[T]) Handle(ctx context.Context, evt event.BaseEvent) error {
+	payload, err := DecodePayload[T](evt)
+	if err != nil {
+		return err
+	}
+	return h.fn(ctx, evt, payload)
+}
+
+// NewTypedHandler bir fonksiyondan event tipine bağlı bir EventHandler oluşturur
+func NewTypedHandler[T any](eventType event.EventType, fn TypedHandlerFunc[T]) EventHandler {
+	return &typedHandler[T]{eventType: eventType, fn: fn}
+}
+
+// RegisterTyped event tipi için payload'ı T tipine çözen bir işleyici kaydeder:
+//
+//	handler.RegisterTyped(registry, event.PortfolioReport,
+//		func(ctx context.Context, evt event.BaseEvent, p event.PortfolioReportPayload) error { ... })
+func RegisterTyped[T any](r *HandlerRegistry, eventType event.EventType, fn TypedHandlerFunc[T]) {
+	r.RegisterHandler(NewTypedHandler(eventType, fn))
+}
+
+// DecodePayload olayın payload'ını T tipine çözer ve T event.Validator ise doğrular.
+// Çözme ve doğrulama hataları tekrar denense de düzelmeyeceği için kalıcı hatadır.
+func DecodePayload[T any](evt event.BaseEvent) (T, error) {
+	var payload T
+	if err := evt.ParsePayload(&payload); err != nil {
+		return payload, Permanent(fmt.Errorf("failed to parse %s payload: %w", evt.EventType, err))
+	}
+
+	// *T hem değer hem pointer alıcılı Validate metodunu kapsar
+	if v, ok := any(&payload).(event.Validator); ok {
+		if err := v.Validate(); err != nil {
+			// Uzun hata listeleri iş kaydına ve loglara sınırlı yazılır
+			var verrs event.ValidationErrors
+			if errors.As(err, &verrs) {
+				err = verrs.Truncate(event.MaxValidationErrors, "payload")
+			}
+			return payload, Permanent(fmt.Errorf("invalid %s payload: %w", evt.EventType, err))
+		}
+	}
+	return payload, nil
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+// greetingPayload pointer alıcılı Validate metodu olan test payload'ı
+type greetingPayload struct {
+	Name string `json:"name"`
+}
+
+func (g *greetingPayload) Validate() error {
+	if g.Name == "" {
+		return event.ValidationErrors{{Path: "name", Rule: event.RuleRequired, Message: "must not be blank"}}
+	}
+	return nil
+}
+
+func TestRegisterTyped(t *testing.T) {
+	const greeting event.EventType = "test.greeting"
+	registry := NewHandlerRegistry()
+
+	var got greetingPayload
+	var gotID string
+	RegisterTyped(registry, greeting, func(ctx context.Context, evt event.BaseEvent, p greetingPayload) error {
+		got, gotID = p, evt.EventID
+		return nil
+	})
+
+	evt := event.BaseEvent{EventID: "evt-1", EventType: greeting, Payload: json.RawMessage(`{"name":"Ada"}`)}
+	if err := registry.HandleEvent(context.Background(), evt); err != nil {
+		t.Fatalf("HandleEvent error: %v", err)
+	}
+	if got.Name != "Ada" || gotID != "evt-1" {
+		t.Errorf("Typed handler got %+v (event %q)", got, gotID)
+	}
+}
+
+func TestDecodePayload_Errors(t *testing.T) {
+	cases := map[string]json.RawMessage{
+		"malformed": json.RawMessage(`{"name":`),
+		"invalid":   json.RawMessage(`{"name":""}`),
+	}
+	for name, raw := range cases {
+		_, err := DecodePayload[greetingPayload](event.BaseEvent{EventType: "test.greeting", Payload: raw})
+		if !IsPermanent(err) {
+			t.Errorf("%s: expected permanent error, got %v", name, err)
+		}
+	}
+
+	_, err := DecodePayload[greetingPayload](event.BaseEvent{EventType: "test.greeting", Payload: cases["invalid"]})
+	var verrs event.ValidationErrors
+	if !errors.As(err, &verrs) || verrs[0].Path != "name" {
+		t.Errorf("Validation errors not preserved: %v", err)
+	}
+
+	// Payloads without a Validate method are only decoded
+	var m map[string]string
+	m, err = DecodePayload[map[string]string](event.BaseEvent{Payload: json.RawMessage(`{"a":"b"}`)})
+	if err != nil || m["a"] != "b" {
+		t.Errorf("DecodePayload map = %v, %v", m, err)
+	}
+}
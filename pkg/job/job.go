@@ -0,0 +1,143 @@
+package job
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+// Status bir rapor işinin durumunu belirtir
+type Status string
+
+// İş durumları
+const (
+	StatusProcessing Status = "processing" // Handler çalışıyor
+	StatusCompleted  Status = "completed"  // Başarıyla tamamlandı
+	StatusFailed     Status = "failed"     // Geçici hata, mesaj yeniden denenecek
+	StatusRejected   Status = "rejected"   // Kalıcı hata (ör. doğrulama), yeniden denenmeyecek
+)
+
+// CreateTableQuery iş kayıtları tablosunu oluşturur
+const CreateTableQuery = `
+	CREATE TABLE IF NOT EXISTS report_jobs (
+		event_id TEXT PRIMARY KEY,
+		event_type TEXT NOT NULL,
+		correlation_id TEXT,
+		status TEXT NOT NULL,
+		attempts INTEGER NOT NULL DEFAULT 0,
+		error TEXT,
+		validation_errors JSONB,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	);
+	ALTER TABLE report_jobs ADD COLUMN IF NOT EXISTS quality_issues JSONB;`
+
+// upsertQuery iş kaydını event kimliğine göre oluşturur veya günceller
+const upsertQuery = `
+	INSERT INTO report_jobs(event_id, event_type, correlation_id, status, attempts, error, validation_errors, quality_issues)
+	VALUES($1, $2, $3, $4, $5, $6, $7, $8)
+	ON CONFLICT (event_id) DO UPDATE SET
+		status = EXCLUDED.status,
+		attempts = report_jobs.attempts + EXCLUDED.attempts,
+		error = EXCLUDED.error,
+		validation_errors = EXCLUDED.validation_errors,
+		quality_issues = EXCLUDED.quality_issues,
+		updated_at = now()`
+
+// Recorder rapor işlerinin durumunu kaydeden arayüz
+type Recorder interface {
+	// Record event'e ait işin durumunu ve (varsa) hatasını kaydeder
+	Record(ctx context.Context, evt event.BaseEvent, status Status, err error) error
+}
+
+// SQLRecorder iş durumlarını PostgreSQL'deki report_jobs tablosuna yazar
+type SQLRecorder struct {
+	DB *sql.DB
+}
+
+// NewSQLRecorder yeni bir SQL iş kaydedici oluşturur
+func NewSQLRecorder(db *sql.DB) *SQLRecorder {
+	return &SQLRecorder{DB: db}
+}
+
+// Record iş durumunu kaydeder. Doğrulama hataları ve bağlamda toplanan veri kalitesi
+// sorunları (bkz. AddIssues) alan bazında JSON olarak saklanır.
+func (r *SQLRecorder) Record(ctx context.Context, evt event.BaseEvent, status Status, err error) error {
+	attempts := 0
+	if status == StatusProcessing {
+		attempts = 1
+	}
+
+	var errText, validationJSON sql.NullString
+	if err != nil {
+		errText = sql.NullString{String: err.Error(), Valid: true}
+
+		var verrs event.ValidationErrors
+		if errors.As(err, &verrs) {
+			data, marshalErr := json.Marshal(verrs)
+			if marshalErr != nil {
+				return fmt.Errorf("failed to encode validation errors: %w", marshalErr)
+			}
+			validationJSON = sql.NullString{String: string(data), Valid: true}
+		}
+	}
+
+	var issuesJSON sql.NullString
+	if issues := Issues(ctx); len(issues) > 0 {
+		data, marshalErr := json.Marshal(issues)
+		if marshalErr != nil {
+			return fmt.Errorf("failed to encode quality issues: %w", marshalErr)
+		}
+		issuesJSON = sql.NullString{String: string(data), Valid: true}
+	}
+
+	_, execErr := r.DB.ExecContext(ctx, upsertQuery,
+		evt.EventID, string(evt.EventType), evt.CorrelationID, string(status), attempts, errText, validationJSON, issuesJSON)
+	if execErr != nil {
+		return fmt.Errorf("failed to record job status: %w", execErr)
+	}
+	return nil
+}
+
+// issuesKey veri kalitesi sorunlarının bağlamdaki anahtarı
+type issuesKey struct{}
+
+// issueList bir işin işlenmesi sırasında toplanan sorunlar
+type issueList struct {
+	mu     sync.Mutex
+	issues []event.FieldError
+}
+
+// WithIssues işin işlenmesi sırasında bulunan veri kalitesi sorunlarını toplayan
+// bir bağlam döndürür. Aynı bağlamla yapılan Record çağrıları sorunları iş kaydına yazar.
+func WithIssues(ctx context.Context) context.Context {
+	return context.WithValue(ctx, issuesKey{}, &issueList{})
+}
+
+// AddIssues işi durdurmayan veri kalitesi sorunlarını bağlama ekler. Bağlam
+// WithIssues ile oluşturulmamışsa sorunlar yok sayılır.
+func AddIssues(ctx context.Context, issues ...event.FieldError) {
+	list, ok := ctx.Value(issuesKey{}).(*issueList)
+	if !ok || len(issues) == 0 {
+		return
+	}
+	list.mu.Lock()
+	defer list.mu.Unlock()
+	list.issues = append(list.issues, issues...)
+}
+
+// Issues bağlamda toplanan veri kalitesi sorunlarını döndürür
+func Issues(ctx context.Context) []event.FieldError {
+	list, ok := ctx.Value(issuesKey{}).(*issueList)
+	if !ok {
+		return nil
+	}
+	list.mu.Lock()
+	defer list.mu.Unlock()
+	return append([]event.FieldError(nil), list.issues...)
+}
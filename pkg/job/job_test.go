@@ -0,0 +1,82 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+func TestSQLRecorder_Record(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	defer db.Close()
+
+	evt := event.BaseEvent{EventID: "e1", EventType: event.PortfolioReport, CorrelationID: "c1"}
+	verrs := event.ValidationErrors{{Path: "portfolios", Rule: event.RuleRequired, Message: "must contain at least one portfolio"}}
+	validationErr := fmt.Errorf("invalid payload: %w", verrs)
+
+	mock.ExpectExec("INSERT INTO report_jobs").
+		WithArgs("e1", "portfolio.report", "c1", "processing", 1, nil, nil, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO report_jobs").
+		WithArgs("e1", "portfolio.report", "c1", "rejected", 0, validationErr.Error(),
+			`[{"path":"portfolios","rule":"required","message":"must contain at least one portfolio"}]`, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	r := NewSQLRecorder(db)
+	if err := r.Record(context.Background(), evt, StatusProcessing, nil); err != nil {
+		t.Fatalf("Record processing error: %v", err)
+	}
+	if err := r.Record(context.Background(), evt, StatusRejected, validationErr); err != nil {
+		t.Fatalf("Record rejected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("DB expectations not met: %v", err)
+	}
+}
+
+func TestSQLRecorder_RecordQualityIssues(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	defer db.Close()
+
+	evt := event.BaseEvent{EventID: "e1", EventType: event.PortfolioReport, CorrelationID: "c1"}
+	mock.ExpectExec("INSERT INTO report_jobs").
+		WithArgs("e1", "portfolio.report", "c1", "completed", 0, nil, nil,
+			`[{"path":"portfolios[1].name","rule":"required","value":" ","message":"is blank"}]`).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// Sorunlar yalnızca WithIssues ile oluşturulan bağlamda toplanır
+	AddIssues(context.Background(), event.FieldError{Path: "ignored"})
+	ctx := WithIssues(context.Background())
+	AddIssues(ctx, event.FieldError{Path: "portfolios[1].name", Rule: event.RuleRequired, Value: " ", Message: "is blank"})
+
+	if err := NewSQLRecorder(db).Record(ctx, evt, StatusCompleted, nil); err != nil {
+		t.Fatalf("Record error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("DB expectations not met: %v", err)
+	}
+}
+
+func TestSQLRecorder_RecordDBError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO report_jobs").WillReturnError(errors.New("db down"))
+	err = NewSQLRecorder(db).Record(context.Background(), event.BaseEvent{EventID: "e1"}, StatusCompleted, nil)
+	if err == nil {
+		t.Error("Expected error when the database fails")
+	}
+}
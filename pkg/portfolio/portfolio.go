@@ -0,0 +1,96 @@
+package portfolio
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/lib/pq"
+)
+
+// CreateTableQuery portföy referans verisi tablosunu oluşturur
+const CreateTableQuery = `
+	CREATE TABLE IF NOT EXISTS portfolios (
+		port_id INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+		last_update TIMESTAMPTZ NOT NULL DEFAULT now()
+	);
+	CREATE INDEX IF NOT EXISTS portfolios_user_id_idx ON portfolios(user_id);`
+
+// selectByUserQuery kullanıcının portföylerini, verilmişse yalnızca istenen kimlikleri döndürür
+const selectByUserQuery = `
+	SELECT port_id, name, user_id, created_at, last_update
+	FROM portfolios
+	WHERE user_id = $1 AND (cardinality($2::int[]) = 0 OR port_id = ANY($2::int[]))
+	ORDER BY port_id`
+
+// selectByUsersQuery verilen kullanıcıların portföylerini döndürür
+const selectByUsersQuery = `
+	SELECT port_id, name, user_id, created_at, last_update
+	FROM portfolios
+	WHERE user_id = ANY($1::text[])
+	ORDER BY user_id, port_id`
+
+// Repository portföy referans verisine erişim arayüzü
+type Repository interface {
+	// FindByUser kullanıcının portföylerini kimlik sırasıyla döndürür. portIDs boş
+	// değilse yalnızca bu kimliklere sahip ve kullanıcıya ait portföyler döner.
+	FindByUser(ctx context.Context, userID string, portIDs []int) ([]event.Portfolio, error)
+
+	// FindByUsers verilen kullanıcıların tüm portföylerini kullanıcı ve kimlik sırasıyla döndürür
+	FindByUsers(ctx context.Context, userIDs []string) ([]event.Portfolio, error)
+}
+
+// SQLRepository portföyleri PostgreSQL'deki portfolios tablosundan okur
+type SQLRepository struct {
+	DB *sql.DB
+}
+
+// NewSQLRepository yeni bir SQL portföy deposu oluşturur
+func NewSQLRepository(db *sql.DB) *SQLRepository {
+	return &SQLRepository{DB: db}
+}
+
+// FindByUser kullanıcının portföylerini veritabanından yükler
+func (r *SQLRepository) FindByUser(ctx context.Context, userID string, portIDs []int) ([]event.Portfolio, error) {
+	ids := make(pq.Int64Array, len(portIDs))
+	for i, id := range portIDs {
+		ids[i] = int64(id)
+	}
+
+	return r.query(ctx, selectByUserQuery, userID, ids)
+}
+
+// FindByUsers kullanıcıların portföylerini tek sorguda veritabanından yükler
+func (r *SQLRepository) FindByUsers(ctx context.Context, userIDs []string) ([]event.Portfolio, error) {
+	return r.query(ctx, selectByUsersQuery, pq.StringArray(userIDs))
+}
+
+// query portföy sorgusunu çalıştırır ve satırları okur
+func (r *SQLRepository) query(ctx context.Context, query string, args ...interface{}) ([]event.Portfolio, error) {
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query portfolios: %w", err)
+	}
+	defer rows.Close()
+
+	var portfolios []event.Portfolio
+	for rows.Next() {
+		var p event.Portfolio
+		var createdAt, lastUpdate time.Time
+		if err := rows.Scan(&p.PortID, &p.Name, &p.UserID, &createdAt, &lastUpdate); err != nil {
+			return nil, fmt.Errorf("failed to scan portfolio: %w", err)
+		}
+		p.CreatedAt = event.NewTimestamp(createdAt)
+		p.LastUpdate = event.NewTimestamp(lastUpdate)
+		portfolios = append(portfolios, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read portfolios: %w", err)
+	}
+	return portfolios, nil
+}
@@ -0,0 +1,103 @@
+package portfolio
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestSQLRepository_FindByUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	defer db.Close()
+
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	updated := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("SELECT port_id, name, user_id, created_at, last_update FROM portfolios").
+		WithArgs("user123", "{1,3}").
+		WillReturnRows(sqlmock.NewRows([]string{"port_id", "name", "user_id", "created_at", "last_update"}).
+			AddRow(1, "Tech", "user123", created, updated).
+			AddRow(3, "Growth", "user123", created, updated))
+
+	portfolios, err := NewSQLRepository(db).FindByUser(context.Background(), "user123", []int{1, 3})
+	if err != nil {
+		t.Fatalf("FindByUser error: %v", err)
+	}
+	if len(portfolios) != 2 {
+		t.Fatalf("Expected 2 portfolios, got %d", len(portfolios))
+	}
+	p := portfolios[1]
+	if p.PortID != 3 || p.Name != "Growth" || p.UserID != "user123" {
+		t.Errorf("Unexpected portfolio: %+v", p)
+	}
+	if !p.CreatedAt.Equal(created) || !p.LastUpdate.Equal(updated) {
+		t.Errorf("Unexpected timestamps: %s, %s", p.CreatedAt, p.LastUpdate)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("DB expectations not met: %v", err)
+	}
+}
+
+func TestSQLRepository_FindByUserAllPortfolios(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT port_id").
+		WithArgs("user123", "{}").
+		WillReturnRows(sqlmock.NewRows([]string{"port_id", "name", "user_id", "created_at", "last_update"}))
+
+	portfolios, err := NewSQLRepository(db).FindByUser(context.Background(), "user123", nil)
+	if err != nil {
+		t.Fatalf("FindByUser error: %v", err)
+	}
+	if len(portfolios) != 0 {
+		t.Errorf("Expected no portfolios, got %d", len(portfolios))
+	}
+}
+
+func TestSQLRepository_FindByUserDBError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT port_id").WillReturnError(errors.New("db down"))
+	if _, err := NewSQLRepository(db).FindByUser(context.Background(), "user123", nil); err == nil {
+		t.Error("Expected error when the database fails")
+	}
+}
+
+func TestSQLRepository_FindByUsers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New error: %v", err)
+	}
+	defer db.Close()
+
+	ts := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT port_id, name, user_id, created_at, last_update FROM portfolios WHERE user_id = ANY`).
+		WithArgs(`{"user123","user456"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"port_id", "name", "user_id", "created_at", "last_update"}).
+			AddRow(1, "Tech", "user123", ts, ts).
+			AddRow(2, "Retirement", "user456", ts, ts))
+
+	portfolios, err := NewSQLRepository(db).FindByUsers(context.Background(), []string{"user123", "user456"})
+	if err != nil {
+		t.Fatalf("FindByUsers error: %v", err)
+	}
+	if len(portfolios) != 2 || portfolios[1].UserID != "user456" {
+		t.Errorf("Unexpected portfolios: %+v", portfolios)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("DB expectations not met: %v", err)
+	}
+}
@@ -0,0 +1,193 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/streadway/amqp"
+)
+
+// CloudEvents AMQP protokol bağlamasında binary mode öznitelikleri
+// "cloudEvents:" önekli application-properties (header) olarak taşınır.
+// JMS uyumlu istemcilerin kullandığı "cloudEvents_" öneki de okunur.
+const (
+	cloudEventsHeaderPrefix    = "cloudEvents:"
+	cloudEventsAltHeaderPrefix = "cloudEvents_"
+)
+
+// EventFormat bir bağlamada yayınlanan mesajların formatı
+type EventFormat string
+
+// Desteklenen mesaj formatları
+const (
+	// FormatNative kendi JSON zarfımız (varsayılan)
+	FormatNative EventFormat = "native"
+	// FormatCloudEventsStructured tüm event tek bir CloudEvents JSON gövdesinde
+	FormatCloudEventsStructured EventFormat = "cloudevents-structured"
+	// FormatCloudEventsBinary öznitelikler header'larda, gövde yalnızca veri
+	FormatCloudEventsBinary EventFormat = "cloudevents-binary"
+	// FormatGob zarf ve payload gob ile kodlanır (bkz. encoding.go)
+	FormatGob EventFormat = "gob"
+)
+
+// ParseEventFormat metin olarak verilen formatı doğrular
+func ParseEventFormat(s string) (EventFormat, error) {
+	switch f := EventFormat(strings.TrimSpace(s)); f {
+	case FormatNative, FormatCloudEventsStructured, FormatCloudEventsBinary, FormatGob:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown event format %q", s)
+	}
+}
+
+// encodeEvent event'i seçilen formatta bir AMQP mesajına dönüştürür. Parça bilgisi
+// yalnızca native zarfta taşındığından parçalı mesajlar diğer formatlarda reddedilir;
+// aksi halde parça tüm bir event gibi işlenirdi.
+func encodeEvent(evt event.BaseEvent, format EventFormat) (amqp.Publishing, error) {
+	if evt.Chunk != nil && format != FormatNative && format != "" {
+		return amqp.Publishing{}, fmt.Errorf("cannot encode chunk %d/%d of %s as %s: chunks are only supported in the native format",
+			evt.Chunk.Seq, evt.Chunk.Total, evt.Chunk.RequestID, format)
+	}
+
+	msg := amqp.Publishing{
+		Timestamp:     time.Now(),
+		MessageId:     evt.EventID,
+		CorrelationId: evt.CorrelationID,
+		AppId:         evt.Source,
+		Type:          string(evt.EventType),
+	}
+
+	switch format {
+	case FormatCloudEventsStructured:
+		body, err := event.MarshalCloudEvent(evt)
+		if err != nil {
+			return msg, err
+		}
+		msg.ContentType = event.CloudEventsContentType
+		msg.Body = body
+
+	case FormatCloudEventsBinary:
+		msg.ContentType = event.JSONContentType
+		msg.Headers = cloudEventHeaders(evt)
+		msg.Body = evt.Payload
+
+	case FormatGob:
+		body, err := encodeGobEvent(evt)
+		if err != nil {
+			return msg, err
+		}
+		msg.ContentType = GobContentType
+		msg.Body = body
+
+	default:
+		body, err := json.Marshal(evt)
+		if err != nil {
+			return msg, fmt.Errorf("failed to marshal event: %w", err)
+		}
+		msg.ContentType = event.JSONContentType
+		msg.Body = body
+		msg.Headers = amqp.Table{
+			"schema_version": int32(evt.SchemaVersion),
+			"causation_id":   evt.CausationID,
+			"requested_by":   evt.RequestedBy,
+		}
+	}
+
+	return msg, nil
+}
+
+// decodeDelivery gelen mesajı formatına göre (native, CloudEvents structured veya
+// binary, gob) çözer. Gövde önce content-encoding'e göre açılır; content-type
+// belirtilmemişse JSON varsayılır. Açılmış gövde maxBodyBytes ile sınırlıdır (bkz. decompressBody).
+func decodeDelivery(msg amqp.Delivery, maxBodyBytes int) (event.BaseEvent, error) {
+	body, err := decompressBody(msg.Body, msg.ContentEncoding, maxBodyBytes)
+	if err != nil {
+		return event.BaseEvent{}, err
+	}
+
+	switch {
+	case isBinaryCloudEvent(msg.Headers):
+		return parseBinaryCloudEvent(msg.Headers, msg.ContentType, body)
+	case isGobContentType(msg.ContentType):
+		return decodeGobEvent(body, msg.MessageId, msg.CorrelationId)
+	default:
+		return event.ParseEventWithIDs(body, msg.MessageId, msg.CorrelationId)
+	}
+}
+
+// cloudEventHeaders CloudEvent özniteliklerini binary mode header'larına dönüştürür.
+// datacontenttype header olarak değil, AMQP content-type özelliği olarak taşınır.
+func cloudEventHeaders(evt event.BaseEvent) amqp.Table {
+	ce := event.ToCloudEvent(evt)
+	headers := amqp.Table{
+		cloudEventsHeaderPrefix + "specversion": ce.SpecVersion,
+		cloudEventsHeaderPrefix + "id":          ce.ID,
+		cloudEventsHeaderPrefix + "source":      ce.Source,
+		cloudEventsHeaderPrefix + "type":        ce.Type,
+	}
+	optional := map[string]string{
+		"time":          ce.Time,
+		"correlationid": ce.CorrelationID,
+		"causationid":   ce.CausationID,
+		"schemaversion": ce.SchemaVersion,
+		"requestedby":   ce.RequestedBy,
+	}
+	for name, value := range optional {
+		if value != "" {
+			headers[cloudEventsHeaderPrefix+name] = value
+		}
+	}
+	return headers
+}
+
+// isBinaryCloudEvent mesajın CloudEvents binary mode header'ları taşıyıp taşımadığını belirler
+func isBinaryCloudEvent(headers amqp.Table) bool {
+	return cloudEventHeader(headers, "specversion") != ""
+}
+
+// parseBinaryCloudEvent binary mode header'larını ve gövdeyi zarfımıza dönüştürür
+func parseBinaryCloudEvent(headers amqp.Table, contentType string, body []byte) (event.BaseEvent, error) {
+	ce := event.CloudEvent{
+		SpecVersion:     cloudEventHeader(headers, "specversion"),
+		ID:              cloudEventHeader(headers, "id"),
+		Source:          cloudEventHeader(headers, "source"),
+		Type:            cloudEventHeader(headers, "type"),
+		Time:            cloudEventHeader(headers, "time"),
+		DataContentType: contentType,
+		Data:            body,
+		CorrelationID:   cloudEventHeader(headers, "correlationid"),
+		CausationID:     cloudEventHeader(headers, "causationid"),
+		SchemaVersion:   cloudEventHeader(headers, "schemaversion"),
+		RequestedBy:     cloudEventHeader(headers, "requestedby"),
+	}
+
+	evt, err := ce.ToBaseEvent()
+	if err != nil {
+		return event.BaseEvent{}, fmt.Errorf("invalid binary CloudEvent: %w", err)
+	}
+	return evt, nil
+}
+
+// cloudEventHeader bir CloudEvents özniteliğini her iki önekle de arar ve metin olarak döndürür
+func cloudEventHeader(headers amqp.Table, name string) string {
+	for _, prefix := range []string{cloudEventsHeaderPrefix, cloudEventsAltHeaderPrefix} {
+		value, ok := headers[prefix+name]
+		if !ok {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			return v
+		case []byte:
+			return string(v)
+		case time.Time:
+			return v.UTC().Format(time.RFC3339)
+		default:
+			return fmt.Sprint(v)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,140 @@
+package rabbitmq
+
+import (
+	"testing"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/streadway/amqp"
+)
+
+func TestEncodeDecode_AllFormats(t *testing.T) {
+	evt, err := event.NewPortfolioReportEvent(event.CreateSamplePortfolios())
+	if err != nil {
+		t.Fatalf("NewPortfolioReportEvent error: %v", err)
+	}
+
+	for _, format := range []EventFormat{FormatNative, FormatCloudEventsStructured, FormatCloudEventsBinary} {
+		pub, err := encodeEvent(evt, format)
+		if err != nil {
+			t.Fatalf("encodeEvent(%s) error: %v", format, err)
+		}
+		delivery := amqp.Delivery{Headers: pub.Headers, ContentType: pub.ContentType, Body: pub.Body}
+
+		got, err := decodeDelivery(delivery, 0)
+		if err != nil {
+			t.Fatalf("decodeDelivery(%s) error: %v", format, err)
+		}
+		if got.EventID != evt.EventID || got.EventType != evt.EventType || got.CorrelationID != evt.CorrelationID {
+			t.Errorf("%s: envelope mismatch: got %+v", format, got)
+		}
+		var payload event.PortfolioReportPayload
+		if err := got.ParsePayload(&payload); err != nil || len(payload.Portfolios) != 3 {
+			t.Errorf("%s: payload not preserved: %v", format, err)
+		}
+	}
+}
+
+func TestEncodeEvent_ChunkRequiresNativeFormat(t *testing.T) {
+	evt, _ := event.NewPortfolioReportEvent(event.CreateSamplePortfolios())
+	parts, err := event.SplitEvent(evt, 64)
+	if err != nil || len(parts) < 2 {
+		t.Fatalf("SplitEvent = %d parts, %v", len(parts), err)
+	}
+	part := parts[0]
+
+	for _, format := range []EventFormat{FormatCloudEventsStructured, FormatCloudEventsBinary, FormatGob} {
+		if _, err := encodeEvent(part, format); err == nil {
+			t.Errorf("encodeEvent(chunk, %s) expected error, got nil", format)
+		}
+	}
+
+	pub, err := encodeEvent(part, FormatNative)
+	if err != nil {
+		t.Fatalf("encodeEvent(chunk, native) error: %v", err)
+	}
+	got, err := decodeDelivery(amqp.Delivery{ContentType: pub.ContentType, Body: pub.Body}, 0)
+	if err != nil || got.Chunk == nil || *got.Chunk != *part.Chunk {
+		t.Errorf("decodeDelivery(chunk) = %+v, %v; want chunk %+v", got.Chunk, err, part.Chunk)
+	}
+}
+
+func TestBinaryCloudEvent_HeadersAndBody(t *testing.T) {
+	evt, _ := event.NewPortfolioReportEvent(nil)
+	pub, _ := encodeEvent(evt, FormatCloudEventsBinary)
+
+	if pub.Headers["cloudEvents:type"] != "portfolio.report" || pub.Headers["cloudEvents:id"] != evt.EventID {
+		t.Errorf("Unexpected binary headers: %v", pub.Headers)
+	}
+	if pub.ContentType != "application/json" || string(pub.Body) != string(evt.Payload) {
+		t.Errorf("Binary body should be the bare payload, got %s (%s)", pub.Body, pub.ContentType)
+	}
+
+	// The JMS-style "cloudEvents_" prefix is accepted as well
+	headers := amqp.Table{
+		"cloudEvents_specversion": "1.0",
+		"cloudEvents_id":          "x1",
+		"cloudEvents_source":      "/other-team",
+		"cloudEvents_type":        "portfolio.report",
+	}
+	got, err := decodeDelivery(amqp.Delivery{Headers: headers, ContentType: "application/json", Body: []byte(`{"portfolios":[]}`)}, 0)
+	if err != nil || got.EventID != "x1" || got.Source != "/other-team" {
+		t.Errorf("decodeDelivery with alt prefix = %+v, %v", got, err)
+	}
+}
+
+func TestApplyEventFormats(t *testing.T) {
+	bindings := DefaultBindings()
+	if err := applyEventFormats(bindings, "portfolio.report=cloudevents-binary"); err != nil {
+		t.Fatalf("applyEventFormats error: %v", err)
+	}
+	client := &RabbitMQClient{Bindings: bindings}
+	if got, _ := client.formatFor("portfolio.report"); got != FormatCloudEventsBinary {
+		t.Errorf("formatFor = %s; want %s", got, FormatCloudEventsBinary)
+	}
+	if got, _ := client.formatFor("unbound.key"); got != FormatNative {
+		t.Errorf("formatFor unbound = %s; want %s", got, FormatNative)
+	}
+
+	if err := applyEventFormats(bindings, "portfolio.report=gob+gzip"); err != nil {
+		t.Fatalf("applyEventFormats error: %v", err)
+	}
+	if format, compression := client.formatFor("portfolio.report"); format != FormatGob || compression != GzipEncoding {
+		t.Errorf("formatFor = %s, %q; want gob, gzip", format, compression)
+	}
+
+	for _, spec := range []string{"portfolio.report=xml", "unknown.key=native", "no-equals"} {
+		if err := applyEventFormats(DefaultBindings(), spec); err == nil {
+			t.Errorf("applyEventFormats(%q) expected error", spec)
+		}
+	}
+}
+
+func TestDecodeDelivery_AMQPIdentityFallback(t *testing.T) {
+	props := func(body string) amqp.Delivery {
+		return amqp.Delivery{ContentType: "application/json", MessageId: "amqp-id", CorrelationId: "amqp-corr", Body: []byte(body)}
+	}
+
+	// Native envelopes without event_id take the IDs from the AMQP properties
+	got, err := decodeDelivery(props(`{"event_type":"portfolio.report","payload":{}}`), 0)
+	if err != nil || got.EventID != "amqp-id" || got.CorrelationID != "amqp-corr" {
+		t.Errorf("native without event_id = %+v, %v", got, err)
+	}
+
+	// Native envelopes with event_id keep their own IDs
+	got, err = decodeDelivery(props(`{"event_id":"e1","event_type":"portfolio.report","payload":{}}`), 0)
+	if err != nil || got.EventID != "e1" || got.CorrelationID != "e1" {
+		t.Errorf("native with event_id = %+v, %v", got, err)
+	}
+
+	// CloudEvents without a schemaversion extension keep the required id and their correlation
+	got, err = decodeDelivery(props(`{"specversion":"1.0","id":"ce1","source":"/s","type":"portfolio.report","correlationid":"c1","data":{}}`), 0)
+	if err != nil || got.EventID != "ce1" || got.CorrelationID != "c1" {
+		t.Errorf("structured CloudEvent = %+v, %v", got, err)
+	}
+	binary := props(`{}`)
+	binary.Headers = amqp.Table{"cloudEvents:specversion": "1.0", "cloudEvents:id": "ce2", "cloudEvents:source": "/s", "cloudEvents:type": "portfolio.report"}
+	got, err = decodeDelivery(binary, 0)
+	if err != nil || got.EventID != "ce2" || got.CorrelationID != "ce2" {
+		t.Errorf("binary CloudEvent = %+v, %v", got, err)
+	}
+}
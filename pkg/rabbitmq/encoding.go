@@ -0,0 +1,196 @@
+package rabbitmq
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+// Alternatif içerik tipi ve sıkıştırma
+const (
+	// GobContentType gob ile kodlanmış mesajların AMQP content-type değeri
+	GobContentType = "application/x-gob"
+	// GzipEncoding gzip ile sıkıştırılmış gövdelerin AMQP content-encoding değeri
+	GzipEncoding = "gzip"
+	// DefaultMaxDecompressedBytes sınır verilmediğinde açılmış gövdenin en fazla boyutu
+	DefaultMaxDecompressedBytes = 256 << 20
+)
+
+// ErrBodyTooLarge sıkıştırılmış gövde açıldığında izin verilen boyutu aştığında döner
+var ErrBodyTooLarge = errors.New("decompressed body too large")
+
+// gobEnvelope gob formatında zarfın tel (wire) gösterimi. Payload, event tipinin
+// kayıtlı payload yapısının gob kodlaması olarak taşınır; böylece alan adları
+// her kayıtta tekrarlanmaz.
+type gobEnvelope struct {
+	EventID       string
+	EventType     string
+	Timestamp     event.Timestamp
+	CorrelationID string
+	CausationID   string
+	Source        string
+	SchemaVersion int
+	RequestedBy   string
+	Payload       []byte
+}
+
+// parseFormatSpec "format" veya "format+gzip" biçimindeki ayarı çözer
+func parseFormatSpec(spec string) (EventFormat, string, error) {
+	spec = strings.TrimSpace(spec)
+	compression := ""
+	if base := strings.TrimSuffix(spec, "+"+GzipEncoding); base != spec {
+		spec, compression = base, GzipEncoding
+	}
+	format, err := ParseEventFormat(spec)
+	if err != nil {
+		return "", "", err
+	}
+	return format, compression, nil
+}
+
+// encodeGobEvent zarfı ve payload'ı gob ile kodlar
+func encodeGobEvent(evt event.BaseEvent) ([]byte, error) {
+	payloadType, ok := event.PayloadType(evt.EventType)
+	if !ok {
+		return nil, fmt.Errorf("cannot gob-encode %s: no payload type registered", evt.EventType)
+	}
+
+	// JSON payload'ı kayıtlı tipe çözüp gob ile yeniden kodla
+	payload := reflect.New(payloadType)
+	if err := json.Unmarshal(evt.Payload, payload.Interface()); err != nil {
+		return nil, fmt.Errorf("cannot gob-encode %s payload: %w", evt.EventType, err)
+	}
+	var payloadBuf bytes.Buffer
+	if err := gob.NewEncoder(&payloadBuf).EncodeValue(payload); err != nil {
+		return nil, fmt.Errorf("gob payload encoding error: %w", err)
+	}
+
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(gobEnvelope{
+		EventID:       evt.EventID,
+		EventType:     string(evt.EventType),
+		Timestamp:     evt.Timestamp,
+		CorrelationID: evt.CorrelationID,
+		CausationID:   evt.CausationID,
+		Source:        evt.Source,
+		SchemaVersion: evt.SchemaVersion,
+		RequestedBy:   evt.RequestedBy,
+		Payload:       payloadBuf.Bytes(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("gob envelope encoding error: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeGobEvent gob mesajını zarfa çözer. Handler'lar JSON payload beklediği için
+// payload kayıtlı tipe çözülüp JSON'a dönüştürülür. Zarfta kimlik yoksa verilen
+// eventID ve correlationID (AMQP özellikleri) kullanılır.
+func decodeGobEvent(body []byte, eventID, correlationID string) (event.BaseEvent, error) {
+	var env gobEnvelope
+	if err := gob.NewDecoder(bytes.NewReader(body)).Decode(&env); err != nil {
+		return event.BaseEvent{}, fmt.Errorf("invalid gob event: %w", err)
+	}
+
+	eventType := event.EventType(env.EventType)
+	payloadType, ok := event.PayloadType(eventType)
+	if !ok {
+		return event.BaseEvent{}, fmt.Errorf("cannot decode gob payload of %s: no payload type registered", eventType)
+	}
+	payload := reflect.New(payloadType)
+	if err := gob.NewDecoder(bytes.NewReader(env.Payload)).DecodeValue(payload); err != nil {
+		return event.BaseEvent{}, fmt.Errorf("invalid gob payload: %w", err)
+	}
+	payloadJSON, err := json.Marshal(payload.Interface())
+	if err != nil {
+		return event.BaseEvent{}, fmt.Errorf("payload serialization error: %w", err)
+	}
+
+	evt := event.BaseEvent{
+		EventID:       env.EventID,
+		EventType:     eventType,
+		Timestamp:     env.Timestamp,
+		CorrelationID: env.CorrelationID,
+		CausationID:   env.CausationID,
+		Source:        env.Source,
+		SchemaVersion: env.SchemaVersion,
+		RequestedBy:   env.RequestedBy,
+		Payload:       payloadJSON,
+	}
+	if evt.SchemaVersion == 0 {
+		evt.SchemaVersion = event.SchemaVersionV1
+	}
+	if evt.EventID == "" {
+		evt.EventID = eventID
+		if evt.CorrelationID == "" {
+			evt.CorrelationID = correlationID
+		}
+	}
+	if evt.CorrelationID == "" {
+		evt.CorrelationID = evt.EventID
+	}
+	return evt, nil
+}
+
+// isGobContentType içerik tipinin gob olup olmadığını döndürür (parametreler yok sayılır)
+func isGobContentType(contentType string) bool {
+	return strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0]) == GobContentType
+}
+
+// compressBody gövdeyi verilen content-encoding ile sıkıştırır
+func compressBody(body []byte, encoding string) ([]byte, error) {
+	switch encoding {
+	case "":
+		return body, nil
+	case GzipEncoding:
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(body); err != nil {
+			return nil, fmt.Errorf("gzip compression error: %w", err)
+		}
+		if err := zw.Close(); err != nil {
+			return nil, fmt.Errorf("gzip compression error: %w", err)
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+}
+
+// decompressBody gövdeyi AMQP content-encoding değerine göre açar. Açılmış gövde
+// maxBytes'ı aşarsa (küçük bir gzip bombası gibi) ErrBodyTooLarge döner; maxBytes <= 0
+// ise DefaultMaxDecompressedBytes kullanılır.
+func decompressBody(body []byte, encoding string, maxBytes int) ([]byte, error) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxDecompressedBytes
+	}
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "", "identity":
+		return body, nil
+	case GzipEncoding:
+		zr, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("invalid gzip body: %w", err)
+		}
+		defer zr.Close()
+		// Sınırı aşıp aşmadığını anlamak için bir byte fazlası okunur
+		data, err := io.ReadAll(io.LimitReader(zr, int64(maxBytes)+1))
+		if err != nil {
+			return nil, fmt.Errorf("invalid gzip body: %w", err)
+		}
+		if len(data) > maxBytes {
+			return nil, fmt.Errorf("%w: exceeds %d bytes", ErrBodyTooLarge, maxBytes)
+		}
+		return data, nil
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+}
@@ -0,0 +1,118 @@
+package rabbitmq
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/streadway/amqp"
+)
+
+// largePortfolioEvent n portföylük bir portfolio.report event'i oluşturur
+func largePortfolioEvent(t *testing.T, n int) event.BaseEvent {
+	portfolios := make([]event.Portfolio, n)
+	for i := range portfolios {
+		portfolios[i] = event.Portfolio{
+			PortID:     i + 1,
+			Name:       fmt.Sprintf("Portfolio %d", i+1),
+			UserID:     fmt.Sprintf("user%d", i%50),
+			CreatedAt:  event.NewTimestamp(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)),
+			LastUpdate: event.NewTimestamp(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)),
+		}
+	}
+	evt, err := event.NewPortfolioReportEvent(portfolios)
+	if err != nil {
+		t.Fatalf("NewPortfolioReportEvent error: %v", err)
+	}
+	return evt
+}
+
+func TestEncodeDecode_GobAndGzip(t *testing.T) {
+	evt := largePortfolioEvent(t, 500)
+	native, _ := encodeEvent(evt, FormatNative)
+
+	for _, spec := range []string{"gob", "gob+gzip", "native+gzip", "cloudevents-structured+gzip"} {
+		format, compression, err := parseFormatSpec(spec)
+		if err != nil {
+			t.Fatalf("parseFormatSpec(%q) error: %v", spec, err)
+		}
+		pub, err := encodeEvent(evt, format)
+		if err != nil {
+			t.Fatalf("%s: encodeEvent error: %v", spec, err)
+		}
+		body, err := compressBody(pub.Body, compression)
+		if err != nil {
+			t.Fatalf("%s: compressBody error: %v", spec, err)
+		}
+		if len(body) >= len(native.Body) {
+			t.Errorf("%s: %d bytes is not smaller than native JSON (%d bytes)", spec, len(body), len(native.Body))
+		}
+
+		delivery := amqp.Delivery{Headers: pub.Headers, ContentType: pub.ContentType, ContentEncoding: compression, Body: body}
+		got, err := decodeDelivery(delivery, 0)
+		if err != nil {
+			t.Fatalf("%s: decodeDelivery error: %v", spec, err)
+		}
+		if got.EventID != evt.EventID || got.SchemaVersion != evt.SchemaVersion || !got.Timestamp.Equal(evt.Timestamp.Time) {
+			t.Errorf("%s: envelope mismatch: %+v", spec, got)
+		}
+		var payload event.PortfolioReportPayload
+		if err := got.ParsePayload(&payload); err != nil || len(payload.Portfolios) != 500 || payload.Portfolios[499].Name != "Portfolio 500" {
+			t.Errorf("%s: payload not preserved: %v", spec, err)
+		}
+	}
+}
+
+func TestEncodeDecode_GobDecimals(t *testing.T) {
+	evt, err := event.NewHoldingsReportEvent(event.CurrencySettings{ReportingCurrency: "EUR"}, []event.PortfolioHoldings{{
+		PortID: 1, Name: "A", UserID: "u1",
+		Holdings: []event.Holding{{Instrument: "SAP", AssetClass: event.AssetEquity,
+			Quantity: event.MustParseDecimal("12.5"), Price: event.MustParseDecimal("178.0450")}},
+	}})
+	if err != nil {
+		t.Fatalf("NewHoldingsReportEvent error: %v", err)
+	}
+
+	pub, err := encodeEvent(evt, FormatGob)
+	if err != nil {
+		t.Fatalf("encodeEvent error: %v", err)
+	}
+	got, err := decodeDelivery(amqp.Delivery{Headers: pub.Headers, ContentType: pub.ContentType, Body: pub.Body}, 0)
+	if err != nil {
+		t.Fatalf("decodeDelivery error: %v", err)
+	}
+
+	var payload event.HoldingsReportPayload
+	if err := got.ParsePayload(&payload); err != nil {
+		t.Fatalf("ParsePayload error: %v", err)
+	}
+	// Decimals keep their exact value and scale through gob
+	if payload.ReportingCurrency != "EUR" || payload.Portfolios[0].Holdings[0].Price.String() != "178.0450" {
+		t.Errorf("Payload not preserved: %+v", payload)
+	}
+}
+
+func TestDecodeDelivery_EncodingErrors(t *testing.T) {
+	if _, err := decodeDelivery(amqp.Delivery{ContentEncoding: "br", Body: []byte("{}")}, 0); err == nil {
+		t.Error("Expected error for unsupported content encoding")
+	}
+	if _, err := decodeDelivery(amqp.Delivery{ContentEncoding: "gzip", Body: []byte("not gzip")}, 0); err == nil {
+		t.Error("Expected error for corrupt gzip body")
+	}
+	bomb, _ := compressBody(bytes.Repeat([]byte(" "), 4096), GzipEncoding)
+	if _, err := decodeDelivery(amqp.Delivery{ContentEncoding: "gzip", Body: bomb}, 1024); !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("Expected ErrBodyTooLarge for a body inflating past the limit, got %v", err)
+	}
+	if _, err := decodeDelivery(amqp.Delivery{ContentType: GobContentType, Body: []byte("not gob")}, 0); err == nil {
+		t.Error("Expected error for corrupt gob body")
+	}
+	if _, err := encodeEvent(event.BaseEvent{EventType: "unregistered.event"}, FormatGob); err == nil {
+		t.Error("Expected error gob-encoding an event type without a payload type")
+	}
+	if _, _, err := parseFormatSpec("gob+brotli"); err == nil {
+		t.Error("Expected error for unknown compression suffix")
+	}
+}
@@ -2,9 +2,9 @@ package rabbitmq
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/burakmike/report-export-service/pkg/config"
@@ -13,20 +13,98 @@ import (
 	"github.com/streadway/amqp"
 )
 
+// exchangeName tüm eventlerin yayınlandığı topic exchange
+const exchangeName = "investment_exchange"
+
+// Binding bir kuyruğu, bağlandığı routing key'leri ve bu routing key'lerle
+// yayınlanan mesajların formatını ve sıkıştırmasını tanımlar
+type Binding struct {
+	Queue       string
+	RoutingKeys []string
+	Format      EventFormat
+	Compression string // Yayınlanan gövdelerin content-encoding'i ("" veya "gzip")
+}
+
+// DefaultBindings servisin dinlediği kuyrukları ve bağlamaları döndürür
+func DefaultBindings() []Binding {
+	return []Binding{
+		{Queue: "portfolio_report_queue", RoutingKeys: []string{"portfolio.report"}, Format: FormatNative},
+		{Queue: "transaction_report_queue", RoutingKeys: []string{"transaction.report"}, Format: FormatNative},
+		{Queue: "holdings_report_queue", RoutingKeys: []string{"holdings.report"}, Format: FormatNative},
+		{Queue: "performance_report_queue", RoutingKeys: []string{"performance.report"}, Format: FormatNative},
+		{Queue: "realized_gains_report_queue", RoutingKeys: []string{"realized_gains.report"}, Format: FormatNative},
+		{Queue: "income_report_queue", RoutingKeys: []string{"income.report"}, Format: FormatNative},
+		{Queue: "portfolio_report_request_queue", RoutingKeys: []string{"portfolio.report.request"}, Format: FormatNative},
+		{Queue: "portfolio_group_report_queue", RoutingKeys: []string{"portfolio.group.report"}, Format: FormatNative},
+		// İleride yeni kuyruklar ve routing key'ler buraya eklenebilir
+	}
+}
+
 // RabbitMQClient RabbitMQ bağlantısını ve kanalını yöneten yapı
 type RabbitMQClient struct {
 	Connection *amqp.Connection
 	Channel    *amqp.Channel
 	Config     config.Config
 	Registry   *handler.HandlerRegistry
+	Bindings   []Binding
 }
 
 // NewRabbitMQClient yeni bir RabbitMQ client oluşturur
 func NewRabbitMQClient(cfg config.Config, registry *handler.HandlerRegistry) *RabbitMQClient {
+	bindings := DefaultBindings()
+	if err := applyEventFormats(bindings, cfg.EventFormats); err != nil {
+		log.Printf("Warning: ignoring invalid EVENT_FORMATS setting: %v", err)
+	}
+
 	return &RabbitMQClient{
 		Config:   cfg,
 		Registry: registry,
+		Bindings: bindings,
+	}
+}
+
+// applyEventFormats "routing.key=format[+gzip],..." biçimindeki ayarı bağlamalara uygular
+func applyEventFormats(bindings []Binding, spec string) error {
+	for _, entry := range strings.Split(spec, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid entry %q, expected routing.key=format", entry)
+		}
+		format, compression, err := parseFormatSpec(parts[1])
+		if err != nil {
+			return err
+		}
+		routingKey := strings.TrimSpace(parts[0])
+		found := false
+		for i := range bindings {
+			for _, key := range bindings[i].RoutingKeys {
+				if key == routingKey {
+					bindings[i].Format = format
+					bindings[i].Compression = compression
+					found = true
+				}
+			}
+		}
+		if !found {
+			return fmt.Errorf("no binding for routing key %q", routingKey)
+		}
 	}
+	return nil
+}
+
+// formatFor routing key'e bağlı bağlamanın mesaj formatını ve sıkıştırmasını döndürür
+func (r *RabbitMQClient) formatFor(routingKey string) (EventFormat, string) {
+	for _, b := range r.Bindings {
+		for _, key := range b.RoutingKeys {
+			if key == routingKey && b.Format != "" {
+				return b.Format, b.Compression
+			}
+		}
+	}
+	return FormatNative, ""
 }
 
 // Connect RabbitMQ'ya bağlanır
@@ -62,7 +140,7 @@ func (r *RabbitMQClient) Connect() error {
 func (r *RabbitMQClient) SetupExchangeAndQueues() error {
 	// Exchange oluştur
 	err := r.Channel.ExchangeDeclare(
-		"investment_exchange", // name
+		exchangeName,          // name
 		"topic",               // type
 		true,                  // durable
 		false,                 // auto-deleted
@@ -74,14 +152,9 @@ func (r *RabbitMQClient) SetupExchangeAndQueues() error {
 		return fmt.Errorf("failed to declare exchange: %w", err)
 	}
 
-	// Kuyruk tanımlamaları ve bağlamaları
-	queueBindings := map[string][]string{
-		"portfolio_report_queue": {"portfolio.report"},
-		// İleride yeni kuyruklar ve routing key'ler buraya eklenebilir
-	}
-
 	// Her bir kuyruğu oluştur ve bağla
-	for queueName, routingKeys := range queueBindings {
+	for _, binding := range r.Bindings {
+		queueName, routingKeys := binding.Queue, binding.RoutingKeys
 		// Kuyruk oluştur
 		queue, err := r.Channel.QueueDeclare(
 			queueName, // name
@@ -100,7 +173,7 @@ func (r *RabbitMQClient) SetupExchangeAndQueues() error {
 			err = r.Channel.QueueBind(
 				queue.Name,            // queue name
 				routingKey,            // routing key
-				"investment_exchange", // exchange
+				exchangeName,          // exchange
 				false,                 // no-wait
 				nil,                   // arguments
 			)
@@ -118,14 +191,9 @@ func (r *RabbitMQClient) SetupExchangeAndQueues() error {
 
 // ConsumeMessages mesajları dinlemeye başlar ve işler
 func (r *RabbitMQClient) ConsumeMessages(ctx context.Context) error {
-	// Tüm kuyrukları dinlemek için bir map
-	queueConsumers := []string{
-		"portfolio_report_queue",
-		// İleride yeni kuyruklar eklenebilir
-	}
-
 	// Her bir kuyruk için tüketici oluştur
-	for _, queueName := range queueConsumers {
+	for _, binding := range r.Bindings {
+		queueName := binding.Queue
 		msgs, err := r.Channel.Consume(
 			queueName, // queue
 			"",        // consumer
@@ -144,10 +212,11 @@ func (r *RabbitMQClient) ConsumeMessages(ctx context.Context) error {
 			log.Printf("Started consuming messages from queue: %s", qName)
 			
 			for msg := range deliveries {
-				// İşlem başarılı olmazsa mesajı tekrar kuyruğa koy
+				// İşlem başarılı olmazsa mesajı tekrar kuyruğa koy; kalıcı hatalar
+				// (bozuk veya geçersiz payload) tekrar denense de düzelmeyeceği için reddedilir
 				if err := r.processMessage(ctx, msg); err != nil {
 					log.Printf("Error processing message from queue %s: %v", qName, err)
-					msg.Nack(false, true) // mesajı tekrar kuyruğa koy
+					msg.Nack(false, !handler.IsPermanent(err))
 				} else {
 					msg.Ack(false) // başarılı işleme
 				}
@@ -161,43 +230,91 @@ func (r *RabbitMQClient) ConsumeMessages(ctx context.Context) error {
 
 // processMessage gelen mesajı uygun handler'a yönlendirir
 func (r *RabbitMQClient) processMessage(ctx context.Context, msg amqp.Delivery) error {
-	log.Printf("Received a message: %s", msg.Body)
+	switch {
+	case r.Config.StreamThresholdBytes > 0 && len(msg.Body) > r.Config.StreamThresholdBytes:
+		// Çok büyük gövdeler logu doldurmasın diye yalnızca boyut ve kimlik loglanır
+		log.Printf("Received a large message (%d bytes, message-id=%s)", len(msg.Body), msg.MessageId)
+	case msg.ContentEncoding != "" || isGobContentType(msg.ContentType):
+		log.Printf("Received a message (%d bytes, content-type=%s, content-encoding=%s)", len(msg.Body), msg.ContentType, msg.ContentEncoding)
+	default:
+		log.Printf("Received a message: %s", msg.Body)
+	}
 
-	// Mesajı BaseEvent yapısına dönüştür
-	baseEvent, err := event.ParseEvent(msg.Body)
+	// Mesajı BaseEvent yapısına dönüştür (native, CloudEvents structured veya binary)
+	baseEvent, err := decodeDelivery(msg, r.Config.MaxDecompressedBytes)
 	if err != nil {
-		return fmt.Errorf("failed to parse event: %w", err)
+		return handler.Permanent(fmt.Errorf("failed to parse event: %w", err))
+	}
+
+	log.Printf("Routing event %s (%s)", baseEvent.EventType, baseEvent.LogContext())
+
+	// Binary mode CloudEvents'te zarf header'lardadır; gövde tek başına mesajın tamamı değildir
+	if !isBinaryCloudEvent(msg.Headers) {
+		ctx = handler.WithRawMessage(ctx, handler.RawMessage{
+			Body:            msg.Body,
+			ContentType:     msg.ContentType,
+			ContentEncoding: msg.ContentEncoding,
+			EventType:       baseEvent.EventType,
+			Timestamp:       baseEvent.Timestamp,
+		})
 	}
 
 	// Uygun handler'ı bul ve mesajı işle
 	return r.Registry.HandleEvent(ctx, baseEvent)
 }
 
-// PublishEvent bir event'i RabbitMQ'ya gönderir
+// PublishEvent bir event'i routing key'in bağlamasında seçili formatta RabbitMQ'ya gönderir
 func (r *RabbitMQClient) PublishEvent(evt event.BaseEvent, routingKey string) error {
-	// Event'i JSON'a dönüştür
-	eventData, err := json.Marshal(evt)
+	// Event'i bağlamanın formatına dönüştür ve gerekirse sıkıştır
+	format, compression := r.formatFor(routingKey)
+	return r.publish(evt, routingKey, format, compression)
+}
+
+// PublishEventChunked payload'ı maxPayloadBytes'ı aşan bir event'i parçalara bölerek
+// yayınlar (bkz. event.SplitEvent). Parçaların payload'ı JSON metni olduğundan parçalar
+// her zaman native formatta gönderilir; bağlamanın sıkıştırması korunur.
+func (r *RabbitMQClient) PublishEventChunked(evt event.BaseEvent, routingKey string, maxPayloadBytes int) error {
+	parts, err := event.SplitEvent(evt, maxPayloadBytes)
 	if err != nil {
-		return fmt.Errorf("failed to marshal event: %w", err)
+		return err
+	}
+	if len(parts) == 1 {
+		return r.PublishEvent(evt, routingKey)
+	}
+
+	_, compression := r.formatFor(routingKey)
+	for _, part := range parts {
+		if err := r.publish(part, routingKey, FormatNative, compression); err != nil {
+			return fmt.Errorf("failed to publish chunk %d/%d: %w", part.Chunk.Seq, part.Chunk.Total, err)
+		}
+	}
+	return nil
+}
+
+// publish event'i verilen format ve sıkıştırmayla exchange'e gönderir
+func (r *RabbitMQClient) publish(evt event.BaseEvent, routingKey string, format EventFormat, compression string) error {
+	publishing, err := encodeEvent(evt, format)
+	if err != nil {
+		return err
+	}
+	if publishing.Body, err = compressBody(publishing.Body, compression); err != nil {
+		return err
 	}
+	publishing.ContentEncoding = compression
 
 	// Mesajı yayınla
 	err = r.Channel.Publish(
-		"investment_exchange", // exchange
+		exchangeName,          // exchange
 		routingKey,            // routing key
 		false,                 // mandatory
 		false,                 // immediate
-		amqp.Publishing{
-			ContentType: "application/json",
-			Body:        eventData,
-			Timestamp:   time.Now(),
-		},
+		publishing,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to publish event: %w", err)
 	}
 
-	log.Printf("Published event with type %s to exchange with routing key %s", evt.EventType, routingKey)
+	log.Printf("Published %s event with type %s to exchange with routing key %s (%s)", format, evt.EventType, routingKey, evt.LogContext())
 	return nil
 }
 
@@ -0,0 +1,147 @@
+package report
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// portfolioCSVRecord bir portföyü CSV satırına dönüştürür (sütunlar portfolioTableHeader ile aynı)
+func portfolioCSVRecord(portfolio event.Portfolio) []string {
+	return []string{
+		strconv.Itoa(portfolio.PortID),
+		portfolio.Name,
+		portfolio.UserID,
+		portfolio.CreatedAt.String(),
+		portfolio.LastUpdate.String(),
+	}
+}
+
+// SourceEvent raporu tetikleyen mesajın PDF'e gömülecek hali: ya alındığı haliyle
+// mesaj gövdesi ya da (gövde yoksa) çözülmüş event'in JSON'u
+type SourceEvent struct {
+	Data        []byte
+	Filename    string // ör. event.json, event.json.gz
+	Description string // ek tablosunda ve PDF'te gösterilen açıklama
+	EventType   event.EventType
+	Timestamp   event.Timestamp
+}
+
+// NormalizedSourceEvent çözülmüş ve güncel şemaya yükseltilmiş event'in JSON halini
+// oluşturur. Alınan mesaj ile aynı olmayabileceğinden böyle etiketlenir.
+func NormalizedSourceEvent(evt event.BaseEvent) (*SourceEvent, error) {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode source event: %w", err)
+	}
+	return &SourceEvent{
+		Data:        data,
+		Filename:    "event.json",
+		Description: fmt.Sprintf("Normalized event (schema v%d)", evt.SchemaVersion),
+		EventType:   evt.EventType,
+		Timestamp:   evt.Timestamp,
+	}, nil
+}
+
+// sourceAttachments PDF'e gömülecek kaynak veri eklerini oluşturur:
+// portföylerin CSV ve JSON hali ile (varsa) raporu tetikleyen mesaj
+func sourceAttachments(portfolios []event.Portfolio, source *SourceEvent) ([]gofpdf.Attachment, error) {
+	var csvBuf bytes.Buffer
+	w := csv.NewWriter(&csvBuf)
+	w.Write(portfolioTableHeader)
+	for _, p := range portfolios {
+		w.Write(portfolioCSVRecord(p))
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("failed to encode portfolios as CSV: %w", err)
+	}
+
+	jsonData, err := json.MarshalIndent(portfolios, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode portfolios as JSON: %w", err)
+	}
+
+	attachments := []gofpdf.Attachment{
+		{Filename: "portfolios.csv", Content: csvBuf.Bytes(), Description: "Portfolio data (CSV)"},
+		{Filename: "portfolios.json", Content: jsonData, Description: "Portfolio data (JSON)"},
+	}
+	if source != nil && len(source.Data) > 0 {
+		attachments = append(attachments, gofpdf.Attachment{
+			Filename: source.Filename, Content: source.Data, Description: source.Description,
+		})
+	}
+	return attachments, nil
+}
+
+// addProvenancePage raporun kaynağını ve gömülü ekleri listeleyen sayfayı ekler
+func (g *PDFGenerator) addProvenancePage(pdf *gofpdf.Fpdf, portfolios []event.Portfolio, source *SourceEvent, attachments []gofpdf.Attachment) {
+	pdf.AddPage()
+	g.addHeader(pdf, ReportOptions{
+		Title:    "Report Provenance",
+		Subtitle: "Source data embedded in this document",
+	})
+
+	// Genel bilgiler
+	info := [][2]string{
+		{"Generated", time.Now().Format("January 2, 2006 at 15:04:05")},
+		{"Portfolios", strconv.Itoa(len(portfolios))},
+	}
+	if source != nil {
+		info = append(info,
+			[2]string{"Source Event", string(source.EventType)},
+			[2]string{"Event Timestamp", source.Timestamp.String()},
+		)
+	}
+	for _, row := range info {
+		pdf.SetFont("Arial", "B", 10)
+		pdf.CellFormat(45, 7, row[0]+":", "", 0, "L", false, 0, "")
+		pdf.SetFont("Arial", "", 10)
+		pdf.CellFormat(0, 7, row[1], "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(5)
+
+	// Ek tablosu
+	colWidths := []float64{40, 60, 25, 152}
+	pdf.SetFont("Arial", "B", 10)
+	pdf.SetFillColor(66, 133, 244)
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetDrawColor(66, 133, 244)
+	for i, heading := range []string{"Attachment", "Description", "Size", "SHA-256"} {
+		pdf.CellFormat(colWidths[i], 8, heading, "1", 0, "C", true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetDrawColor(200, 200, 200)
+	for i, a := range attachments {
+		if i%2 == 0 {
+			pdf.SetFillColor(240, 240, 240)
+		} else {
+			pdf.SetFillColor(255, 255, 255)
+		}
+		sum := sha256.Sum256(a.Content)
+		pdf.SetFont("Arial", "", 9)
+		pdf.CellFormat(colWidths[0], 8, a.Filename, "1", 0, "L", true, 0, "")
+		pdf.CellFormat(colWidths[1], 8, a.Description, "1", 0, "L", true, 0, "")
+		pdf.CellFormat(colWidths[2], 8, fmt.Sprintf("%d B", len(a.Content)), "1", 0, "R", true, 0, "")
+		pdf.SetFont("Courier", "", 8)
+		pdf.CellFormat(colWidths[3], 8, hex.EncodeToString(sum[:]), "1", 0, "L", true, 0, "")
+		pdf.Ln(-1)
+	}
+
+	pdf.Ln(5)
+	pdf.SetFont("Arial", "I", 9)
+	pdf.SetTextColor(120, 120, 120)
+	pdf.MultiCell(0, 5, "The files above are embedded in this PDF as attachments. "+
+		"Open the attachments panel of your PDF reader to extract them.", "", "L", false)
+	pdf.SetTextColor(0, 0, 0)
+}
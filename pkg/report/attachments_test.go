@@ -0,0 +1,80 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+func TestSourceAttachments(t *testing.T) {
+	portfolios := event.CreateSamplePortfolios()
+	source := &SourceEvent{Data: []byte(`{"event_type":"portfolio.report"}`), Filename: "event.json", Description: "Original event message (JSON)"}
+	attachments, err := sourceAttachments(portfolios, source)
+	if err != nil {
+		t.Fatalf("sourceAttachments error: %v", err)
+	}
+	if len(attachments) != 3 {
+		t.Fatalf("Expected 3 attachments, got %d", len(attachments))
+	}
+
+	csvLines := strings.Split(strings.TrimSpace(string(attachments[0].Content)), "\n")
+	if attachments[0].Filename != "portfolios.csv" || len(csvLines) != len(portfolios)+1 {
+		t.Errorf("Unexpected CSV attachment %s with %d lines", attachments[0].Filename, len(csvLines))
+	}
+
+	var decoded []event.Portfolio
+	if err := json.Unmarshal(attachments[1].Content, &decoded); err != nil || len(decoded) != len(portfolios) {
+		t.Errorf("JSON attachment does not round-trip: %v", err)
+	}
+
+	if attachments[2].Filename != "event.json" || attachments[2].Description != source.Description {
+		t.Errorf("Unexpected source attachment %s (%s)", attachments[2].Filename, attachments[2].Description)
+	}
+
+	// Without a source event only the portfolio data is attached
+	attachments, _ = sourceAttachments(portfolios, nil)
+	if len(attachments) != 2 {
+		t.Errorf("Expected 2 attachments without source event, got %d", len(attachments))
+	}
+}
+
+func TestGeneratePortfolioReportWithSource_EmbedsAttachments(t *testing.T) {
+	gen, err := NewPDFGenerator(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewPDFGenerator error: %v", err)
+	}
+	gen.EmbedSourceData = true
+
+	evt, err := event.NewPortfolioReportEvent(event.CreateSamplePortfolios())
+	if err != nil {
+		t.Fatalf("NewPortfolioReportEvent error: %v", err)
+	}
+	source, err := NormalizedSourceEvent(evt)
+	if err != nil {
+		t.Fatalf("NormalizedSourceEvent error: %v", err)
+	}
+	if want := fmt.Sprintf("Normalized event (schema v%d)", event.CurrentSchemaVersion); source.Description != want {
+		t.Errorf("Unexpected description %q", source.Description)
+	}
+
+	filePath, err := gen.GeneratePortfolioReportWithSource(event.CreateSamplePortfolios(), source)
+	if err != nil {
+		t.Fatalf("GeneratePortfolioReportWithSource error: %v", err)
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("ReadFile error: %v", err)
+	}
+	if !bytes.Contains(data, []byte("/EmbeddedFiles")) {
+		t.Errorf("PDF has no /EmbeddedFiles name tree")
+	}
+	if n := bytes.Count(data, []byte("/Type /Filespec")); n != 3 {
+		t.Errorf("Expected 3 embedded files, got %d", n)
+	}
+}
@@ -0,0 +1,217 @@
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/burakmike/report-export-service/pkg/signing"
+)
+
+// DOCXGenerator düzenlenebilir Word (DOCX) raporları oluşturmak için kullanılan yapı.
+// OOXML paketi yalnızca standart kütüphane (archive/zip, encoding/xml) ile yazılır.
+type DOCXGenerator struct {
+	OutputDir string          // Raporların kaydedileceği dizin
+	Signer    *signing.Signer // Raporları imzalamak için (opsiyonel)
+}
+
+// docxColumnWidths tablo sütun genişlikleri (twip). PDF'teki 20/90/40/60/60 mm oranlarıyla aynıdır.
+var docxColumnWidths = []int{1134, 5103, 2268, 3402, 3402}
+
+// NewDOCXGenerator yeni bir DOCX generator oluşturur
+func NewDOCXGenerator(outputDir string) (*DOCXGenerator, error) {
+	if outputDir == "" {
+		outputDir = "reports"
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	return &DOCXGenerator{
+		OutputDir: outputDir,
+	}, nil
+}
+
+// GeneratePortfolioReport portföy verilerinden DOCX raporu oluşturur
+func (g *DOCXGenerator) GeneratePortfolioReport(portfolios []event.Portfolio) (string, error) {
+	filePath := filepath.Join(g.OutputDir, reportFileName("docx"))
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create DOCX file: %w", err)
+	}
+
+	if err := WritePortfolioDOCX(f, portfolios, defaultPortfolioReportOptions()); err != nil {
+		f.Close()
+		os.Remove(filePath)
+		return "", err
+	}
+
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("failed to save DOCX file: %w", err)
+	}
+
+	if err := signArtifact(g.Signer, filePath); err != nil {
+		return "", err
+	}
+
+	return filePath, nil
+}
+
+// WritePortfolioDOCX portföy raporunu DOCX paketi olarak w'ye yazar
+func WritePortfolioDOCX(w io.Writer, portfolios []event.Portfolio, options ReportOptions) error {
+	parts := []struct {
+		name    string
+		content []byte
+	}{
+		{"[Content_Types].xml", []byte(docxContentTypes)},
+		{"_rels/.rels", []byte(docxRootRels)},
+		{"word/_rels/document.xml.rels", []byte(docxDocumentRels)},
+		{"word/styles.xml", []byte(docxStyles)},
+		{"word/footer1.xml", []byte(docxFooter)},
+		{"word/document.xml", docxDocument(portfolios, options)},
+	}
+
+	zw := zip.NewWriter(w)
+	for _, part := range parts {
+		pw, err := zw.Create(part.name)
+		if err != nil {
+			return fmt.Errorf("failed to create DOCX part %s: %w", part.name, err)
+		}
+		if _, err := pw.Write(part.content); err != nil {
+			return fmt.Errorf("failed to write DOCX part %s: %w", part.name, err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize DOCX package: %w", err)
+	}
+	return nil
+}
+
+// docxDocument word/document.xml içeriğini oluşturur
+func docxDocument(portfolios []event.Portfolio, options ReportOptions) []byte {
+	var b bytes.Buffer
+
+	b.WriteString(xml.Header)
+	b.WriteString(`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><w:body>`)
+
+	// Başlık ve alt başlık
+	docxParagraph(&b, "Title", options.Title)
+	docxParagraph(&b, "Subtitle", options.Subtitle)
+
+	// Portföy tablosu
+	b.WriteString(`<w:tbl><w:tblPr><w:tblW w:w="0" w:type="auto"/><w:tblBorders>`)
+	for _, side := range []string{"top", "left", "bottom", "right", "insideH", "insideV"} {
+		fmt.Fprintf(&b, `<w:%s w:val="single" w:sz="4" w:space="0" w:color="C8C8C8"/>`, side)
+	}
+	b.WriteString(`</w:tblBorders></w:tblPr><w:tblGrid>`)
+	for _, width := range docxColumnWidths {
+		fmt.Fprintf(&b, `<w:gridCol w:w="%d"/>`, width)
+	}
+	b.WriteString(`</w:tblGrid>`)
+
+	// Başlık satırı - PDF'teki mavi zemin ve beyaz yazı
+	b.WriteString(`<w:tr><w:trPr><w:tblHeader/></w:trPr>`)
+	for i, heading := range portfolioTableHeader {
+		docxCell(&b, heading, docxColumnWidths[i], "4285F4", "center", true)
+	}
+	b.WriteString(`</w:tr>`)
+
+	// Alternatif satır renkleri
+	for i, p := range portfolios {
+		fill := "F0F0F0"
+		if i%2 == 1 {
+			fill = "FFFFFF"
+		}
+		b.WriteString(`<w:tr>`)
+		docxCell(&b, fmt.Sprintf("%d", p.PortID), docxColumnWidths[0], fill, "center", false)
+		docxCell(&b, p.Name, docxColumnWidths[1], fill, "left", false)
+		docxCell(&b, p.UserID, docxColumnWidths[2], fill, "center", false)
+		docxCell(&b, displayDate(p.CreatedAt), docxColumnWidths[3], fill, "center", false)
+		docxCell(&b, displayDate(p.LastUpdate), docxColumnWidths[4], fill, "center", false)
+		b.WriteString(`</w:tr>`)
+	}
+	b.WriteString(`</w:tbl>`)
+
+	// Toplam bilgisi ve alt bilgi
+	docxParagraph(&b, "Total", fmt.Sprintf("Total Portfolios: %d", len(portfolios)))
+	docxParagraph(&b, "Notice", generatedNotice())
+	docxParagraph(&b, "Notice", copyrightNotice)
+
+	// Yatay A4 sayfa ve sayfa numaralı alt bilgi
+	b.WriteString(`<w:sectPr><w:footerReference w:type="default" r:id="rIdFooter1"/>` +
+		`<w:pgSz w:w="16838" w:h="11906" w:orient="landscape"/>` +
+		`<w:pgMar w:top="567" w:right="567" w:bottom="850" w:left="567" w:header="567" w:footer="425" w:gutter="0"/>` +
+		`</w:sectPr></w:body></w:document>`)
+
+	return b.Bytes()
+}
+
+// docxParagraph verilen stil ile tek satırlık bir paragraf yazar
+func docxParagraph(b *bytes.Buffer, style, text string) {
+	fmt.Fprintf(b, `<w:p><w:pPr><w:pStyle w:val="%s"/></w:pPr><w:r><w:t xml:space="preserve">`, style)
+	xml.EscapeText(b, []byte(text))
+	b.WriteString(`</w:t></w:r></w:p>`)
+}
+
+// docxCell zemin rengi ve hizalaması verilmiş bir tablo hücresi yazar
+func docxCell(b *bytes.Buffer, text string, width int, fill, align string, header bool) {
+	fmt.Fprintf(b, `<w:tc><w:tcPr><w:tcW w:w="%d" w:type="dxa"/><w:shd w:val="clear" w:color="auto" w:fill="%s"/></w:tcPr>`, width, fill)
+	fmt.Fprintf(b, `<w:p><w:pPr><w:spacing w:before="40" w:after="40"/><w:jc w:val="%s"/></w:pPr><w:r>`, align)
+	if header {
+		b.WriteString(`<w:rPr><w:b/><w:color w:val="FFFFFF"/></w:rPr>`)
+	}
+	b.WriteString(`<w:t xml:space="preserve">`)
+	xml.EscapeText(b, []byte(text))
+	b.WriteString(`</w:t></w:r></w:p></w:tc>`)
+}
+
+const docxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>
+<Override PartName="/word/footer1.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.footer+xml"/>
+</Types>`
+
+const docxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+</Relationships>`
+
+const docxDocumentRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rIdStyles" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+<Relationship Id="rIdFooter1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/footer" Target="footer1.xml"/>
+</Relationships>`
+
+// docxStyles PDF'teki yazı tipi, boyut ve renkleri taklit eden paragraf stilleri
+const docxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:docDefaults><w:rPrDefault><w:rPr><w:rFonts w:ascii="Arial" w:hAnsi="Arial" w:cs="Arial"/><w:sz w:val="20"/></w:rPr></w:rPrDefault></w:docDefaults>
+<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/></w:style>
+<w:style w:type="paragraph" w:styleId="Title"><w:name w:val="Title"/><w:basedOn w:val="Normal"/><w:pPr><w:spacing w:after="60"/></w:pPr><w:rPr><w:b/><w:color w:val="003366"/><w:sz w:val="36"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Subtitle"><w:name w:val="Subtitle"/><w:basedOn w:val="Normal"/><w:pPr><w:pBdr><w:bottom w:val="single" w:sz="4" w:space="6" w:color="C8C8C8"/></w:pBdr><w:spacing w:after="300"/></w:pPr><w:rPr><w:i/><w:color w:val="787878"/><w:sz w:val="24"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Total"><w:name w:val="Total"/><w:basedOn w:val="Normal"/><w:pPr><w:spacing w:before="100" w:after="300"/><w:jc w:val="right"/></w:pPr><w:rPr><w:b/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Notice"><w:name w:val="Notice"/><w:basedOn w:val="Normal"/><w:pPr><w:spacing w:after="0"/></w:pPr><w:rPr><w:i/><w:color w:val="808080"/><w:sz w:val="16"/></w:rPr></w:style>
+</w:styles>`
+
+// docxFooter PDF'teki "Page X/Y" alt bilgisini Word alanlarıyla oluşturur
+const docxFooter = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:ftr xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:p><w:pPr><w:jc w:val="center"/></w:pPr>
+<w:r><w:rPr><w:i/><w:sz w:val="16"/></w:rPr><w:t xml:space="preserve">Page </w:t></w:r>
+<w:fldSimple w:instr=" PAGE "><w:r><w:rPr><w:i/><w:sz w:val="16"/></w:rPr><w:t>1</w:t></w:r></w:fldSimple>
+<w:r><w:rPr><w:i/><w:sz w:val="16"/></w:rPr><w:t>/</w:t></w:r>
+<w:fldSimple w:instr=" NUMPAGES "><w:r><w:rPr><w:i/><w:sz w:val="16"/></w:rPr><w:t>1</w:t></w:r></w:fldSimple>
+</w:p>
+</w:ftr>`
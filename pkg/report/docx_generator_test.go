@@ -0,0 +1,74 @@
+package report
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+func TestDOCXGenerator_GeneratePortfolioReport(t *testing.T) {
+	dir := t.TempDir()
+	gen, err := NewDOCXGenerator(dir)
+	if err != nil {
+		t.Fatalf("NewDOCXGenerator error: %v", err)
+	}
+
+	portfolios := []event.Portfolio{
+		{PortID: 1, Name: "Bonds & <Equities>", UserID: "user1", CreatedAt: event.MustParseTimestamp("2023-01-01 00:00:00"), LastUpdate: event.MustParseTimestamp("2023-01-02 00:00:00")},
+		{PortID: 2, Name: "Test2", UserID: "user2", CreatedAt: event.MustParseTimestamp("2023-02-01 00:00:00"), LastUpdate: event.MustParseTimestamp("2023-02-02 00:00:00")},
+	}
+
+	filePath, err := gen.GeneratePortfolioReport(portfolios)
+	if err != nil {
+		t.Fatalf("GeneratePortfolioReport error: %v", err)
+	}
+	if !strings.HasSuffix(filePath, ".docx") {
+		t.Errorf("Expected .docx extension, got %s", filePath)
+	}
+
+	zr, err := zip.OpenReader(filePath)
+	if err != nil {
+		t.Fatalf("DOCX is not a valid zip package: %v", err)
+	}
+	defer zr.Close()
+
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("Open part %s error: %v", f.Name, err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[f.Name] = string(data)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "word/document.xml", "word/styles.xml", "word/footer1.xml"} {
+		content, ok := parts[name]
+		if !ok {
+			t.Errorf("Missing DOCX part %s", name)
+			continue
+		}
+		// Every part must be well-formed XML
+		dec := xml.NewDecoder(strings.NewReader(content))
+		for {
+			if _, err := dec.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Errorf("Part %s is not well-formed XML: %v", name, err)
+				break
+			}
+		}
+	}
+
+	doc := parts["word/document.xml"]
+	for _, want := range []string{"Portfolio Report", "Bonds &amp; &lt;Equities&gt;", "Total Portfolios: 2", "Confidential Information"} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("document.xml does not contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package report
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/burakmike/report-export-service/pkg/analytics"
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// realizedLotsHeader lot detay tablosunun sütun başlıkları
+var realizedLotsHeader = []string{"Instrument", "Acquired", "Sold", "Quantity", "Proceeds", "Cost Basis", "Gain/Loss", "Term"}
+
+// realizedLotsColWidths lot detay tablosunun sütun genişlikleri (mm, toplam 277)
+var realizedLotsColWidths = []float64{40, 30, 30, 30, 40, 40, 40, 27}
+
+// gainsSummaryHeader yıllık özet tablosunun sütun başlıkları
+var gainsSummaryHeader = []string{"Year", "Proceeds", "Cost Basis", "Short-Term", "Long-Term", "Total"}
+
+// gainsSummaryColWidths yıllık özet tablosunun sütun genişlikleri (mm)
+var gainsSummaryColWidths = []float64{25, 45, 45, 45, 45, 45}
+
+// GenerateRealizedGainsReport gerçekleşen kazançlardan PDF raporu oluşturur. Her
+// portföy lot bazında detay tablosu ve yıllık kısa/uzun vade özetiyle ayrı bir bölümde yer alır.
+func (g *PDFGenerator) GenerateRealizedGainsReport(all []analytics.RealizedGains) (string, error) {
+	pdf := g.newDocument()
+	pdf.AddPage()
+
+	g.addHeader(pdf, ReportOptions{
+		Title:    "Realized Gains and Losses",
+		Subtitle: fmt.Sprintf("Generated on %s", time.Now().Format("January 2, 2006")),
+	})
+
+	for i, gains := range all {
+		if i > 0 {
+			pdf.AddPage()
+		}
+		g.addRealizedGains(pdf, gains)
+	}
+
+	g.addFooter(pdf)
+
+	filePath := filepath.Join(g.OutputDir, reportFileNameFor("realized_gains_report", "pdf"))
+	if err := g.savePDF(pdf, filePath); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+// addRealizedGains bir portföyün lot detaylarını ve yıllık özetini ekler
+func (g *PDFGenerator) addRealizedGains(pdf *gofpdf.Fpdf, gains analytics.RealizedGains) {
+	g.addSectionTitle(pdf, fmt.Sprintf("Portfolio %d - %s (%s)", gains.PortID, gains.Name, gains.UserID))
+	pdf.SetFont("Arial", "", 10)
+	pdf.CellFormat(0, 8, "Lot matching: "+lotMethodLabel(gains.Method), "", 1, "L", false, 0, "")
+	pdf.Ln(2)
+
+	g.addTableHeader(pdf, realizedLotsHeader, realizedLotsColWidths)
+	_, pageHeight := pdf.GetPageSize()
+	for i, lot := range gains.Lots {
+		// Sayfa sonuna gelindiğinde başlık satırını yeni sayfada tekrarla
+		if pdf.GetY() > pageHeight-30 {
+			pdf.AddPage()
+			g.addTableHeader(pdf, realizedLotsHeader, realizedLotsColWidths)
+		}
+		g.addRealizedLotRow(pdf, i, lot)
+	}
+	if len(gains.Lots) == 0 {
+		pdf.CellFormat(0, 8, "No realized sales", "1", 1, "C", false, 0, "")
+	}
+
+	pdf.Ln(6)
+	if pdf.GetY()+float64(len(gains.Years)+3)*8 > pageHeight-20 {
+		pdf.AddPage()
+	}
+	g.addSectionTitle(pdf, "Yearly Summary")
+	g.addTableHeader(pdf, gainsSummaryHeader, gainsSummaryColWidths)
+	for i, y := range gains.Years {
+		g.addYearSummaryRow(pdf, i, strconv.Itoa(y.Year), y)
+	}
+	total := gains.Total()
+	if len(gains.Years) > 1 {
+		pdf.SetFont("Arial", "B", 10)
+		g.addYearSummaryRow(pdf, len(gains.Years), "Total", total)
+		pdf.SetFont("Arial", "", 10)
+	}
+
+	g.addTotal(pdf, fmt.Sprintf("Short-term: %s   Long-term: %s   Net realized: %s",
+		formatMoney(total.ShortTerm), formatMoney(total.LongTerm), formatMoney(total.Total())))
+}
+
+// addRealizedLotRow lot detay tablosuna i. sıradaki satırı ekler
+func (g *PDFGenerator) addRealizedLotRow(pdf *gofpdf.Fpdf, i int, lot analytics.RealizedLot) {
+	setRowFill(pdf, i)
+
+	cells := []struct {
+		text  string
+		align string
+	}{
+		{lot.Instrument, "L"},
+		{lot.Acquired.Format(shortDateLayout), "C"},
+		{lot.Sold.Format(shortDateLayout), "C"},
+		{formatDecimal(lot.Quantity), "R"},
+		{formatMoney(lot.Proceeds), "R"},
+		{formatMoney(lot.CostBasis), "R"},
+		{formatMoney(lot.Gain), "R"},
+		{holdingTermLabel(lot.Term), "C"},
+	}
+	for j, c := range cells {
+		pdf.CellFormat(realizedLotsColWidths[j], 8, c.text, "1", 0, c.align, true, 0, "")
+	}
+	pdf.Ln(-1)
+}
+
+// addYearSummaryRow yıllık özet tablosuna bir satır ekler
+func (g *PDFGenerator) addYearSummaryRow(pdf *gofpdf.Fpdf, i int, label string, y analytics.YearSummary) {
+	setRowFill(pdf, i)
+	pdf.CellFormat(gainsSummaryColWidths[0], 8, label, "1", 0, "C", true, 0, "")
+	for j, v := range []event.Money{y.Proceeds, y.CostBasis, y.ShortTerm, y.LongTerm, y.Total()} {
+		pdf.CellFormat(gainsSummaryColWidths[j+1], 8, formatMoney(v), "1", 0, "R", true, 0, "")
+	}
+	pdf.Ln(-1)
+}
+
+// lotMethodLabel lot eşleştirme yönteminin raporlarda gösterilen adı
+func lotMethodLabel(m event.LotMethod) string {
+	switch m {
+	case event.LotFIFO:
+		return "FIFO (first in, first out)"
+	case event.LotAverageCost:
+		return "Average cost"
+	}
+	return string(m)
+}
+
+// holdingTermLabel elde tutma süresi sınıfının raporlarda gösterilen adı
+func holdingTermLabel(t analytics.HoldingTerm) string {
+	if t == analytics.LongTerm {
+		return "Long"
+	}
+	return "Short"
+}
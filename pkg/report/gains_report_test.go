@@ -0,0 +1,45 @@
+package report
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/burakmike/report-export-service/pkg/analytics"
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+func TestGenerateRealizedGainsReport(t *testing.T) {
+	gen, err := NewPDFGenerator(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewPDFGenerator error: %v", err)
+	}
+
+	usd := func(s string) event.Money { return event.NewMoney(event.MustParseDecimal(s), "USD") }
+	sold := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	lots := make([]analytics.RealizedLot, 40)
+	for i := range lots {
+		lots[i] = analytics.RealizedLot{Instrument: "AAPL", Acquired: sold.AddDate(-1, -i, 0), Sold: sold, Quantity: event.NewDecimalFromInt(1),
+			Proceeds: usd("150"), CostBasis: usd("100"), Gain: usd("50"), Term: analytics.LongTerm}
+	}
+	all := []analytics.RealizedGains{
+		{PortID: 1, Name: "A", UserID: "u1", Currency: "USD", Method: event.LotFIFO, Lots: lots,
+			Years: []analytics.YearSummary{
+				{Year: 2023, Proceeds: usd("0"), CostBasis: usd("0"), ShortTerm: usd("0"), LongTerm: usd("10")},
+				{Year: 2024, Proceeds: usd("6000"), CostBasis: usd("4000"), ShortTerm: usd("0"), LongTerm: usd("2000")},
+			}},
+		{PortID: 2, Name: "NoSales", UserID: "u2", Currency: "EUR", Method: event.LotAverageCost},
+	}
+
+	filePath, err := gen.GenerateRealizedGainsReport(all)
+	if err != nil {
+		t.Fatalf("GenerateRealizedGainsReport error: %v", err)
+	}
+	if !strings.Contains(filePath, "realized_gains_report_") {
+		t.Errorf("Unexpected file name %s", filePath)
+	}
+	if info, err := os.Stat(filePath); err != nil || info.Size() == 0 {
+		t.Errorf("PDF not written: %v", err)
+	}
+}
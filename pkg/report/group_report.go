@@ -0,0 +1,90 @@
+package report
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/burakmike/report-export-service/pkg/analytics"
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// groupSummaryHeader grup özeti tablosunun sütun başlıkları
+var groupSummaryHeader = []string{"User ID", "Portfolios", "New in Period", "Updated in Period"}
+
+// groupSummaryColWidths grup özeti tablosunun sütun genişlikleri (mm)
+var groupSummaryColWidths = []float64{90, 40, 45, 45}
+
+// groupReportTitle grup türüne göre rapor başlığını döndürür
+func groupReportTitle(kind event.GroupKind, name string) string {
+	if kind == event.GroupAdvisor {
+		return "Advisor Book Report - " + name
+	}
+	return "Household Report - " + name
+}
+
+// GenerateGroupReport bir hane ya da danışman portföyü için birleşik PDF raporu oluşturur.
+// İlk sayfada üye bazında özet ve grup toplamları, ardından her üye için yalnızca
+// kendi portföylerini içeren ayrı bir bölüm yer alır.
+func (g *PDFGenerator) GenerateGroupReport(kind event.GroupKind, name string, group analytics.GroupReport, period event.ResolvedPeriod) (string, error) {
+	pdf := g.newDocument()
+	pdf.AddPage()
+
+	options := portfolioReportOptions(period)
+	options.Title = groupReportTitle(kind, name)
+	g.addHeader(pdf, options)
+
+	g.addGroupSummary(pdf, group, period)
+	for _, member := range group.Members {
+		pdf.AddPage()
+		g.addSectionTitle(pdf, "User "+member.UserID)
+		if len(member.Portfolios) == 0 {
+			pdf.SetFont("Arial", "", 10)
+			pdf.CellFormat(0, 8, "No portfolios", "1", 1, "C", false, 0, "")
+			continue
+		}
+		g.addPortfolioTable(pdf, member.Portfolios, period)
+	}
+
+	g.addFooter(pdf)
+
+	filePath := filepath.Join(g.OutputDir, reportFileNameFor("group_report", "pdf"))
+	if err := g.savePDF(pdf, filePath); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+// addGroupSummary üye bazında özet tablosunu ve grup toplamlarını ekler. Dönem
+// sütunları yalnızca başlangıcı ya da bitişi olan dönemlerde doldurulur.
+func (g *PDFGenerator) addGroupSummary(pdf *gofpdf.Fpdf, group analytics.GroupReport, period event.ResolvedPeriod) {
+	g.addSectionTitle(pdf, "Group Summary")
+	g.addTableHeader(pdf, groupSummaryHeader, groupSummaryColWidths)
+
+	bounded := !period.Start.IsZero() || !period.End.IsZero()
+	periodCount := func(n int) string {
+		if !bounded {
+			return "-"
+		}
+		return strconv.Itoa(n)
+	}
+
+	pdf.SetFont("Arial", "", 10)
+	for i, member := range group.Members {
+		setRowFill(pdf, i)
+		pdf.CellFormat(groupSummaryColWidths[0], 8, member.UserID, "1", 0, "L", true, 0, "")
+		pdf.CellFormat(groupSummaryColWidths[1], 8, strconv.Itoa(member.Summary.Total), "1", 0, "C", true, 0, "")
+		pdf.CellFormat(groupSummaryColWidths[2], 8, periodCount(member.Summary.New), "1", 0, "C", true, 0, "")
+		pdf.CellFormat(groupSummaryColWidths[3], 8, periodCount(member.Summary.Updated), "1", 0, "C", true, 0, "")
+		pdf.Ln(-1)
+	}
+
+	g.addTotal(pdf, groupTotalLine(group, period))
+}
+
+// groupTotalLine grup toplamları satırını döndürür
+func groupTotalLine(group analytics.GroupReport, period event.ResolvedPeriod) string {
+	return fmt.Sprintf("Group Total: %d users - %s", len(group.Members),
+		portfolioTotalLine(group.Totals.Total, group.Totals, period))
+}
@@ -0,0 +1,41 @@
+package report
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/burakmike/report-export-service/pkg/analytics"
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+func TestGenerateGroupReport(t *testing.T) {
+	gen, err := NewPDFGenerator(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewPDFGenerator error: %v", err)
+	}
+	ts := event.MustParseTimestamp("2026-01-01")
+	portfolios := []event.Portfolio{
+		{PortID: 1, Name: "Tech", UserID: "u1", CreatedAt: ts, LastUpdate: ts},
+		{PortID: 2, Name: "Bonds", UserID: "u2", CreatedAt: ts, LastUpdate: ts},
+	}
+	group := analytics.BuildGroupReport([]string{"u1", "u2", "u3"}, portfolios, event.ResolvedPeriod{})
+
+	filePath, err := gen.GenerateGroupReport(event.GroupHousehold, "Smith Family", group, event.ResolvedPeriod{})
+	if err != nil {
+		t.Fatalf("GenerateGroupReport error: %v", err)
+	}
+	if !strings.Contains(filePath, "group_report_") {
+		t.Errorf("Unexpected file name %s", filePath)
+	}
+	if info, err := os.Stat(filePath); err != nil || info.Size() == 0 {
+		t.Fatalf("Expected non-empty report file %s: %v", filePath, err)
+	}
+
+	if got, want := groupTotalLine(group, event.ResolvedPeriod{}), "Group Total: 3 users - Total Portfolios: 2"; got != want {
+		t.Errorf("groupTotalLine = %q; want %q", got, want)
+	}
+	if got := groupReportTitle(event.GroupAdvisor, "Book A"); got != "Advisor Book Report - Book A" {
+		t.Errorf("groupReportTitle = %q", got)
+	}
+}
@@ -0,0 +1,226 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/burakmike/report-export-service/pkg/analytics"
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// holdingsTableHeader pozisyon tablosunun sütun başlıkları
+var holdingsTableHeader = []string{"Instrument", "Description", "Asset Class", "Quantity", "Price", "Local Value", "Market Value", "Weight"}
+
+// holdingsColWidths pozisyon tablosunun sütun genişlikleri (mm, toplam 277)
+var holdingsColWidths = []float64{30, 50, 30, 25, 37, 40, 40, 25}
+
+// holdingsSummaryHeader toplu görünümdeki portföy özet tablosunun sütun başlıkları
+var holdingsSummaryHeader = []string{"ID", "Portfolio Name", "User ID", "Market Value", "Weight"}
+
+// holdingsSummaryColWidths portföy özet tablosunun sütun genişlikleri (mm)
+var holdingsSummaryColWidths = []float64{20, 90, 40, 50, 30}
+
+// Pasta grafiği ölçüleri (mm)
+const (
+	pieRadius      = 30.0
+	pieChartHeight = 2*pieRadius + 20 // başlık ve boşluklar dahil
+	legendBoxSize  = 4.0
+)
+
+// assetClassColors varlık sınıflarının grafiklerdeki renkleri (RGB)
+var assetClassColors = map[event.AssetClass][3]int{
+	event.AssetEquity:      {66, 133, 244},
+	event.AssetFixedIncome: {52, 168, 83},
+	event.AssetCash:        {251, 188, 5},
+	event.AssetRealEstate:  {234, 67, 53},
+	event.AssetCommodity:   {154, 102, 54},
+	event.AssetAlternative: {142, 68, 173},
+}
+
+// GenerateHoldingsReport pozisyon dökümlerinden PDF raporu oluşturur. Her portföy
+// piyasa değerleri, ağırlıklar ve varlık dağılımı pasta grafiğiyle ayrı bir bölümde
+// yer alır; son bölüm tüm portföylerin toplu dağılımını gösterir.
+func (g *PDFGenerator) GenerateHoldingsReport(statements []analytics.HoldingsStatement) (string, error) {
+	pdf := g.newDocument()
+	pdf.AddPage()
+
+	g.addHeader(pdf, ReportOptions{
+		Title:    "Holdings Statement",
+		Subtitle: fmt.Sprintf("Generated on %s", time.Now().Format("January 2, 2006")),
+	})
+
+	for i, statement := range statements {
+		if i > 0 {
+			pdf.AddPage()
+		}
+		g.addHoldingsStatement(pdf, statement)
+	}
+
+	pdf.AddPage()
+	g.addAggregateAllocation(pdf, statements)
+
+	g.addFooter(pdf)
+
+	filePath := filepath.Join(g.OutputDir, reportFileNameFor("holdings_report", "pdf"))
+	if err := g.savePDF(pdf, filePath); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+// addHoldingsStatement bir portföyün pozisyon tablosunu ve varlık dağılımını ekler
+func (g *PDFGenerator) addHoldingsStatement(pdf *gofpdf.Fpdf, statement analytics.HoldingsStatement) {
+	g.addSectionTitle(pdf, fmt.Sprintf("Portfolio %d - %s (%s)", statement.PortID, statement.Name, statement.UserID))
+	if !statement.AsOf.IsZero() {
+		pdf.SetFont("Arial", "", 10)
+		pdf.CellFormat(0, 8, "Prices as of "+displayDate(statement.AsOf), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(2)
+
+	g.addTableHeader(pdf, holdingsTableHeader, holdingsColWidths)
+	_, pageHeight := pdf.GetPageSize()
+	for i, position := range statement.Positions {
+		// Sayfa sonuna gelindiğinde başlık satırını yeni sayfada tekrarla
+		if pdf.GetY() > pageHeight-30 {
+			pdf.AddPage()
+			g.addTableHeader(pdf, holdingsTableHeader, holdingsColWidths)
+		}
+		g.addPositionRow(pdf, i, position)
+	}
+	if len(statement.Positions) == 0 {
+		pdf.CellFormat(0, 8, "No holdings", "1", 1, "C", false, 0, "")
+	}
+
+	g.addTotal(pdf, "Total market value: "+formatMoney(statement.TotalValue))
+	g.addAllocationChart(pdf, "Asset Allocation", statement.Allocation)
+}
+
+// addPositionRow tabloya i. sıradaki pozisyon satırını ekler
+func (g *PDFGenerator) addPositionRow(pdf *gofpdf.Fpdf, i int, position analytics.Position) {
+	h := position.Holding
+	setRowFill(pdf, i)
+
+	cells := []struct {
+		text  string
+		align string
+	}{
+		{h.Instrument, "L"},
+		{h.Description, "L"},
+		{assetClassLabel(h.AssetClass), "L"},
+		{formatDecimal(h.Quantity), "R"},
+		{formatUnitPrice(h.Price, position.LocalValue.Currency), "R"},
+		{formatMoney(position.LocalValue), "R"},
+		{formatMoney(position.MarketValue), "R"},
+		{formatPercent(position.Weight), "R"},
+	}
+	for j, c := range cells {
+		pdf.CellFormat(holdingsColWidths[j], 8, c.text, "1", 0, c.align, true, 0, "")
+	}
+	pdf.Ln(-1)
+}
+
+// addAggregateAllocation tüm portföylerin değer özetini ve toplu varlık dağılımını ekler
+func (g *PDFGenerator) addAggregateAllocation(pdf *gofpdf.Fpdf, statements []analytics.HoldingsStatement) {
+	slices, total := analytics.AggregateAllocation(statements)
+
+	g.addSectionTitle(pdf, "All Portfolios")
+	g.addTableHeader(pdf, holdingsSummaryHeader, holdingsSummaryColWidths)
+	for i, s := range statements {
+		setRowFill(pdf, i)
+		pdf.CellFormat(holdingsSummaryColWidths[0], 8, strconv.Itoa(s.PortID), "1", 0, "C", true, 0, "")
+		pdf.CellFormat(holdingsSummaryColWidths[1], 8, s.Name, "1", 0, "L", true, 0, "")
+		pdf.CellFormat(holdingsSummaryColWidths[2], 8, s.UserID, "1", 0, "C", true, 0, "")
+		pdf.CellFormat(holdingsSummaryColWidths[3], 8, formatMoney(s.TotalValue), "1", 0, "R", true, 0, "")
+		pdf.CellFormat(holdingsSummaryColWidths[4], 8, formatPercent(analytics.Share(s.TotalValue.Amount.Float64(), total.Amount.Float64())), "1", 0, "R", true, 0, "")
+		pdf.Ln(-1)
+	}
+
+	g.addTotal(pdf, "Total market value: "+formatMoney(total))
+	g.addAllocationChart(pdf, "Aggregate Asset Allocation", slices)
+}
+
+// addAllocationChart varlık dağılımını pasta grafiği ve açıklama tablosuyla ekler.
+// Kalan sayfa alanı yetmiyorsa grafik yeni sayfaya taşınır.
+func (g *PDFGenerator) addAllocationChart(pdf *gofpdf.Fpdf, title string, slices []analytics.AllocationSlice) {
+	_, pageHeight := pdf.GetPageSize()
+	if pdf.GetY()+pieChartHeight > pageHeight-20 {
+		pdf.AddPage()
+	}
+
+	g.addSectionTitle(pdf, title)
+	if len(slices) == 0 {
+		pdf.SetFont("Arial", "I", 10)
+		pdf.CellFormat(0, 8, "No allocation data", "", 1, "L", false, 0, "")
+		return
+	}
+
+	left, _, _, _ := pdf.GetMargins()
+	top := pdf.GetY()
+	cx, cy := left+pieRadius+5, top+pieRadius+2
+	drawPieChart(pdf, cx, cy, pieRadius, slices)
+
+	// Açıklama: renk kutusu, sınıf adı, ağırlık ve piyasa değeri
+	pdf.SetFont("Arial", "", 10)
+	pdf.SetTextColor(0, 0, 0)
+	legendX := cx + pieRadius + 15
+	for i, slice := range slices {
+		y := top + 6 + float64(i)*8
+		setAssetClassFill(pdf, slice.AssetClass)
+		pdf.Rect(legendX, y+2, legendBoxSize, legendBoxSize, "F")
+		pdf.SetXY(legendX+legendBoxSize+3, y)
+		pdf.CellFormat(40, 8, assetClassLabel(slice.AssetClass), "", 0, "L", false, 0, "")
+		pdf.CellFormat(25, 8, formatPercent(slice.Weight), "", 0, "R", false, 0, "")
+		pdf.CellFormat(50, 8, formatMoney(slice.MarketValue), "", 0, "R", false, 0, "")
+	}
+
+	pdf.SetXY(left, top+2*pieRadius+8)
+}
+
+// drawPieChart (cx, cy) merkezli, r yarıçaplı bir pasta grafiği çizer. Dilimler
+// saat 12 yönünden başlayarak ağırlıklarıyla orantılı açılarla dizilir.
+func drawPieChart(pdf *gofpdf.Fpdf, cx, cy, r float64, slices []analytics.AllocationSlice) {
+	// Dilimler ince beyaz kenarlıkla ayrılır
+	pdf.SetDrawColor(255, 255, 255)
+	pdf.SetLineWidth(0.4)
+
+	start := 90.0
+	for _, slice := range slices {
+		sweep := slice.Weight * 360
+		if sweep <= 0 {
+			continue
+		}
+		setAssetClassFill(pdf, slice.AssetClass)
+		pdf.MoveTo(cx, cy)
+		pdf.ArcTo(cx, cy, r, r, 0, start, start+sweep)
+		pdf.ClosePath()
+		pdf.DrawPath("FD")
+		start += sweep
+	}
+
+	pdf.SetLineWidth(0.2)
+	pdf.SetDrawColor(200, 200, 200)
+}
+
+// setAssetClassFill dolgu rengini varlık sınıfının grafik rengine ayarlar
+func setAssetClassFill(pdf *gofpdf.Fpdf, class event.AssetClass) {
+	c, ok := assetClassColors[class]
+	if !ok {
+		c = [3]int{128, 128, 128}
+	}
+	pdf.SetFillColor(c[0], c[1], c[2])
+}
+
+// assetClassLabel varlık sınıfının raporlarda gösterilen adı, ör. "Fixed income"
+func assetClassLabel(c event.AssetClass) string {
+	return capitalize(strings.ReplaceAll(string(c), "_", " "))
+}
+
+// formatPercent 0-1 aralığındaki oranı tek ondalıklı yüzde olarak biçimlendirir, ör. 42.5%
+func formatPercent(w float64) string {
+	return strconv.FormatFloat(math.Round(w*1000)/10, 'f', 1, 64) + "%"
+}
@@ -0,0 +1,58 @@
+package report
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/burakmike/report-export-service/pkg/analytics"
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+func TestFormatPercent(t *testing.T) {
+	cases := map[float64]string{0: "0.0%", 0.425: "42.5%", 1: "100.0%", 0.33333: "33.3%"}
+	for in, want := range cases {
+		if got := formatPercent(in); got != want {
+			t.Errorf("formatPercent(%v) = %q; want %q", in, got, want)
+		}
+	}
+	if got := assetClassLabel(event.AssetFixedIncome); got != "Fixed income" {
+		t.Errorf("assetClassLabel = %q; want %q", got, "Fixed income")
+	}
+}
+
+func TestGenerateHoldingsReport(t *testing.T) {
+	gen, err := NewPDFGenerator(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewPDFGenerator error: %v", err)
+	}
+
+	// Enough positions to push the allocation chart onto a new page
+	holdings := make([]event.Holding, 25)
+	for i := range holdings {
+		holdings[i] = event.Holding{Instrument: "EQ", AssetClass: event.AssetClasses[i%len(event.AssetClasses)],
+			Quantity: event.NewDecimalFromInt(1), Price: event.NewDecimalFromInt(int64(i + 1))}
+	}
+	holdings[0].Currency = "JPY"
+	statements, err := analytics.BuildHoldingsStatements(event.HoldingsReportPayload{
+		CurrencySettings: event.CurrencySettings{FXRates: event.FXRates{{From: "USD", To: "JPY", Rate: event.NewDecimalFromInt(150)}}},
+		Portfolios: []event.PortfolioHoldings{
+			{PortID: 1, Name: "A", UserID: "u1", AsOf: event.MustParseTimestamp("2024-06-30"), Holdings: holdings},
+			{PortID: 2, Name: "Empty", UserID: "u2"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("BuildHoldingsStatements error: %v", err)
+	}
+
+	filePath, err := gen.GenerateHoldingsReport(statements)
+	if err != nil {
+		t.Fatalf("GenerateHoldingsReport error: %v", err)
+	}
+	if !strings.Contains(filePath, "holdings_report_") || !strings.HasSuffix(filePath, ".pdf") {
+		t.Errorf("Unexpected file name %s", filePath)
+	}
+	if info, err := os.Stat(filePath); err != nil || info.Size() == 0 {
+		t.Errorf("PDF not written: %v", err)
+	}
+}
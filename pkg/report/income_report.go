@@ -0,0 +1,217 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/burakmike/report-export-service/pkg/analytics"
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// incomeInstrumentHeader enstrüman bazında gelir tablosunun sütun başlıkları
+var incomeInstrumentHeader = []string{"Instrument", "Type", "Payments", "Gross", "Withholding Tax", "Net"}
+
+// incomeInstrumentColWidths enstrüman bazında gelir tablosunun sütun genişlikleri (mm)
+var incomeInstrumentColWidths = []float64{60, 35, 27, 50, 50, 55}
+
+// incomeMonthHeader aylık toplamlar tablosunun sütun başlıkları
+var incomeMonthHeader = []string{"Month", "Dividends", "Coupons", "Interest", "Gross", "Withholding Tax", "Net"}
+
+// incomeMonthColWidths aylık toplamlar tablosunun sütun genişlikleri (mm, toplam 277)
+var incomeMonthColWidths = []float64{37, 40, 40, 40, 40, 40, 40}
+
+// barChartHeight aylık gelir grafiğinin yüksekliği (mm)
+const barChartHeight = 70.0
+
+// monthLabelLayout grafik ve tablolarda ay gösterimi
+const monthLabelLayout = "Jan 2006"
+
+// incomeTypeColors gelir türlerinin grafiklerdeki renkleri (RGB)
+var incomeTypeColors = map[event.IncomeType][3]int{
+	event.IncomeDividend: {66, 133, 244},
+	event.IncomeCoupon:   {52, 168, 83},
+	event.IncomeInterest: {251, 188, 5},
+}
+
+// GenerateIncomeReport gelir özetlerinden PDF raporu oluşturur. Her portföy
+// enstrüman bazında gelir tablosu, aylık toplamlar ve aylık gelir grafiğiyle
+// ayrı bir bölümde yer alır.
+func (g *PDFGenerator) GenerateIncomeReport(statements []analytics.IncomeStatement) (string, error) {
+	pdf := g.newDocument()
+	pdf.AddPage()
+
+	subtitle := fmt.Sprintf("Generated on %s", time.Now().Format("January 2, 2006"))
+	if len(statements) > 0 {
+		subtitle = fmt.Sprintf("Period: %s - %s", statements[0].Period.Label(), subtitle)
+	}
+	g.addHeader(pdf, ReportOptions{Title: "Income Statement", Subtitle: subtitle})
+
+	for i, statement := range statements {
+		if i > 0 {
+			pdf.AddPage()
+		}
+		g.addIncomeStatement(pdf, statement)
+	}
+
+	g.addFooter(pdf)
+
+	filePath := filepath.Join(g.OutputDir, reportFileNameFor("income_report", "pdf"))
+	if err := g.savePDF(pdf, filePath); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+// addIncomeStatement bir portföyün gelir tablolarını ve aylık gelir grafiğini ekler
+func (g *PDFGenerator) addIncomeStatement(pdf *gofpdf.Fpdf, statement analytics.IncomeStatement) {
+	_, pageHeight := pdf.GetPageSize()
+	g.addSectionTitle(pdf, fmt.Sprintf("Portfolio %d - %s (%s)", statement.PortID, statement.Name, statement.UserID))
+	pdf.Ln(2)
+
+	g.addTableHeader(pdf, incomeInstrumentHeader, incomeInstrumentColWidths)
+	for i, line := range statement.ByInstrument {
+		if pdf.GetY() > pageHeight-30 {
+			pdf.AddPage()
+			g.addTableHeader(pdf, incomeInstrumentHeader, incomeInstrumentColWidths)
+		}
+		instrument := line.Instrument
+		if instrument == "" {
+			instrument = "-"
+		}
+		setRowFill(pdf, i)
+		pdf.CellFormat(incomeInstrumentColWidths[0], 8, instrument, "1", 0, "L", true, 0, "")
+		pdf.CellFormat(incomeInstrumentColWidths[1], 8, capitalize(string(line.Type)), "1", 0, "L", true, 0, "")
+		g.addIncomeTotalsCells(pdf, incomeInstrumentColWidths[2:], line.IncomeTotals, true)
+	}
+	if len(statement.ByInstrument) == 0 {
+		pdf.CellFormat(0, 8, "No income in this period", "1", 1, "C", false, 0, "")
+	} else {
+		pdf.SetFont("Arial", "B", 10)
+		setRowFill(pdf, len(statement.ByInstrument))
+		pdf.CellFormat(incomeInstrumentColWidths[0]+incomeInstrumentColWidths[1], 8, "Total", "1", 0, "L", true, 0, "")
+		g.addIncomeTotalsCells(pdf, incomeInstrumentColWidths[2:], statement.Total, true)
+		pdf.SetFont("Arial", "", 10)
+	}
+	pdf.Ln(6)
+
+	if pdf.GetY()+float64(len(statement.Months)+2)*8+10 > pageHeight-20 {
+		pdf.AddPage()
+	}
+	g.addSectionTitle(pdf, "Monthly Totals")
+	g.addTableHeader(pdf, incomeMonthHeader, incomeMonthColWidths)
+	for i, month := range statement.Months {
+		if pdf.GetY() > pageHeight-30 {
+			pdf.AddPage()
+			g.addTableHeader(pdf, incomeMonthHeader, incomeMonthColWidths)
+		}
+		setRowFill(pdf, i)
+		pdf.CellFormat(incomeMonthColWidths[0], 8, month.Month.Format(monthLabelLayout), "1", 0, "C", true, 0, "")
+		for j, t := range event.IncomeTypes {
+			pdf.CellFormat(incomeMonthColWidths[j+1], 8, formatMoney(event.NewMoney(month.ByType[t], statement.Currency)), "1", 0, "R", true, 0, "")
+		}
+		g.addIncomeTotalsCells(pdf, incomeMonthColWidths[4:], month.IncomeTotals, false)
+	}
+	pdf.Ln(6)
+
+	if pdf.GetY()+barChartHeight+15 > pageHeight-20 {
+		pdf.AddPage()
+	}
+	g.addSectionTitle(pdf, fmt.Sprintf("Income by Month (%s)", statement.Currency))
+	left, _, right, _ := pdf.GetMargins()
+	pageWidth, _ := pdf.GetPageSize()
+	top := pdf.GetY()
+	drawIncomeBarChart(pdf, left, top, pageWidth-left-right, barChartHeight, statement.Months, statement.Currency)
+	pdf.SetXY(left, top+barChartHeight+10)
+}
+
+// addIncomeTotalsCells satırın kalanına (isteğe bağlı ödeme sayısı,) brüt, stopaj ve net hücrelerini ekler
+func (g *PDFGenerator) addIncomeTotalsCells(pdf *gofpdf.Fpdf, widths []float64, t analytics.IncomeTotals, withCount bool) {
+	if withCount {
+		pdf.CellFormat(widths[0], 8, strconv.Itoa(t.Payments), "1", 0, "C", true, 0, "")
+		widths = widths[1:]
+	}
+	for j, v := range []event.Money{t.Gross, t.Withholding, t.Net} {
+		pdf.CellFormat(widths[j], 8, formatMoney(v), "1", 0, "R", true, 0, "")
+	}
+	pdf.Ln(-1)
+}
+
+// drawIncomeBarChart (x, y) sol üst köşeli w x h alana aylık brüt geliri, gelir
+// türlerine göre yığılmış sütunlar halinde çizer
+func drawIncomeBarChart(pdf *gofpdf.Fpdf, x, y, w, h float64, months []analytics.MonthIncome, currency event.Currency) {
+	plotX, plotW, plotH := x+lineChartAxisMargin, w-lineChartAxisMargin-45, h-10
+
+	maxV := 0.0
+	for _, m := range months {
+		maxV = math.Max(maxV, m.Gross.Amount.Float64())
+	}
+	if maxV == 0 {
+		maxV = 1
+	}
+	py := func(v float64) float64 { return y + plotH - plotH*v/maxV }
+
+	// Yatay kılavuz çizgileri ve değer etiketleri
+	pdf.SetFont("Arial", "", 8)
+	pdf.SetTextColor(100, 100, 100)
+	pdf.SetDrawColor(220, 220, 220)
+	pdf.SetLineWidth(0.1)
+	for i := 0; i <= lineChartGridLines; i++ {
+		v := maxV * float64(i) / lineChartGridLines
+		pdf.Line(plotX, py(v), plotX+plotW, py(v))
+		pdf.SetXY(x, py(v)-3)
+		pdf.CellFormat(lineChartAxisMargin-2, 6, formatChartAmount(v, currency), "", 0, "R", false, 0, "")
+	}
+
+	// Sütunlar: her ay için türlere göre alttan üste yığılır
+	slot := plotW / float64(max(len(months), 1))
+	barW := slot * 0.6
+	labelEvery := int(math.Ceil(float64(len(months)) / 12))
+	for i, m := range months {
+		barX := plotX + float64(i)*slot + (slot-barW)/2
+		base := 0.0
+		for _, t := range event.IncomeTypes {
+			v := m.ByType[t].Float64()
+			if v <= 0 {
+				continue
+			}
+			setIncomeTypeFill(pdf, t)
+			pdf.Rect(barX, py(base+v), barW, py(base)-py(base+v), "F")
+			base += v
+		}
+		if i%labelEvery == 0 {
+			pdf.SetXY(plotX+float64(i)*slot, y+plotH+2)
+			pdf.CellFormat(slot*float64(labelEvery), 6, m.Month.Format(monthLabelLayout), "", 0, "L", false, 0, "")
+		}
+	}
+
+	// Eksenler
+	pdf.SetDrawColor(120, 120, 120)
+	pdf.SetLineWidth(0.3)
+	pdf.Line(plotX, y, plotX, y+plotH)
+	pdf.Line(plotX, y+plotH, plotX+plotW, y+plotH)
+
+	// Açıklama
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont("Arial", "", 9)
+	legendX := plotX + plotW + 8
+	for i, t := range event.IncomeTypes {
+		ly := y + 4 + float64(i)*7
+		setIncomeTypeFill(pdf, t)
+		pdf.Rect(legendX, ly+1.5, legendBoxSize, legendBoxSize, "F")
+		pdf.SetXY(legendX+legendBoxSize+2, ly)
+		pdf.CellFormat(30, 7, capitalize(string(t)), "", 0, "L", false, 0, "")
+	}
+
+	pdf.SetLineWidth(0.2)
+	pdf.SetDrawColor(200, 200, 200)
+}
+
+// setIncomeTypeFill dolgu rengini gelir türünün grafik rengine ayarlar
+func setIncomeTypeFill(pdf *gofpdf.Fpdf, t event.IncomeType) {
+	c := incomeTypeColors[t]
+	pdf.SetFillColor(c[0], c[1], c[2])
+}
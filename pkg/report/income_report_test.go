@@ -0,0 +1,53 @@
+package report
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/burakmike/report-export-service/pkg/analytics"
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+func TestGenerateIncomeReport(t *testing.T) {
+	gen, err := NewPDFGenerator(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewPDFGenerator error: %v", err)
+	}
+
+	// Two years of monthly dividends plus a quarterly coupon
+	var payments []event.IncomePayment
+	for i := 0; i < 24; i++ {
+		date := event.MustParseTimestamp("2023-01-15")
+		date.Time = date.AddDate(0, i, 0)
+		payments = append(payments, event.IncomePayment{Date: date, Type: event.IncomeDividend, Instrument: "VTI", Gross: event.NewDecimalFromInt(int64(50 + i)), WithholdingTax: event.MustParseDecimal("7.5")})
+		if i%3 == 0 {
+			payments = append(payments, event.IncomePayment{Date: date, Type: event.IncomeCoupon, Instrument: "BND", Gross: event.MustParseDecimal("30")})
+		}
+	}
+	start, end := event.MustParseTimestamp("2023-01-01"), event.MustParseTimestamp("2024-12-31")
+	payload := event.IncomeReportPayload{
+		ReportPeriod: event.ReportPeriod{PeriodStart: &start, PeriodEnd: &end},
+		Portfolios: []event.PortfolioIncome{
+			{PortID: 1, Name: "A", UserID: "u1", Payments: payments},
+			{PortID: 2, Name: "NoIncome", UserID: "u2"},
+		},
+	}
+	period, err := payload.ReportPeriod.Resolve(time.Now())
+	if err != nil {
+		t.Fatalf("Resolve error: %v", err)
+	}
+	statements := analytics.BuildIncomeStatements(payload, period)
+
+	filePath, err := gen.GenerateIncomeReport(statements)
+	if err != nil {
+		t.Fatalf("GenerateIncomeReport error: %v", err)
+	}
+	if !strings.Contains(filePath, "income_report_") {
+		t.Errorf("Unexpected file name %s", filePath)
+	}
+	if info, err := os.Stat(filePath); err != nil || info.Size() == 0 {
+		t.Errorf("PDF not written: %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package report
+
+import "github.com/burakmike/report-export-service/pkg/event"
+
+// formatMoney tutarı para birimi koduyla, para biriminin ondalık basamak sayısında
+// ve binlik ayraçla biçimlendirir, ör. "USD -1,234.50", "JPY 1,235", "KWD 12.500"
+func formatMoney(m event.Money) string {
+	return string(m.Currency) + " " + formatDecimal(m.Amount.Round(m.Currency.MinorUnits(), event.RoundHalfUp))
+}
+
+// formatUnitPrice birim fiyatı en az para biriminin ondalık basamak sayısıyla,
+// daha hassas fiyatlarda tüm basamaklarıyla biçimlendirir, ör. "EUR 12.3456"
+func formatUnitPrice(price event.Decimal, currency event.Currency) string {
+	if units := currency.MinorUnits(); price.Scale() < units {
+		price = price.Round(units, event.RoundHalfUp)
+	}
+	return string(currency) + " " + formatDecimal(price)
+}
+
+// formatDecimal ondalık sayıyı ölçeğini koruyarak binlik ayraçla biçimlendirir
+func formatDecimal(d event.Decimal) string {
+	s := groupThousands(d.Abs().String())
+	if d.Sign() < 0 {
+		return "-" + s
+	}
+	return s
+}
+
+// formatChartAmount grafik eksenlerindeki kayan noktalı değeri para biriminin
+// ondalık basamak sayısında, para birimi kodu olmadan biçimlendirir
+func formatChartAmount(v float64, currency event.Currency) string {
+	return formatDecimal(event.DecimalFromFloat(v).Round(currency.MinorUnits(), event.RoundHalfUp))
+}
@@ -0,0 +1,39 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+func TestFormatMoney(t *testing.T) {
+	cases := []struct {
+		m    event.Money
+		want string
+	}{
+		{event.NewMoney(event.MustParseDecimal("-1234.5"), "USD"), "USD -1,234.50"},
+		{event.NewMoney(event.MustParseDecimal("1234567.5"), "JPY"), "JPY 1,234,568"},
+		{event.NewMoney(event.MustParseDecimal("12.5"), "KWD"), "KWD 12.500"},
+		{event.NewMoney(event.Decimal{}, "EUR"), "EUR 0.00"},
+		{event.NewMoney(event.MustParseDecimal("-0.001"), "USD"), "USD 0.00"},
+	}
+	for _, c := range cases {
+		if got := formatMoney(c.m); got != c.want {
+			t.Errorf("formatMoney(%s) = %q; want %q", c.m, got, c.want)
+		}
+	}
+
+	if got := formatUnitPrice(event.MustParseDecimal("1234.5678"), "EUR"); got != "EUR 1,234.5678" {
+		t.Errorf("formatUnitPrice = %q", got)
+	}
+	if got := formatUnitPrice(event.MustParseDecimal("12"), "USD"); got != "USD 12.00" {
+		t.Errorf("formatUnitPrice = %q", got)
+	}
+
+	if got := formatChartAmount(1234567.891, "USD"); got != "1,234,567.89" {
+		t.Errorf("formatChartAmount = %q", got)
+	}
+	if got := formatChartAmount(1234.5, "JPY"); got != "1,235" {
+		t.Errorf("formatChartAmount = %q", got)
+	}
+}
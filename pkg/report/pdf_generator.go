@@ -6,14 +6,21 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/burakmike/report-export-service/pkg/analytics"
 	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/burakmike/report-export-service/pkg/signing"
 	"github.com/jung-kurt/gofpdf"
 )
 
 // PDFGenerator PDF rapor oluşturmak için kullanılan yapı
 type PDFGenerator struct {
-	OutputDir  string // Raporların kaydedileceği dizin
-	ReportLogo string // Rapor logosu (opsiyonel)
+	OutputDir  string          // Raporların kaydedileceği dizin
+	ReportLogo string          // Rapor logosu (opsiyonel)
+	Signer     *signing.Signer // Raporları imzalamak için (opsiyonel)
+
+	// EmbedSourceData açıksa portföylerin CSV/JSON hali ve raporu tetikleyen mesaj
+	// PDF'e ek olarak gömülür ve kaynak (provenance) sayfasında listelenir
+	EmbedSourceData bool
 }
 
 // ReportOptions rapor oluşturma seçeneklerini belirtir
@@ -21,6 +28,74 @@ type ReportOptions struct {
 	Title    string
 	Subtitle string
 	Logo     string
+
+	// SourceEvent raporu tetikleyen mesaj (opsiyonel)
+	SourceEvent *SourceEvent
+
+	// Period raporun dönemi; sıfır değer dönemsiz rapordur
+	Period event.ResolvedPeriod
+
+	// QualityIssues gelen verideki veri kalitesi sorunları; varsa rapora ek olarak eklenir
+	QualityIssues []event.FieldError
+}
+
+// copyrightNotice tüm rapor formatlarının alt bilgisinde yer alan yasal uyarı
+const copyrightNotice = "© Portfolio Report Service - Confidential Information"
+
+// defaultPortfolioReportOptions portföy raporu için varsayılan başlık bilgilerini döndürür
+func defaultPortfolioReportOptions() ReportOptions {
+	return ReportOptions{
+		Title:    "Portfolio Report",
+		Subtitle: fmt.Sprintf("Generated on %s", time.Now().Format("January 2, 2006")),
+	}
+}
+
+// portfolioReportOptions dönem verilmişse dönemi alt başlığa ekleyen portföy raporu seçeneklerini döndürür
+func portfolioReportOptions(period event.ResolvedPeriod) ReportOptions {
+	options := defaultPortfolioReportOptions()
+	options.Period = period
+	if !period.IsZero() {
+		options.Subtitle = fmt.Sprintf("Period: %s - %s", period.Label(), options.Subtitle)
+	}
+	return options
+}
+
+// generatedNotice raporun oluşturulma zamanını belirten alt bilgi metnini döndürür
+func generatedNotice() string {
+	return fmt.Sprintf("This report was automatically generated on %s",
+		time.Now().Format("January 2, 2006 at 15:04:05"))
+}
+
+// dateDisplayLayout tablolarda tarihlerin gösterim biçimi
+const dateDisplayLayout = "2006-01-02 15:04:05"
+
+// displayDate bir tarihi tablolarda gösterilecek biçime dönüştürür; boş tarih için boş metin
+func displayDate(ts event.Timestamp) string {
+	if ts.IsZero() {
+		return ""
+	}
+	return ts.Format(dateDisplayLayout)
+}
+
+// reportFileName verilen uzantı için zaman damgalı portföy raporu dosya adını döndürür
+func reportFileName(ext string) string {
+	return reportFileNameFor("portfolio_report", ext)
+}
+
+// reportFileNameFor verilen rapor türü ve uzantı için zaman damgalı dosya adını döndürür
+func reportFileNameFor(kind, ext string) string {
+	return fmt.Sprintf("%s_%s.%s", kind, time.Now().Format("20060102_150405"), ext)
+}
+
+// signArtifact imzalayıcı yapılandırılmışsa dosyayı imzalar ve yanına .sig dosyası yazar
+func signArtifact(signer *signing.Signer, filePath string) error {
+	if signer == nil {
+		return nil
+	}
+	if _, err := signer.SignFile(filePath); err != nil {
+		return fmt.Errorf("failed to sign report: %w", err)
+	}
+	return nil
 }
 
 // NewPDFGenerator yeni bir PDF generator oluşturur
@@ -41,29 +116,33 @@ func NewPDFGenerator(outputDir string) (*PDFGenerator, error) {
 
 // GeneratePortfolioReport portföy verilerinden PDF raporu oluşturur
 func (g *PDFGenerator) GeneratePortfolioReport(portfolios []event.Portfolio) (string, error) {
-	// Varsayılan rapor seçenekleri
-	options := ReportOptions{
-		Title:    "Portfolio Report",
-		Subtitle: fmt.Sprintf("Generated on %s", time.Now().Format("January 2, 2006")),
-	}
+	return g.generateReport(portfolios, defaultPortfolioReportOptions())
+}
 
+// GeneratePortfolioReportWithSource raporu, tetikleyen mesajla birlikte oluşturur.
+// EmbedSourceData açıksa mesaj da PDF'e ek olarak gömülür.
+func (g *PDFGenerator) GeneratePortfolioReportWithSource(portfolios []event.Portfolio, sourceEvent *SourceEvent) (string, error) {
+	options := defaultPortfolioReportOptions()
+	options.SourceEvent = sourceEvent
+	return g.generateReport(portfolios, options)
+}
+
+// GeneratePortfolioPeriodReport dönem raporunu oluşturur: dönem başlıkta gösterilir ve
+// toplam satırında dönem içinde oluşturulan/güncellenen portföyler sayılır. Portföylerin
+// analytics.FilterPortfolios ile süzülmüş olması beklenir. Veri kalitesi sorunları
+// verilirse rapora ek olarak eklenir.
+func (g *PDFGenerator) GeneratePortfolioPeriodReport(portfolios []event.Portfolio, period event.ResolvedPeriod, issues []event.FieldError, sourceEvent *SourceEvent) (string, error) {
+	options := portfolioReportOptions(period)
+	options.QualityIssues = issues
+	options.SourceEvent = sourceEvent
 	return g.generateReport(portfolios, options)
 }
 
 // generateReport belirtilen seçeneklerle PDF raporu oluşturur
 func (g *PDFGenerator) generateReport(portfolios []event.Portfolio, options ReportOptions) (string, error) {
 	// PDF dosyasını oluştur - Yatay A4 kağıdı
-	pdf := gofpdf.New("L", "mm", "A4", "")
-	
-	// Sayfa numaralarını ekle
-	pdf.SetFooterFunc(func() {
-		pdf.SetY(-15)
-		pdf.SetFont("Arial", "I", 8)
-		pdf.CellFormat(0, 10, fmt.Sprintf("Page %d/{nb}", pdf.PageNo()),
-			"", 0, "C", false, 0, "")
-	})
-	pdf.AliasNbPages("")
-	
+	pdf := g.newDocument()
+
 	// Yeni sayfa ekle
 	pdf.AddPage()
 
@@ -71,24 +150,66 @@ func (g *PDFGenerator) generateReport(portfolios []event.Portfolio, options Repo
 	g.addHeader(pdf, options)
 	
 	// PDF içeriğini oluştur
-	g.addPortfolioTable(pdf, portfolios)
+	g.addPortfolioTable(pdf, portfolios, options.Period)
 	
 	// Alt bilgi - copyright ve diğer bilgiler
 	g.addFooter(pdf)
+
+	// Veri kalitesi sorunları eki
+	g.addQualityAppendix(pdf, options.QualityIssues)
+
+	// Kaynak verileri ek olarak göm ve kaynak sayfasında listele
+	if g.EmbedSourceData {
+		attachments, err := sourceAttachments(portfolios, options.SourceEvent)
+		if err != nil {
+			return "", err
+		}
+		pdf.SetAttachments(attachments)
+		g.addProvenancePage(pdf, portfolios, options.SourceEvent, attachments)
+	}
 	
 	// Dosya adını oluştur
-	fileName := fmt.Sprintf("portfolio_report_%s.pdf", time.Now().Format("20060102_150405"))
-	filePath := filepath.Join(g.OutputDir, fileName)
+	filePath := filepath.Join(g.OutputDir, reportFileName("pdf"))
 	
 	// PDF dosyasını kaydet
-	err := pdf.OutputFileAndClose(filePath)
-	if err != nil {
-		return "", fmt.Errorf("failed to save PDF file: %w", err)
+	if err := g.savePDF(pdf, filePath); err != nil {
+		return "", err
 	}
 	
 	return filePath, nil
 }
 
+// savePDF PDF'i diske yazar. İmzalayıcı varsa imza referansı PDF meta verisine
+// gömülür ve dosya kaydedildikten sonra ayrık imzası oluşturulur.
+func (g *PDFGenerator) savePDF(pdf *gofpdf.Fpdf, filePath string) error {
+	pdf.SetCreator("Portfolio Report Service", false)
+	if g.Signer != nil {
+		pdf.SetKeywords(g.Signer.Reference(filePath), false)
+	}
+
+	if err := pdf.OutputFileAndClose(filePath); err != nil {
+		return fmt.Errorf("failed to save PDF file: %w", err)
+	}
+
+	return signArtifact(g.Signer, filePath)
+}
+
+// newDocument sayfa numaralı, yatay A4 boş bir PDF belgesi oluşturur
+func (g *PDFGenerator) newDocument() *gofpdf.Fpdf {
+	pdf := gofpdf.New("L", "mm", "A4", "")
+	
+	// Sayfa numaralarını ekle
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont("Arial", "I", 8)
+		pdf.CellFormat(0, 10, fmt.Sprintf("Page %d/{nb}", pdf.PageNo()),
+			"", 0, "C", false, 0, "")
+	})
+	pdf.AliasNbPages("")
+
+	return pdf
+}
+
 // addHeader PDF'e başlık ekler
 func (g *PDFGenerator) addHeader(pdf *gofpdf.Fpdf, options ReportOptions) {
 	// Başlık için font ve renk ayarları
@@ -114,8 +235,41 @@ func (g *PDFGenerator) addHeader(pdf *gofpdf.Fpdf, options ReportOptions) {
 	pdf.SetTextColor(0, 0, 0) // Siyah
 }
 
+// portfolioColWidths portföy tablosunun sütun genişlikleri (mm)
+var portfolioColWidths = []float64{20, 90, 40, 60, 60}
+
 // addPortfolioTable PDF'e portföy tablosunu ekler
-func (g *PDFGenerator) addPortfolioTable(pdf *gofpdf.Fpdf, portfolios []event.Portfolio) {
+func (g *PDFGenerator) addPortfolioTable(pdf *gofpdf.Fpdf, portfolios []event.Portfolio, period event.ResolvedPeriod) {
+	g.addPortfolioTableHeader(pdf)
+	
+	// Her bir portfolyo satırını ekle
+	var summary analytics.PeriodSummary
+	for i, portfolio := range portfolios {
+		g.addPortfolioRow(pdf, i, portfolio)
+		summary.Add(portfolio, period)
+	}
+	
+	// Toplam bilgisi
+	g.addTotal(pdf, portfolioTotalLine(len(portfolios), summary, period))
+}
+
+// portfolioTotalLine toplam satırını döndürür; başlangıcı ya da bitişi olan dönemlerde
+// dönem içinde oluşturulan ve güncellenen portföyler de yazılır
+func portfolioTotalLine(count int, summary analytics.PeriodSummary, period event.ResolvedPeriod) string {
+	line := fmt.Sprintf("Total Portfolios: %d", count)
+	if period.Start.IsZero() && period.End.IsZero() {
+		return line
+	}
+	return fmt.Sprintf("%s - New in period: %d - Updated in period: %d", line, summary.New, summary.Updated)
+}
+
+// addPortfolioTableHeader portföy tablosunun başlık satırını ekler
+func (g *PDFGenerator) addPortfolioTableHeader(pdf *gofpdf.Fpdf) {
+	g.addTableHeader(pdf, portfolioTableHeader, portfolioColWidths)
+}
+
+// addTableHeader verilen başlık ve sütun genişlikleriyle bir tablo başlık satırı ekler
+func (g *PDFGenerator) addTableHeader(pdf *gofpdf.Fpdf, headings []string, widths []float64) {
 	// Tablo başlıkları için font ayarla
 	pdf.SetFont("Arial", "B", 11)
 	
@@ -124,13 +278,9 @@ func (g *PDFGenerator) addPortfolioTable(pdf *gofpdf.Fpdf, portfolios []event.Po
 	pdf.SetTextColor(255, 255, 255) // Beyaz
 	pdf.SetDrawColor(66, 133, 244) // Google mavi
 	
-	// Sütun genişlikleri
-	colWidths := []float64{20, 90, 40, 60, 60}
-	
 	// Tablo başlıklarını ekle
-	header := []string{"ID", "Portfolio Name", "User ID", "Created", "Last Updated"}
-	for i, heading := range header {
-		pdf.CellFormat(colWidths[i], 8, heading, "1", 0, "C", true, 0, "")
+	for i, heading := range headings {
+		pdf.CellFormat(widths[i], 8, heading, "1", 0, "C", true, 0, "")
 	}
 	pdf.Ln(-1)
 	
@@ -138,45 +288,43 @@ func (g *PDFGenerator) addPortfolioTable(pdf *gofpdf.Fpdf, portfolios []event.Po
 	pdf.SetFont("Arial", "", 10)
 	pdf.SetTextColor(0, 0, 0) // Siyah
 	
-	// İçerik için alternatif satır renkleri
-	evenRowColor := []int{240, 240, 240} // Açık gri
-	oddRowColor := []int{255, 255, 255}  // Beyaz
-	
 	// Tablo hücre sınır rengi
 	pdf.SetDrawColor(200, 200, 200) // Açık gri
-	
-	// Her bir portfolyo satırını ekle
-	for i, portfolio := range portfolios {
-		// Alternatif satır renkleri
-		if i%2 == 0 {
-			pdf.SetFillColor(evenRowColor[0], evenRowColor[1], evenRowColor[2])
-		} else {
-			pdf.SetFillColor(oddRowColor[0], oddRowColor[1], oddRowColor[2])
-		}
-		
-		// Portföy ID
-		pdf.CellFormat(colWidths[0], 8, fmt.Sprintf("%d", portfolio.PortID), "1", 0, "C", true, 0, "")
-		
-		// Portföy adı
-		pdf.CellFormat(colWidths[1], 8, portfolio.Name, "1", 0, "L", true, 0, "")
-		
-		// Kullanıcı ID
-		pdf.CellFormat(colWidths[2], 8, portfolio.UserID, "1", 0, "C", true, 0, "")
-		
-		// Oluşturulma tarihi
-		pdf.CellFormat(colWidths[3], 8, portfolio.CreatedAt, "1", 0, "C", true, 0, "")
-		
-		// Son güncelleme tarihi
-		pdf.CellFormat(colWidths[4], 8, portfolio.LastUpdate, "1", 0, "C", true, 0, "")
-		
-		pdf.Ln(-1)
+}
+
+// addPortfolioRow tabloya i. sıradaki portföy satırını ekler
+func (g *PDFGenerator) addPortfolioRow(pdf *gofpdf.Fpdf, i int, portfolio event.Portfolio) {
+	// Alternatif satır renkleri
+	if i%2 == 0 {
+		pdf.SetFillColor(240, 240, 240) // Açık gri
+	} else {
+		pdf.SetFillColor(255, 255, 255) // Beyaz
 	}
 	
-	// Toplam bilgisi
+	// Portföy ID
+	pdf.CellFormat(portfolioColWidths[0], 8, fmt.Sprintf("%d", portfolio.PortID), "1", 0, "C", true, 0, "")
+	
+	// Portföy adı
+	pdf.CellFormat(portfolioColWidths[1], 8, portfolio.Name, "1", 0, "L", true, 0, "")
+	
+	// Kullanıcı ID
+	pdf.CellFormat(portfolioColWidths[2], 8, portfolio.UserID, "1", 0, "C", true, 0, "")
+	
+	// Oluşturulma tarihi
+	pdf.CellFormat(portfolioColWidths[3], 8, displayDate(portfolio.CreatedAt), "1", 0, "C", true, 0, "")
+	
+	// Son güncelleme tarihi
+	pdf.CellFormat(portfolioColWidths[4], 8, displayDate(portfolio.LastUpdate), "1", 0, "C", true, 0, "")
+	
+	pdf.Ln(-1)
+}
+
+// addTotal tablonun altına sağa hizalı toplam satırı ekler
+func (g *PDFGenerator) addTotal(pdf *gofpdf.Fpdf, text string) {
 	pdf.Ln(5)
 	pdf.SetFont("Arial", "B", 10)
 	pdf.SetTextColor(0, 0, 0)
-	pdf.CellFormat(0, 8, fmt.Sprintf("Total Portfolios: %d", len(portfolios)), "", 0, "R", false, 0, "")
+	pdf.CellFormat(0, 8, text, "", 0, "R", false, 0, "")
 	pdf.Ln(15)
 }
 
@@ -187,11 +335,9 @@ func (g *PDFGenerator) addFooter(pdf *gofpdf.Fpdf) {
 	pdf.SetTextColor(128, 128, 128)
 	
 	// Oluşturulma bilgisi
-	generatedMsg := fmt.Sprintf("This report was automatically generated on %s", 
-		time.Now().Format("January 2, 2006 at 15:04:05"))
-	pdf.CellFormat(0, 10, generatedMsg, "", 0, "L", false, 0, "")
+	pdf.CellFormat(0, 10, generatedNotice(), "", 0, "L", false, 0, "")
 	pdf.Ln(5)
 	
 	// Yasal uyarı/copyright
-	pdf.CellFormat(0, 10, "© Portfolio Report Service - Confidential Information", "", 0, "L", false, 0, "")
+	pdf.CellFormat(0, 10, copyrightNotice, "", 0, "L", false, 0, "")
 } 
\ No newline at end of file
@@ -5,8 +5,11 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/burakmike/report-export-service/pkg/analytics"
 	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/burakmike/report-export-service/pkg/signing"
 )
 
 func TestGeneratePortfolioReport(t *testing.T) {
@@ -18,8 +21,8 @@ func TestGeneratePortfolioReport(t *testing.T) {
 	}
 
 	portfolios := []event.Portfolio{
-		{PortID: 1, Name: "Test1", UserID: "user1", CreatedAt: "2023-01-01 00:00:00", LastUpdate: "2023-01-02 00:00:00"},
-		{PortID: 2, Name: "Test2", UserID: "user2", CreatedAt: "2023-02-01 00:00:00", LastUpdate: "2023-02-02 00:00:00"},
+		{PortID: 1, Name: "Test1", UserID: "user1", CreatedAt: event.MustParseTimestamp("2023-01-01 00:00:00"), LastUpdate: event.MustParseTimestamp("2023-01-02 00:00:00")},
+		{PortID: 2, Name: "Test2", UserID: "user2", CreatedAt: event.MustParseTimestamp("2023-02-01 00:00:00"), LastUpdate: event.MustParseTimestamp("2023-02-02 00:00:00")},
 	}
 
 	filePath, err := gen.GeneratePortfolioReport(portfolios)
@@ -54,4 +57,113 @@ func TestGeneratePortfolioReport(t *testing.T) {
 	if info.Size() == 0 {
 		t.Errorf("Expected file size to be > 0, got 0")
 	}
-} 
\ No newline at end of file
+} 
+
+func TestGeneratePortfolioReport_Signed(t *testing.T) {
+	dir := t.TempDir()
+	privPath := filepath.Join(dir, "report.key")
+	pubPath := filepath.Join(dir, "report.pub")
+	if err := signing.GenerateKeyPair(privPath, pubPath); err != nil {
+		t.Fatalf("GenerateKeyPair error: %v", err)
+	}
+	signer, err := signing.NewSigner(privPath)
+	if err != nil {
+		t.Fatalf("NewSigner error: %v", err)
+	}
+
+	gen, err := NewPDFGenerator(filepath.Join(dir, "out"))
+	if err != nil {
+		t.Fatalf("NewPDFGenerator error: %v", err)
+	}
+	gen.Signer = signer
+
+	filePath, err := gen.GeneratePortfolioReport(event.CreateSamplePortfolios())
+	if err != nil {
+		t.Fatalf("GeneratePortfolioReport error: %v", err)
+	}
+
+	// The signature reference is embedded in the PDF metadata
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("ReadFile error: %v", err)
+	}
+	if !strings.Contains(string(data), filepath.Base(filePath)+".sig") {
+		t.Errorf("PDF metadata does not reference the signature file")
+	}
+
+	// The detached signature verifies with the public key
+	pub, err := signing.LoadPublicKey(pubPath)
+	if err != nil {
+		t.Fatalf("LoadPublicKey error: %v", err)
+	}
+	if _, err := signing.VerifyFile(filePath, signing.SignaturePath(filePath), pub); err != nil {
+		t.Errorf("VerifyFile error: %v", err)
+	}
+}
+
+func TestGeneratePortfolioPeriodReport(t *testing.T) {
+	gen, err := NewPDFGenerator(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewPDFGenerator error: %v", err)
+	}
+	portfolios := []event.Portfolio{
+		{PortID: 1, Name: "Test1", UserID: "user1", CreatedAt: event.MustParseTimestamp("2026-07-10"), LastUpdate: event.MustParseTimestamp("2026-07-11")},
+	}
+	period, err := event.ReportPeriod{Period: "Q3-2026"}.Resolve(time.Now())
+	if err != nil {
+		t.Fatalf("Resolve error: %v", err)
+	}
+
+	filePath, err := gen.GeneratePortfolioPeriodReport(portfolios, period, nil, nil)
+	if err != nil {
+		t.Fatalf("GeneratePortfolioPeriodReport error: %v", err)
+	}
+	if info, err := os.Stat(filePath); err != nil || info.Size() == 0 {
+		t.Fatalf("Expected non-empty report file %s: %v", filePath, err)
+	}
+
+	if got := portfolioReportOptions(period).Subtitle; !strings.HasPrefix(got, "Period: Q3-2026 (2026-07-01 to 2026-09-30)") {
+		t.Errorf("Subtitle = %q; want period label prefix", got)
+	}
+	summary := analytics.PeriodSummary{Total: 1, New: 1}
+	if got, want := portfolioTotalLine(1, summary, period), "Total Portfolios: 1 - New in period: 1 - Updated in period: 0"; got != want {
+		t.Errorf("portfolioTotalLine = %q; want %q", got, want)
+	}
+	if got, want := portfolioTotalLine(1, summary, event.ResolvedPeriod{}), "Total Portfolios: 1"; got != want {
+		t.Errorf("portfolioTotalLine without period = %q; want %q", got, want)
+	}
+}
+
+func TestGenerateReport_QualityAppendix(t *testing.T) {
+	gen, err := NewPDFGenerator(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewPDFGenerator error: %v", err)
+	}
+	portfolios := []event.Portfolio{
+		{PortID: 1, Name: "Test1", UserID: "user1", CreatedAt: event.MustParseTimestamp("2026-07-10"), LastUpdate: event.MustParseTimestamp("2026-07-11")},
+	}
+	issues := []event.FieldError{
+		{Path: "portfolios[1].portID", Rule: event.RuleUnique, Value: 1, Message: "duplicates portfolios[0].portID"},
+		{Path: "portfolios[1].name", Rule: event.RuleRequired, Value: " ", Message: strings.Repeat("very long message ", 20)},
+	}
+
+	filePath, err := gen.GeneratePortfolioPeriodReport(portfolios, event.ResolvedPeriod{}, issues, nil)
+	if err != nil {
+		t.Fatalf("GeneratePortfolioPeriodReport error: %v", err)
+	}
+	if info, err := os.Stat(filePath); err != nil || info.Size() == 0 {
+		t.Fatalf("Expected non-empty report file %s: %v", filePath, err)
+	}
+
+	pdf := gen.newDocument()
+	pdf.SetFont("Arial", "", 9)
+	if got := fitCell(pdf, issues[1].Message, 50); !strings.HasSuffix(got, "...") || pdf.GetStringWidth(got) > 48 {
+		t.Errorf("fitCell did not shorten long text: %q", got)
+	}
+	if got := fitCell(pdf, "short", 50); got != "short" {
+		t.Errorf("fitCell(short) = %q", got)
+	}
+	if got := qualityIssueValue(" "); got != `" "` {
+		t.Errorf("qualityIssueValue(blank) = %q", got)
+	}
+}
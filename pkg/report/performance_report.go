@@ -0,0 +1,222 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"time"
+
+	"github.com/burakmike/report-export-service/pkg/analytics"
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// returnsTableHeader getiri tablosunun sütun başlıkları
+var returnsTableHeader = []string{"Period", "From", "To", "Begin Value", "Net Flows", "End Value", "TWR", "MWR", "TWR p.a.", "MWR p.a."}
+
+// returnsColWidths getiri tablosunun sütun genişlikleri (mm, toplam 277)
+var returnsColWidths = []float64{35, 28, 28, 34, 32, 34, 22, 22, 21, 21}
+
+// Çizgi grafiği ölçüleri (mm)
+const (
+	lineChartHeight     = 75.0
+	lineChartAxisMargin = 28.0 // y ekseni etiketleri için sol boşluk
+	lineChartGridLines  = 5
+	lineChartMaxLabels  = 6
+)
+
+// notAvailable hesaplanamayan değerlerin tablolardaki gösterimi
+const notAvailable = "n/a"
+
+// shortDateLayout grafik eksenlerinde ve dönem sütunlarında kullanılan tarih biçimi
+const shortDateLayout = "2006-01-02"
+
+// GeneratePerformanceReport performans hesaplarından PDF raporu oluşturur. Her portföy
+// için standart dönemlerin TWR/MWR getirileri ve değer grafiği ayrı bir bölümde yer alır.
+func (g *PDFGenerator) GeneratePerformanceReport(performances []analytics.Performance) (string, error) {
+	pdf := g.newDocument()
+	pdf.AddPage()
+
+	g.addHeader(pdf, ReportOptions{
+		Title:    "Performance Report",
+		Subtitle: fmt.Sprintf("Generated on %s", time.Now().Format("January 2, 2006")),
+	})
+
+	for i, perf := range performances {
+		if i > 0 {
+			pdf.AddPage()
+		}
+		g.addPerformance(pdf, perf)
+	}
+
+	g.addFooter(pdf)
+
+	filePath := filepath.Join(g.OutputDir, reportFileNameFor("performance_report", "pdf"))
+	if err := g.savePDF(pdf, filePath); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+// addPerformance bir portföyün getiri tablosunu ve değer grafiğini ekler
+func (g *PDFGenerator) addPerformance(pdf *gofpdf.Fpdf, perf analytics.Performance) {
+	g.addSectionTitle(pdf, fmt.Sprintf("Portfolio %d - %s (%s)", perf.PortID, perf.Name, perf.UserID))
+	pdf.SetFont("Arial", "", 10)
+	pdf.CellFormat(0, 8, "Valuation as of "+perf.AsOf.Format(shortDateLayout), "", 1, "L", false, 0, "")
+	pdf.Ln(2)
+
+	g.addTableHeader(pdf, returnsTableHeader, returnsColWidths)
+	for i, r := range perf.Returns {
+		g.addReturnRow(pdf, i, r)
+	}
+	pdf.SetFont("Arial", "I", 8)
+	pdf.SetTextColor(120, 120, 120)
+	pdf.CellFormat(0, 8, "Returns are cumulative for the period; p.a. figures are shown for periods longer than one year.", "", 1, "L", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+	pdf.Ln(4)
+
+	_, pageHeight := pdf.GetPageSize()
+	if pdf.GetY()+lineChartHeight+15 > pageHeight-20 {
+		pdf.AddPage()
+	}
+	g.addSectionTitle(pdf, fmt.Sprintf("Value Over Time (%s)", perf.Currency))
+	left, _, right, _ := pdf.GetMargins()
+	pageWidth, _ := pdf.GetPageSize()
+	top := pdf.GetY()
+	drawLineChart(pdf, left, top, pageWidth-left-right, lineChartHeight, perf.Series, perf.Currency)
+	pdf.SetXY(left, top+lineChartHeight+10)
+}
+
+// addReturnRow getiri tablosuna i. sıradaki dönem satırını ekler
+func (g *PDFGenerator) addReturnRow(pdf *gofpdf.Fpdf, i int, r analytics.PeriodReturn) {
+	setRowFill(pdf, i)
+
+	twr, mwr := notAvailable, notAvailable
+	twrAnnual, mwrAnnual := notAvailable, notAvailable
+	if r.Available {
+		twr = formatReturn(r.TWR)
+		if v, ok := r.Annualize(r.TWR); ok {
+			twrAnnual = formatReturn(v)
+		}
+	}
+	if r.MWRAvailable {
+		mwr = formatReturn(r.MWR)
+		if v, ok := r.Annualize(r.MWR); ok {
+			mwrAnnual = formatReturn(v)
+		}
+	}
+
+	cells := []struct {
+		text  string
+		align string
+	}{
+		{r.Period.Label(), "L"},
+		{r.From.Format(shortDateLayout), "C"},
+		{r.To.Format(shortDateLayout), "C"},
+		{formatMoney(r.BeginValue), "R"},
+		{formatMoney(r.NetFlows), "R"},
+		{formatMoney(r.EndValue), "R"},
+		{twr, "R"},
+		{mwr, "R"},
+		{twrAnnual, "R"},
+		{mwrAnnual, "R"},
+	}
+	for j, c := range cells {
+		pdf.CellFormat(returnsColWidths[j], 8, c.text, "1", 0, c.align, true, 0, "")
+	}
+	pdf.Ln(-1)
+}
+
+// drawLineChart (x, y) sol üst köşeli w x h alana değer serisinin çizgi grafiğini
+// çizer. Yatay eksen tarihle orantılıdır; dikey eksen değer aralığına göre ölçeklenir.
+func drawLineChart(pdf *gofpdf.Fpdf, x, y, w, h float64, series []event.Valuation, currency event.Currency) {
+	if len(series) == 0 {
+		pdf.SetFont("Arial", "I", 10)
+		pdf.CellFormat(0, 8, "No valuations", "", 1, "L", false, 0, "")
+		return
+	}
+
+	plotX, plotW, plotH := x+lineChartAxisMargin, w-lineChartAxisMargin, h-10
+	minV, maxV := series[0].Value.Float64(), series[0].Value.Float64()
+	for _, v := range series {
+		minV = math.Min(minV, v.Value.Float64())
+		maxV = math.Max(maxV, v.Value.Float64())
+	}
+	if maxV == minV {
+		// Düz seride çizgiyi ortalamak için aralığı genişlet
+		pad := math.Max(math.Abs(maxV)*0.1, 1)
+		minV, maxV = minV-pad, maxV+pad
+	}
+
+	first, last := series[0].Date.Time, series[len(series)-1].Date.Time
+	span := last.Sub(first).Seconds()
+	px := func(t time.Time) float64 {
+		if span == 0 {
+			return plotX + plotW/2
+		}
+		return plotX + plotW*t.Sub(first).Seconds()/span
+	}
+	py := func(v float64) float64 {
+		return y + plotH - plotH*(v-minV)/(maxV-minV)
+	}
+
+	// Yatay kılavuz çizgileri ve değer etiketleri
+	pdf.SetFont("Arial", "", 8)
+	pdf.SetTextColor(100, 100, 100)
+	pdf.SetDrawColor(220, 220, 220)
+	pdf.SetLineWidth(0.1)
+	for i := 0; i <= lineChartGridLines; i++ {
+		v := minV + (maxV-minV)*float64(i)/lineChartGridLines
+		gy := py(v)
+		pdf.Line(plotX, gy, plotX+plotW, gy)
+		pdf.SetXY(x, gy-3)
+		pdf.CellFormat(lineChartAxisMargin-2, 6, formatChartAmount(v, currency), "", 0, "R", false, 0, "")
+	}
+
+	// Tarih etiketleri: seriye eşit aralıklarla dağıtılmış en fazla lineChartMaxLabels nokta
+	step := 1
+	if len(series) > lineChartMaxLabels {
+		step = int(math.Ceil(float64(len(series)-1) / float64(lineChartMaxLabels-1)))
+	}
+	for i := 0; i < len(series); i += step {
+		labelX := px(series[i].Date.Time)
+		pdf.SetXY(labelX-12, y+plotH+2)
+		pdf.CellFormat(24, 6, series[i].Date.Format(shortDateLayout), "", 0, "C", false, 0, "")
+	}
+	if (len(series)-1)%step != 0 {
+		pdf.SetXY(px(last)-12, y+plotH+2)
+		pdf.CellFormat(24, 6, last.Format(shortDateLayout), "", 0, "C", false, 0, "")
+	}
+
+	// Eksenler
+	pdf.SetDrawColor(120, 120, 120)
+	pdf.SetLineWidth(0.3)
+	pdf.Line(plotX, y, plotX, y+plotH)
+	pdf.Line(plotX, y+plotH, plotX+plotW, y+plotH)
+
+	// Değer çizgisi ve noktalar
+	pdf.SetDrawColor(66, 133, 244)
+	pdf.SetFillColor(66, 133, 244)
+	pdf.SetLineWidth(0.6)
+	for i := 1; i < len(series); i++ {
+		pdf.Line(px(series[i-1].Date.Time), py(series[i-1].Value.Float64()), px(series[i].Date.Time), py(series[i].Value.Float64()))
+	}
+	if len(series) <= 60 {
+		for _, v := range series {
+			pdf.Circle(px(v.Date.Time), py(v.Value.Float64()), 0.8, "F")
+		}
+	}
+
+	pdf.SetLineWidth(0.2)
+	pdf.SetDrawColor(200, 200, 200)
+	pdf.SetTextColor(0, 0, 0)
+}
+
+// formatReturn getiriyi iki ondalıklı, işaretli yüzde olarak biçimlendirir, ör. +4.25%
+func formatReturn(r float64) string {
+	pct := math.Round(r*10000) / 100
+	if pct == 0 {
+		pct = 0 // -0.00% yerine +0.00%
+	}
+	return fmt.Sprintf("%+.2f%%", pct)
+}
@@ -0,0 +1,52 @@
+package report
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/burakmike/report-export-service/pkg/analytics"
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+func TestFormatReturn(t *testing.T) {
+	cases := map[float64]string{0.0425: "+4.25%", -0.1: "-10.00%", -0.00001: "+0.00%"}
+	for in, want := range cases {
+		if got := formatReturn(in); got != want {
+			t.Errorf("formatReturn(%v) = %q; want %q", in, got, want)
+		}
+	}
+}
+
+func TestGeneratePerformanceReport(t *testing.T) {
+	gen, err := NewPDFGenerator(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewPDFGenerator error: %v", err)
+	}
+
+	flat := []event.Valuation{
+		{Date: event.MustParseTimestamp("2024-01-31"), Value: event.MustParseDecimal("100")},
+		{Date: event.MustParseTimestamp("2024-02-29"), Value: event.MustParseDecimal("100")},
+	}
+	growing := make([]event.Valuation, 40)
+	for i := range growing {
+		growing[i] = event.Valuation{Date: event.MustParseTimestamp("2021-01-01"), Value: event.NewDecimalFromInt(int64(1000 + i*25))}
+		growing[i].Date.Time = growing[i].Date.AddDate(0, i, 0)
+	}
+	performances := analytics.BuildPerformances(event.PerformanceReportPayload{Portfolios: []event.PortfolioPerformance{
+		{PortID: 1, Name: "Flat", UserID: "u1", Valuations: flat},
+		{PortID: 2, Name: "Growing", UserID: "u2", Valuations: growing,
+			CashFlows: []event.CashFlow{{Date: event.MustParseTimestamp("2022-06-15"), Amount: event.MustParseDecimal("-50")}}},
+	}})
+
+	filePath, err := gen.GeneratePerformanceReport(performances)
+	if err != nil {
+		t.Fatalf("GeneratePerformanceReport error: %v", err)
+	}
+	if !strings.Contains(filePath, "performance_report_") || !strings.HasSuffix(filePath, ".pdf") {
+		t.Errorf("Unexpected file name %s", filePath)
+	}
+	if info, err := os.Stat(filePath); err != nil || info.Size() == 0 {
+		t.Errorf("PDF not written: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// qualityIssueHeader veri kalitesi ekindeki tablonun sütun başlıkları
+var qualityIssueHeader = []string{"Field", "Rule", "Value", "Issue"}
+
+// qualityIssueColWidths veri kalitesi ekindeki tablonun sütun genişlikleri (mm, toplam 277)
+var qualityIssueColWidths = []float64{75, 25, 70, 107}
+
+// addQualityAppendix gelen veride bulunan ve raporu engellemeyen sorunları ek sayfada listeler
+func (g *PDFGenerator) addQualityAppendix(pdf *gofpdf.Fpdf, issues []event.FieldError) {
+	if len(issues) == 0 {
+		return
+	}
+	pdf.AddPage()
+	g.addHeader(pdf, ReportOptions{
+		Title:    "Appendix: Data Quality",
+		Subtitle: "Issues found in the incoming data. The report was generated anyway; please correct them at the source.",
+	})
+
+	g.addTableHeader(pdf, qualityIssueHeader, qualityIssueColWidths)
+	_, pageHeight := pdf.GetPageSize()
+	for i, issue := range issues {
+		// Sayfa sonuna gelindiğinde başlık satırını yeni sayfada tekrarla
+		if pdf.GetY() > pageHeight-30 {
+			pdf.AddPage()
+			g.addTableHeader(pdf, qualityIssueHeader, qualityIssueColWidths)
+		}
+		setRowFill(pdf, i)
+		pdf.SetFont("Arial", "", 9)
+		cells := []string{issue.Path, issue.Rule, qualityIssueValue(issue.Value), issue.Message}
+		for j, text := range cells {
+			pdf.CellFormat(qualityIssueColWidths[j], 7, fitCell(pdf, text, qualityIssueColWidths[j]), "1", 0, "L", true, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+	g.addTotal(pdf, fmt.Sprintf("Data quality issues: %d", len(issues)))
+}
+
+// qualityIssueValue sorunlu değeri tabloda gösterilecek biçimde döndürür
+func qualityIssueValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return fmt.Sprintf("%q", v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// fitCell metni hücre genişliğine sığacak şekilde kısaltır
+func fitCell(pdf *gofpdf.Fpdf, text string, width float64) string {
+	const padding = 2 // hücre iç boşluğu (mm)
+	if pdf.GetStringWidth(text) <= width-padding {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.GetStringWidth(string(runes)+"...") > width-padding {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
@@ -0,0 +1,186 @@
+package report
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/burakmike/report-export-service/pkg/analytics"
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// DefaultVolumeRows bir PDF cildine yazılacak varsayılan en fazla satır sayısı
+const DefaultVolumeRows = 10000
+
+// PortfolioStream çok büyük portföy listelerini satır satır CSV, NDJSON ve
+// numaralandırılmış PDF ciltlerine yazar. CSV ve NDJSON doğrudan diske akar;
+// PDF ise en fazla VolumeRows satırlık ciltler halinde bellekte tutulur, böylece
+// bellek kullanımı toplam portföy sayısından bağımsız kalır.
+type PortfolioStream struct {
+	pdfGenerator *PDFGenerator
+	baseName     string
+	volumeRows   int
+
+	// Period akışın dönemi; ilk Write'tan önce ayarlanmalıdır (sıfır değer dönemsiz rapordur)
+	Period  event.ResolvedPeriod
+	summary analytics.PeriodSummary
+
+	// QualityIssues son cilde ek olarak basılan veri kalitesi sorunları; Close'dan önce ayarlanmalıdır
+	QualityIssues []event.FieldError
+
+	csvFile    *os.File
+	csvWriter  *csv.Writer
+	jsonFile   *os.File
+	jsonWriter *bufio.Writer
+	encoder    *json.Encoder
+
+	pdf         *gofpdf.Fpdf
+	volume      int
+	volumeCount int
+	volumePaths []string
+
+	count int
+}
+
+// NewPortfolioStream PDF generator'ın çıktı dizininde yeni bir akış açar.
+// volumeRows <= 0 ise DefaultVolumeRows kullanılır.
+func (g *PDFGenerator) NewPortfolioStream(volumeRows int) (*PortfolioStream, error) {
+	if volumeRows <= 0 {
+		volumeRows = DefaultVolumeRows
+	}
+
+	s := &PortfolioStream{
+		pdfGenerator: g,
+		baseName:     strings.TrimSuffix(reportFileName("pdf"), ".pdf"),
+		volumeRows:   volumeRows,
+	}
+
+	var err error
+	s.csvFile, err = os.Create(filepath.Join(g.OutputDir, s.baseName+".csv"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CSV file: %w", err)
+	}
+	s.csvWriter = csv.NewWriter(s.csvFile)
+	if err := s.csvWriter.Write(portfolioTableHeader); err != nil {
+		s.csvFile.Close()
+		return nil, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	s.jsonFile, err = os.Create(filepath.Join(g.OutputDir, s.baseName+".ndjson"))
+	if err != nil {
+		s.csvFile.Close()
+		return nil, fmt.Errorf("failed to create NDJSON file: %w", err)
+	}
+	s.jsonWriter = bufio.NewWriter(s.jsonFile)
+	s.encoder = json.NewEncoder(s.jsonWriter)
+
+	return s, nil
+}
+
+// Write tek bir portföyü tüm çıktılara ekler
+func (s *PortfolioStream) Write(portfolio event.Portfolio) error {
+	if err := s.csvWriter.Write(portfolioCSVRecord(portfolio)); err != nil {
+		return fmt.Errorf("failed to write CSV row: %w", err)
+	}
+
+	if err := s.encoder.Encode(portfolio); err != nil {
+		return fmt.Errorf("failed to write NDJSON row: %w", err)
+	}
+
+	// Cilt dolduysa kapat ve yenisini aç
+	if s.pdf != nil && s.volumeCount >= s.volumeRows {
+		if err := s.closeVolume(false); err != nil {
+			return err
+		}
+	}
+	if s.pdf == nil {
+		s.openVolume()
+	}
+
+	s.pdfGenerator.addPortfolioRow(s.pdf, s.volumeCount, portfolio)
+	s.volumeCount++
+	s.count++
+	s.summary.Add(portfolio, s.Period)
+
+	return nil
+}
+
+// Count şimdiye kadar yazılan portföy sayısını döndürür
+func (s *PortfolioStream) Count() int {
+	return s.count
+}
+
+// Close açık cildi ve dosyaları kapatır, oluşturulan tüm dosyaların yollarını döndürür
+func (s *PortfolioStream) Close() ([]string, error) {
+	var firstErr error
+	keep := func(err error) {
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	// Boş akış için de tek ciltlik bir rapor üret
+	if s.pdf == nil {
+		s.openVolume()
+	}
+	keep(s.closeVolume(true))
+
+	s.csvWriter.Flush()
+	keep(s.csvWriter.Error())
+	keep(s.csvFile.Close())
+	keep(s.jsonWriter.Flush())
+	keep(s.jsonFile.Close())
+	if firstErr == nil {
+		keep(signArtifact(s.pdfGenerator.Signer, s.csvFile.Name()))
+		keep(signArtifact(s.pdfGenerator.Signer, s.jsonFile.Name()))
+	}
+	if firstErr != nil {
+		return nil, fmt.Errorf("failed to finalize report stream: %w", firstErr)
+	}
+
+	paths := []string{s.csvFile.Name(), s.jsonFile.Name()}
+	return append(paths, s.volumePaths...), nil
+}
+
+// openVolume yeni bir PDF cildi başlatır
+func (s *PortfolioStream) openVolume() {
+	s.volume++
+	s.volumeCount = 0
+
+	options := portfolioReportOptions(s.Period)
+	options.Subtitle = fmt.Sprintf("%s - Volume %d", options.Subtitle, s.volume)
+
+	s.pdf = s.pdfGenerator.newDocument()
+	s.pdf.AddPage()
+	s.pdfGenerator.addHeader(s.pdf, options)
+	s.pdfGenerator.addPortfolioTableHeader(s.pdf)
+}
+
+// closeVolume açık cildi diske yazar. Son ciltte toplam portföy sayısı ve veri
+// kalitesi eki de basılır.
+func (s *PortfolioStream) closeVolume(last bool) error {
+	summary := fmt.Sprintf("Portfolios in this volume: %d", s.volumeCount)
+	if last {
+		summary = fmt.Sprintf("%s - %s", summary, portfolioTotalLine(s.count, s.summary, s.Period))
+	}
+	s.pdfGenerator.addTotal(s.pdf, summary)
+	if last {
+		s.pdfGenerator.addFooter(s.pdf)
+		s.pdfGenerator.addQualityAppendix(s.pdf, s.QualityIssues)
+	}
+
+	filePath := filepath.Join(s.pdfGenerator.OutputDir, fmt.Sprintf("%s_vol%03d.pdf", s.baseName, s.volume))
+	err := s.pdfGenerator.savePDF(s.pdf, filePath)
+	s.pdf = nil
+	if err != nil {
+		return fmt.Errorf("failed to save PDF volume %d: %w", s.volume, err)
+	}
+
+	s.volumePaths = append(s.volumePaths, filePath)
+	return nil
+}
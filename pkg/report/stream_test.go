@@ -0,0 +1,87 @@
+package report
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+func TestPortfolioStream_SplitsVolumes(t *testing.T) {
+	dir := t.TempDir()
+	gen, err := NewPDFGenerator(dir)
+	if err != nil {
+		t.Fatalf("NewPDFGenerator error: %v", err)
+	}
+
+	stream, err := gen.NewPortfolioStream(2)
+	if err != nil {
+		t.Fatalf("NewPortfolioStream error: %v", err)
+	}
+	for i := 1; i <= 5; i++ {
+		p := event.Portfolio{PortID: i, Name: fmt.Sprintf("P%d", i), UserID: "u", CreatedAt: event.MustParseTimestamp("2023-01-01"), LastUpdate: event.MustParseTimestamp("2023-01-02")}
+		if err := stream.Write(p); err != nil {
+			t.Fatalf("Write error: %v", err)
+		}
+	}
+	paths, err := stream.Close()
+	if err != nil {
+		t.Fatalf("Close error: %v", err)
+	}
+
+	var csvPath, jsonPath string
+	var volumes []string
+	for _, p := range paths {
+		switch {
+		case strings.HasSuffix(p, ".csv"):
+			csvPath = p
+		case strings.HasSuffix(p, ".ndjson"):
+			jsonPath = p
+		case strings.HasSuffix(p, ".pdf"):
+			volumes = append(volumes, p)
+		}
+	}
+
+	// 5 rows with 2 rows per volume -> 3 numbered volumes
+	if len(volumes) != 3 {
+		t.Fatalf("Expected 3 PDF volumes, got %d: %v", len(volumes), volumes)
+	}
+	for i, v := range volumes {
+		if !strings.HasSuffix(v, fmt.Sprintf("_vol%03d.pdf", i+1)) {
+			t.Errorf("Volume %d has unexpected name %s", i+1, v)
+		}
+		if info, err := os.Stat(v); err != nil || info.Size() == 0 {
+			t.Errorf("Volume %s missing or empty", v)
+		}
+	}
+
+	f, err := os.Open(csvPath)
+	if err != nil {
+		t.Fatalf("Open CSV error: %v", err)
+	}
+	records, err := csv.NewReader(f).ReadAll()
+	f.Close()
+	if err != nil {
+		t.Fatalf("Read CSV error: %v", err)
+	}
+	if len(records) != 6 || records[0][0] != "ID" || records[5][1] != "P5" {
+		t.Errorf("Unexpected CSV content: %v", records)
+	}
+
+	f, err = os.Open(jsonPath)
+	if err != nil {
+		t.Fatalf("Open NDJSON error: %v", err)
+	}
+	defer f.Close()
+	lines := 0
+	for sc := bufio.NewScanner(f); sc.Scan(); {
+		lines++
+	}
+	if lines != 5 {
+		t.Errorf("Expected 5 NDJSON lines, got %d", lines)
+	}
+}
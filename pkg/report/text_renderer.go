@@ -0,0 +1,228 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/burakmike/report-export-service/pkg/signing"
+)
+
+// TextFormat metin tabanlı rapor formatlarını tanımlar
+type TextFormat string
+
+// Desteklenen metin formatları
+const (
+	FormatMarkdown  TextFormat = "markdown"
+	FormatPlainText TextFormat = "text"
+)
+
+// maxColumnWidth metin tablolarında bir sütunun alabileceği en fazla karakter sayısı.
+// Daha uzun değerler "…" ile kısaltılır, böylece tablo sohbet ve e-posta
+// istemcilerinde satır kaydırmadan okunabilir kalır.
+const maxColumnWidth = 40
+
+// portfolioTableHeader metin tablolarının başlık satırı (PDF ile aynı sırada)
+var portfolioTableHeader = []string{"ID", "Portfolio Name", "User ID", "Created", "Last Updated"}
+
+// TextGenerator Markdown veya düz metin raporlarını dosyaya yazan yapı
+type TextGenerator struct {
+	OutputDir string          // Raporların kaydedileceği dizin
+	Format    TextFormat      // Markdown veya düz metin
+	Signer    *signing.Signer // Raporları imzalamak için (opsiyonel)
+}
+
+// NewTextGenerator yeni bir metin rapor oluşturucu oluşturur
+func NewTextGenerator(outputDir string, format TextFormat) (*TextGenerator, error) {
+	if outputDir == "" {
+		outputDir = "reports"
+	}
+
+	if format != FormatMarkdown && format != FormatPlainText {
+		return nil, fmt.Errorf("unsupported text format: %q", format)
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	return &TextGenerator{
+		OutputDir: outputDir,
+		Format:    format,
+	}, nil
+}
+
+// GeneratePortfolioReport portföy verilerinden metin raporu oluşturur ve dosya yolunu döndürür
+func (g *TextGenerator) GeneratePortfolioReport(portfolios []event.Portfolio) (string, error) {
+	options := defaultPortfolioReportOptions()
+
+	var content, ext string
+	if g.Format == FormatMarkdown {
+		content, ext = RenderPortfolioMarkdown(portfolios, options), "md"
+	} else {
+		content, ext = RenderPortfolioText(portfolios, options), "txt"
+	}
+
+	filePath := filepath.Join(g.OutputDir, reportFileName(ext))
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("failed to save %s report: %w", g.Format, err)
+	}
+
+	if err := signArtifact(g.Signer, filePath); err != nil {
+		return "", err
+	}
+
+	return filePath, nil
+}
+
+// RenderPortfolioMarkdown portföy raporunu Markdown olarak döndürür.
+// Çıktı hem tek başına .md dosyası olarak hem de sohbet mesajı gövdesi olarak kullanılabilir.
+func RenderPortfolioMarkdown(portfolios []event.Portfolio, options ReportOptions) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", escapeMarkdown(options.Title))
+	if options.Subtitle != "" {
+		fmt.Fprintf(&b, "_%s_\n\n", escapeMarkdown(options.Subtitle))
+	}
+
+	rows := portfolioTableRows(portfolios, escapeMarkdown)
+	widths := columnWidths(portfolioTableHeader, rows)
+
+	writeMarkdownRow(&b, portfolioTableHeader, widths)
+	separators := make([]string, len(widths))
+	for i, w := range widths {
+		separators[i] = strings.Repeat("-", w)
+	}
+	writeMarkdownRow(&b, separators, widths)
+	for _, row := range rows {
+		writeMarkdownRow(&b, row, widths)
+	}
+
+	fmt.Fprintf(&b, "\n**Total Portfolios: %d**\n\n", len(portfolios))
+	fmt.Fprintf(&b, "---\n\n_%s_  \n_%s_\n", generatedNotice(), copyrightNotice)
+
+	return b.String()
+}
+
+// RenderPortfolioText portföy raporunu sabit genişlikli düz metin olarak döndürür.
+// Çıktı düz metin e-posta gövdesi olarak kullanılmak üzere tasarlanmıştır.
+func RenderPortfolioText(portfolios []event.Portfolio, options ReportOptions) string {
+	var b strings.Builder
+
+	b.WriteString(options.Title + "\n")
+	b.WriteString(strings.Repeat("=", utf8.RuneCountInString(options.Title)) + "\n")
+	if options.Subtitle != "" {
+		b.WriteString(options.Subtitle + "\n")
+	}
+	b.WriteString("\n")
+
+	rows := portfolioTableRows(portfolios, nil)
+	widths := columnWidths(portfolioTableHeader, rows)
+
+	writeTextRow(&b, portfolioTableHeader, widths)
+	separators := make([]string, len(widths))
+	for i, w := range widths {
+		separators[i] = strings.Repeat("-", w)
+	}
+	writeTextRow(&b, separators, widths)
+	for _, row := range rows {
+		writeTextRow(&b, row, widths)
+	}
+
+	fmt.Fprintf(&b, "\nTotal Portfolios: %d\n\n", len(portfolios))
+	b.WriteString(generatedNotice() + "\n")
+	b.WriteString(copyrightNotice + "\n")
+
+	return b.String()
+}
+
+// portfolioTableRows portföyleri kısaltılmış (ve isteğe bağlı olarak kaçışlanmış) tablo hücrelerine dönüştürür
+func portfolioTableRows(portfolios []event.Portfolio, escape func(string) string) [][]string {
+	rows := make([][]string, 0, len(portfolios))
+	for _, p := range portfolios {
+		row := []string{
+			fmt.Sprintf("%d", p.PortID),
+			p.Name,
+			p.UserID,
+			displayDate(p.CreatedAt),
+			displayDate(p.LastUpdate),
+		}
+		for i := range row {
+			row[i] = truncateCell(row[i], maxColumnWidth)
+			if escape != nil {
+				row[i] = escape(row[i])
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// columnWidths her sütun için başlık ve hücrelerin en genişini döndürür
+func columnWidths(header []string, rows [][]string) []int {
+	widths := make([]int, len(header))
+	for i, h := range header {
+		widths[i] = utf8.RuneCountInString(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if n := utf8.RuneCountInString(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	return widths
+}
+
+// truncateCell değeri en fazla max karakter olacak şekilde kısaltır
+func truncateCell(s string, max int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:max-1]) + "…"
+}
+
+// padRight değeri sağdan boşlukla verilen genişliğe tamamlar
+func padRight(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
+
+// writeMarkdownRow hizalanmış bir Markdown tablo satırı yazar
+func writeMarkdownRow(b *strings.Builder, cells []string, widths []int) {
+	b.WriteString("|")
+	for i, cell := range cells {
+		b.WriteString(" " + padRight(cell, widths[i]) + " |")
+	}
+	b.WriteString("\n")
+}
+
+// writeTextRow sabit genişlikli bir düz metin tablo satırı yazar
+func writeTextRow(b *strings.Builder, cells []string, widths []int) {
+	padded := make([]string, len(cells))
+	for i, cell := range cells {
+		padded[i] = padRight(cell, widths[i])
+	}
+	b.WriteString(strings.TrimRight(strings.Join(padded, "  "), " ") + "\n")
+}
+
+// markdownEscaper tablo ve vurgu yapısını bozabilecek Markdown karakterlerini kaçışlar
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"|", `\|`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+)
+
+// escapeMarkdown değeri Markdown içinde güvenle kullanılabilir hale getirir
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
@@ -0,0 +1,94 @@
+package report
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+func TestRenderPortfolioMarkdown(t *testing.T) {
+	portfolios := []event.Portfolio{
+		{PortID: 1, Name: "Tech | Growth", UserID: "user1", CreatedAt: event.MustParseTimestamp("2023-01-01 00:00:00"), LastUpdate: event.MustParseTimestamp("2023-01-02 00:00:00")},
+		{PortID: 2, Name: strings.Repeat("Long name ", 10), UserID: "user2", CreatedAt: event.MustParseTimestamp("2023-02-01 00:00:00"), LastUpdate: event.MustParseTimestamp("2023-02-02 00:00:00")},
+	}
+	out := RenderPortfolioMarkdown(portfolios, ReportOptions{Title: "Portfolio Report", Subtitle: "sub"})
+
+	if !strings.HasPrefix(out, "# Portfolio Report\n") {
+		t.Errorf("Markdown should start with title heading, got %q", out[:30])
+	}
+	if !strings.Contains(out, `Tech \| Growth`) {
+		t.Errorf("Pipe in name should be escaped")
+	}
+	if !strings.Contains(out, "**Total Portfolios: 2**") {
+		t.Errorf("Markdown should contain totals")
+	}
+
+	// All table lines must have the same width so the raw text stays aligned
+	var tableWidths []int
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "|") {
+			tableWidths = append(tableWidths, utf8.RuneCountInString(line))
+		}
+	}
+	if len(tableWidths) != 4 {
+		t.Fatalf("Expected 4 table lines (header, separator, 2 rows), got %d", len(tableWidths))
+	}
+	for _, w := range tableWidths {
+		if w != tableWidths[0] {
+			t.Errorf("Table lines are not aligned: %v", tableWidths)
+			break
+		}
+	}
+}
+
+func TestRenderPortfolioText_TruncatesLongNames(t *testing.T) {
+	longName := strings.Repeat("x", 100)
+	portfolios := []event.Portfolio{
+		{PortID: 7, Name: longName, UserID: "user1", CreatedAt: event.MustParseTimestamp("2023-01-01 00:00:00"), LastUpdate: event.MustParseTimestamp("2023-01-02 00:00:00")},
+	}
+	out := RenderPortfolioText(portfolios, ReportOptions{Title: "Portfolio Report"})
+
+	if strings.Contains(out, longName) {
+		t.Errorf("Long name should be truncated")
+	}
+	if !strings.Contains(out, strings.Repeat("x", maxColumnWidth-1)+"…") {
+		t.Errorf("Truncated name should end with ellipsis")
+	}
+	if !strings.Contains(out, "Total Portfolios: 1") {
+		t.Errorf("Text should contain totals")
+	}
+	if !strings.Contains(out, copyrightNotice) {
+		t.Errorf("Text should contain footer")
+	}
+}
+
+func TestTextGenerator_GeneratePortfolioReport(t *testing.T) {
+	dir := t.TempDir()
+	for format, ext := range map[TextFormat]string{FormatMarkdown: ".md", FormatPlainText: ".txt"} {
+		gen, err := NewTextGenerator(dir, format)
+		if err != nil {
+			t.Fatalf("NewTextGenerator(%s) error: %v", format, err)
+		}
+		path, err := gen.GeneratePortfolioReport(event.CreateSamplePortfolios())
+		if err != nil {
+			t.Fatalf("GeneratePortfolioReport(%s) error: %v", format, err)
+		}
+		if !strings.HasSuffix(path, ext) {
+			t.Errorf("Expected %s extension, got %s", ext, path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("ReadFile error: %v", err)
+		}
+		if !strings.Contains(string(data), "Retirement Fund") {
+			t.Errorf("%s report does not contain portfolio names", format)
+		}
+	}
+
+	if _, err := NewTextGenerator(dir, "html"); err == nil {
+		t.Errorf("Expected error for unsupported format")
+	}
+}
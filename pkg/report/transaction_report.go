@@ -0,0 +1,171 @@
+package report
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/burakmike/report-export-service/pkg/analytics"
+	"github.com/burakmike/report-export-service/pkg/event"
+	"github.com/jung-kurt/gofpdf"
+)
+
+// transactionTableHeader işlem defteri tablosunun sütun başlıkları
+var transactionTableHeader = []string{"Date", "Type", "Instrument", "Quantity", "Price", "Amount", "Balance", "Description"}
+
+// transactionColWidths işlem defteri tablosunun sütun genişlikleri (mm, toplam 277)
+var transactionColWidths = []float64{38, 26, 30, 24, 28, 34, 34, 63}
+
+// transactionTotalsHeader tür bazında toplamlar tablosunun sütun başlıkları
+var transactionTotalsHeader = []string{"Type", "Count", "Amount"}
+
+// transactionTotalsColWidths tür bazında toplamlar tablosunun sütun genişlikleri (mm)
+var transactionTotalsColWidths = []float64{40, 25, 45}
+
+// GenerateTransactionReport işlem defterlerinden PDF raporu oluşturur. Her portföy
+// ayrı bir bölümde, yürüyen bakiyeli kronolojik defter ve tür bazında toplamlarla yer alır.
+func (g *PDFGenerator) GenerateTransactionReport(ledgers []analytics.Ledger) (string, error) {
+	pdf := g.newDocument()
+	pdf.AddPage()
+
+	g.addHeader(pdf, ReportOptions{
+		Title:    "Transaction History Report",
+		Subtitle: fmt.Sprintf("Generated on %s", time.Now().Format("January 2, 2006")),
+	})
+
+	for i, ledger := range ledgers {
+		if i > 0 {
+			pdf.AddPage()
+		}
+		g.addLedger(pdf, ledger)
+	}
+
+	g.addFooter(pdf)
+
+	filePath := filepath.Join(g.OutputDir, reportFileNameFor("transaction_report", "pdf"))
+	if err := g.savePDF(pdf, filePath); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+// addSectionTitle bir rapor bölümünün başlığını ekler
+func (g *PDFGenerator) addSectionTitle(pdf *gofpdf.Fpdf, title string) {
+	pdf.SetFont("Arial", "B", 14)
+	pdf.SetTextColor(0, 51, 102) // Koyu mavi
+	pdf.CellFormat(0, 10, title, "", 1, "L", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+}
+
+// addLedger bir portföyün işlem defterini ve tür bazında toplamlarını ekler
+func (g *PDFGenerator) addLedger(pdf *gofpdf.Fpdf, ledger analytics.Ledger) {
+	g.addSectionTitle(pdf, fmt.Sprintf("Portfolio %d - %s (%s)", ledger.PortID, ledger.Name, ledger.UserID))
+
+	pdf.SetFont("Arial", "", 10)
+	pdf.CellFormat(0, 8, "Opening balance: "+formatMoney(ledger.OpeningBalance), "", 1, "L", false, 0, "")
+	pdf.Ln(2)
+
+	g.addTableHeader(pdf, transactionTableHeader, transactionColWidths)
+	_, pageHeight := pdf.GetPageSize()
+	for i, entry := range ledger.Entries {
+		// Sayfa sonuna gelindiğinde başlık satırını yeni sayfada tekrarla
+		if pdf.GetY() > pageHeight-30 {
+			pdf.AddPage()
+			g.addTableHeader(pdf, transactionTableHeader, transactionColWidths)
+		}
+		g.addLedgerRow(pdf, i, entry)
+	}
+	if len(ledger.Entries) == 0 {
+		pdf.CellFormat(0, 8, "No transactions", "1", 1, "C", false, 0, "")
+	}
+
+	pdf.Ln(6)
+	g.addSectionTitle(pdf, "Totals by Type")
+	g.addTableHeader(pdf, transactionTotalsHeader, transactionTotalsColWidths)
+	row := 0
+	for _, t := range event.TransactionTypes {
+		if ledger.Counts[t] == 0 {
+			continue
+		}
+		setRowFill(pdf, row)
+		pdf.CellFormat(transactionTotalsColWidths[0], 8, transactionTypeLabel(t), "1", 0, "L", true, 0, "")
+		pdf.CellFormat(transactionTotalsColWidths[1], 8, strconv.Itoa(ledger.Counts[t]), "1", 0, "C", true, 0, "")
+		pdf.CellFormat(transactionTotalsColWidths[2], 8, formatMoney(ledger.Totals[t]), "1", 0, "R", true, 0, "")
+		pdf.Ln(-1)
+		row++
+	}
+
+	g.addTotal(pdf, "Closing balance: "+formatMoney(ledger.ClosingBalance))
+}
+
+// addLedgerRow deftere i. sıradaki satırı ekler
+func (g *PDFGenerator) addLedgerRow(pdf *gofpdf.Fpdf, i int, entry analytics.LedgerEntry) {
+	t := entry.Transaction
+	setRowFill(pdf, i)
+
+	quantity, price := "", ""
+	if t.Type == event.TransactionBuy || t.Type == event.TransactionSell {
+		quantity = formatDecimal(t.Quantity)
+		price = formatUnitPrice(t.Price, entry.Balance.Currency)
+	}
+
+	cells := []struct {
+		text  string
+		align string
+	}{
+		{displayDate(t.Date), "C"},
+		{transactionTypeLabel(t.Type), "L"},
+		{t.Instrument, "L"},
+		{quantity, "R"},
+		{price, "R"},
+		{formatMoney(entry.CashFlow), "R"},
+		{formatMoney(entry.Balance), "R"},
+		{t.Description, "L"},
+	}
+	for j, c := range cells {
+		pdf.CellFormat(transactionColWidths[j], 8, c.text, "1", 0, c.align, true, 0, "")
+	}
+	pdf.Ln(-1)
+}
+
+// setRowFill tablolarda alternatif satır rengini ayarlar
+func setRowFill(pdf *gofpdf.Fpdf, i int) {
+	if i%2 == 0 {
+		pdf.SetFillColor(240, 240, 240) // Açık gri
+	} else {
+		pdf.SetFillColor(255, 255, 255) // Beyaz
+	}
+}
+
+// transactionTypeLabel işlem türünün raporlarda gösterilen adı
+func transactionTypeLabel(t event.TransactionType) string {
+	return capitalize(string(t))
+}
+
+// capitalize metnin ilk harfini büyütür
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// groupThousands işaretsiz bir ondalık metnin tam sayı kısmına binlik ayracı ekler, ör. 1234.5 -> 1,234.5
+func groupThousands(s string) string {
+	intPart, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, frac = s[:i], s[i:]
+	}
+
+	var b strings.Builder
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteString(frac)
+	return b.String()
+}
@@ -0,0 +1,38 @@
+package report
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/burakmike/report-export-service/pkg/analytics"
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+func TestGenerateTransactionReport(t *testing.T) {
+	gen, err := NewPDFGenerator(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewPDFGenerator error: %v", err)
+	}
+
+	// Enough transactions to span several pages
+	transactions := make([]event.Transaction, 60)
+	for i := range transactions {
+		transactions[i] = event.Transaction{Date: event.MustParseTimestamp("2024-01-01"), Type: event.TransactionDeposit, Amount: event.MustParseDecimal("10")}
+	}
+	ledgers := []analytics.Ledger{
+		analytics.BuildLedger(event.PortfolioTransactions{PortID: 1, Name: "A", UserID: "u1", Transactions: transactions}),
+		analytics.BuildLedger(event.PortfolioTransactions{PortID: 2, Name: "Empty", UserID: "u2", Currency: "JPY"}),
+	}
+
+	filePath, err := gen.GenerateTransactionReport(ledgers)
+	if err != nil {
+		t.Fatalf("GenerateTransactionReport error: %v", err)
+	}
+	if !strings.Contains(filePath, "transaction_report_") || !strings.HasSuffix(filePath, ".pdf") {
+		t.Errorf("Unexpected file name %s", filePath)
+	}
+	if info, err := os.Stat(filePath); err != nil || info.Size() == 0 {
+		t.Errorf("PDF not written: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package schema
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+// PathPrefix şemaların HTTP üzerinden sunulduğu yol
+const PathPrefix = "/schemas/"
+
+// Handler şemaları HTTP üzerinden sunar:
+//
+//	GET /schemas/                          kayıtlı event tipleri ve şema adresleri
+//	GET /schemas/<event_type>              zarf dahil mesaj şeması
+//	GET /schemas/<event_type>/payload      yalnızca payload şeması
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(PathPrefix, serveSchema)
+	return mux
+}
+
+// serveSchema istenen şemayı JSON olarak yazar
+func serveSchema(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.Trim(strings.TrimPrefix(r.URL.Path, PathPrefix), "/")
+	if name == "" {
+		index := make(map[event.EventType]string)
+		for _, t := range event.EventTypes() {
+			index[t] = PathPrefix + string(t)
+		}
+		writeJSON(w, index)
+		return
+	}
+
+	var (
+		s   *Schema
+		err error
+	)
+	if eventType := strings.TrimSuffix(name, "/payload"); eventType != name {
+		s, err = ForPayload(event.EventType(eventType))
+	} else {
+		s, err = ForEvent(event.EventType(name))
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/schema+json")
+	writeJSON(w, s)
+}
+
+// writeJSON değeri girintili JSON olarak yazar
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
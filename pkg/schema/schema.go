@@ -0,0 +1,292 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+// Draft üretilen şemaların JSON Schema sürümü
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// DatePattern event.Timestamp'in kabul ettiği tarih formatlarıyla eşleşen desen.
+// event.TimestampLayouts'tan üretilir: "T" ile ayrılan saatlerde saat dilimi zorunludur,
+// boşlukla ayrılan saatler ve yalın tarihler saat dilimi içermez.
+var DatePattern = layoutsPattern(event.TimestampLayouts)
+
+// DecimalPattern event.Decimal'in metin olarak kabul ettiği ondalık sayılarla eşleşen desen
+const DecimalPattern = `^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?$`
+
+// Schema bir JSON Schema belgesinin bu servisin kullandığı alt kümesi
+type Schema struct {
+	Schema      string             `json:"$schema,omitempty"`
+	ID          string             `json:"$id,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Type        string             `json:"type,omitempty"`
+	Const       interface{}        `json:"const,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Minimum     *float64           `json:"minimum,omitempty"`
+	MinLength   *int               `json:"minLength,omitempty"`
+	MinItems    *int               `json:"minItems,omitempty"`
+	Pattern     string             `json:"pattern,omitempty"`
+	Format      string             `json:"format,omitempty"`
+
+	// AdditionalProperties map alanlarındaki değerlerin şeması
+	AdditionalProperties *Schema `json:"additionalProperties,omitempty"`
+}
+
+// layoutTokens Go zaman formatı öğelerinin düzenli ifade karşılıkları. Saniyeden
+// sonra gelen kesir, time.Parse gibi format belirtmese de kabul edilir. Ayın gün
+// sayısı desenle denetlenemez (ör. 2026-02-30); bu değerler doğrulamada reddedilir.
+var layoutTokens = []struct{ layout, pattern string }{
+	{".999999999", ""},
+	{"Z07:00", `(Z|[+-]([01]\d|2[0-3]):[0-5]\d)`},
+	{"2006", `\d{4}`},
+	{"01", `(0[1-9]|1[0-2])`},
+	{"02", `(0[1-9]|[12]\d|3[01])`},
+	{"15", `([01]\d|2[0-3])`},
+	{"04", `[0-5]\d`},
+	{"05", `[0-5]\d(\.\d+)?`},
+}
+
+// layoutsPattern zaman formatlarından herhangi biriyle eşleşen bir desen üretir
+func layoutsPattern(layouts []string) string {
+	alternatives := make([]string, len(layouts))
+	for i, layout := range layouts {
+		alternatives[i] = layoutPattern(layout)
+	}
+	return "^(" + strings.Join(alternatives, "|") + ")$"
+}
+
+// layoutPattern tek bir Go zaman formatını düzenli ifadeye çevirir; format öğesi
+// olmayan karakterler (ayraçlar) olduğu gibi eşleşir
+func layoutPattern(layout string) string {
+	var b strings.Builder
+	for rest := layout; rest != ""; {
+		matched := false
+		for _, token := range layoutTokens {
+			if strings.HasPrefix(rest, token.layout) {
+				b.WriteString(token.pattern)
+				rest = rest[len(token.layout):]
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			b.WriteString(regexp.QuoteMeta(rest[:1]))
+			rest = rest[1:]
+		}
+	}
+	return b.String()
+}
+
+var (
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	timestampType  = reflect.TypeOf(event.Timestamp{})
+	decimalType    = reflect.TypeOf(event.Decimal{})
+)
+
+// ForPayload event tipinin payload şemasını üretir
+func ForPayload(eventType event.EventType) (*Schema, error) {
+	t, ok := event.PayloadType(eventType)
+	if !ok {
+		return nil, fmt.Errorf("no payload type registered for event type %q", eventType)
+	}
+	s, err := Generate(t)
+	if err != nil {
+		return nil, err
+	}
+	s.Schema = Draft
+	s.ID = schemaID(eventType, "payload")
+	s.Title = string(eventType) + " payload"
+	return s, nil
+}
+
+// ForEvent event tipinin zarf dahil tam mesaj şemasını üretir
+func ForEvent(eventType event.EventType) (*Schema, error) {
+	payload, err := ForPayload(eventType)
+	if err != nil {
+		return nil, err
+	}
+	payload.Schema, payload.ID = "", ""
+
+	s, err := Generate(reflect.TypeOf(event.BaseEvent{}))
+	if err != nil {
+		return nil, err
+	}
+	s.Schema = Draft
+	s.ID = schemaID(eventType, "event")
+	s.Title = string(eventType) + " event"
+	s.Properties["event_type"].Const = string(eventType)
+	s.Properties["payload"] = payload
+	return s, nil
+}
+
+// All kayıtlı tüm event tiplerinin mesaj şemalarını döndürür
+func All() (map[event.EventType]*Schema, error) {
+	schemas := make(map[event.EventType]*Schema)
+	for _, t := range event.EventTypes() {
+		s, err := ForEvent(t)
+		if err != nil {
+			return nil, err
+		}
+		schemas[t] = s
+	}
+	return schemas, nil
+}
+
+// schemaID şema belgesinin göreli kimliği
+func schemaID(eventType event.EventType, kind string) string {
+	return fmt.Sprintf("%s.%s.schema.json", eventType, kind)
+}
+
+// Generate bir Go tipinden yansıma (reflection) ile şema üretir. Struct alanları
+// json etiketlerine göre adlandırılır; omitempty olmayan alanlar zorunludur.
+func Generate(t reflect.Type) (*Schema, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t {
+	case rawMessageType:
+		return &Schema{}, nil
+	case timestampType:
+		return &Schema{Type: "string", Pattern: DatePattern, Description: "RFC3339, 2006-01-02 15:04:05 or 2006-01-02"}, nil
+	case decimalType:
+		// Tip belirtilmez: hem JSON sayısı hem de desene uyan metin kabul edilir
+		return &Schema{Pattern: DecimalPattern, Description: "decimal number or decimal string, e.g. 12.34"}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := Generate(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type %s", t.Key())
+		}
+		values, err := Generate(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		return generateObject(t)
+	case reflect.Interface:
+		return &Schema{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+// generateObject struct alanlarından bir object şeması üretir
+func generateObject(t reflect.Type) (*Schema, error) {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue // dışa açık olmayan alan
+		}
+
+		name, omitempty := jsonName(field)
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && field.Tag.Get("json") == "" && field.Type.Kind() == reflect.Struct {
+			// Gömülü struct alanları encoding/json'daki gibi üst nesneye açılır
+			embedded, err := generateObject(field.Type)
+			if err != nil {
+				return nil, err
+			}
+			for n, prop := range embedded.Properties {
+				s.Properties[n] = prop
+			}
+			s.Required = append(s.Required, embedded.Required...)
+			continue
+		}
+
+		prop, err := Generate(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+		}
+		if err := applyTag(prop, field.Tag.Get("jsonschema")); err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+		}
+
+		s.Properties[name] = prop
+		if !omitempty {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s, nil
+}
+
+// jsonName alanın JSON adını ve omitempty olup olmadığını döndürür
+func jsonName(field reflect.StructField) (string, bool) {
+	parts := strings.Split(field.Tag.Get("json"), ",")
+	name := parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			return name, true
+		}
+	}
+	return name, false
+}
+
+// applyTag jsonschema etiketindeki kısıtları şemaya uygular,
+// ör. `jsonschema:"minimum=1"` veya `jsonschema:"minLength=1,minItems=1"`
+func applyTag(s *Schema, tag string) error {
+	if tag == "" {
+		return nil
+	}
+	for _, opt := range strings.Split(tag, ",") {
+		key, value := opt, ""
+		if i := strings.Index(opt, "="); i >= 0 {
+			key, value = opt[:i], opt[i+1:]
+		}
+
+		switch key {
+		case "minimum":
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("invalid jsonschema minimum %q", value)
+			}
+			s.Minimum = &n
+		case "minLength", "minItems":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid jsonschema %s %q", key, value)
+			}
+			if key == "minLength" {
+				s.MinLength = &n
+			} else {
+				s.MinItems = &n
+			}
+		default:
+			return fmt.Errorf("unknown jsonschema option %q", key)
+		}
+	}
+	return nil
+}
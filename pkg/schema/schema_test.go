@@ -0,0 +1,209 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+func TestForEvent_PortfolioReport(t *testing.T) {
+	s, err := ForEvent(event.PortfolioReport)
+	if err != nil {
+		t.Fatalf("ForEvent error: %v", err)
+	}
+	if s.Properties["event_type"].Const != string(event.PortfolioReport) {
+		t.Errorf("event_type const = %v", s.Properties["event_type"].Const)
+	}
+	for _, name := range []string{"event_type", "timestamp", "payload"} {
+		if !contains(s.Required, name) {
+			t.Errorf("Envelope field %s should be required, got %v", name, s.Required)
+		}
+	}
+	if contains(s.Required, "event_id") {
+		t.Errorf("omitempty field event_id should not be required")
+	}
+
+	item := s.Properties["payload"].Properties["portfolios"].Items
+	if item == nil || item.Type != "object" || len(item.Required) != 5 {
+		t.Fatalf("Unexpected portfolio item schema: %+v", item)
+	}
+	if item.Properties["portID"].Type != "integer" || *item.Properties["portID"].Minimum != 1 {
+		t.Errorf("portID schema = %+v", item.Properties["portID"])
+	}
+	if item.Properties["createdAt"].Pattern != DatePattern {
+		t.Errorf("createdAt should use the date pattern")
+	}
+}
+
+func TestForPayload_Unknown(t *testing.T) {
+	if _, err := ForPayload("unknown.event"); err == nil {
+		t.Error("Expected error for unregistered event type")
+	}
+}
+
+func TestValidateMessage(t *testing.T) {
+	valid, err := event.NewPortfolioReportEvent(event.CreateSamplePortfolios())
+	if err != nil {
+		t.Fatalf("NewPortfolioReportEvent error: %v", err)
+	}
+	data, _ := json.Marshal(valid)
+	if err := ValidateMessage(data, ""); err != nil {
+		t.Errorf("Valid message rejected: %v", err)
+	}
+
+	invalid := []byte(`{"event_type":"portfolio.report","timestamp":"2024-01-01T00:00:00Z",
+		"payload":{"portfolios":[{"portID":0,"name":"A","userID":"u","createdAt":"soon","lastUpdate":"2024-01-01"}]}}`)
+	err = ValidateMessage(invalid, "")
+	var verrs event.ValidationErrors
+	if !errors.As(err, &verrs) || len(verrs) != 2 {
+		t.Fatalf("Expected 2 schema errors, got %v", err)
+	}
+	if verrs[0].Path != "payload.portfolios[0].createdAt" || verrs[0].Rule != "pattern" {
+		t.Errorf("Unexpected first error %+v", verrs[0])
+	}
+	if verrs[1].Path != "payload.portfolios[0].portID" || verrs[1].Rule != "minimum" {
+		t.Errorf("Unexpected second error %+v", verrs[1])
+	}
+
+	// Duplicate IDs pass the schema and the service only flags them, but publishers are told to fix them
+	duplicate := []byte(`{"event_type":"portfolio.report","timestamp":"2024-01-01",
+		"payload":{"portfolios":[
+			{"portID":1,"name":"A","userID":"u","createdAt":"2024-01-01","lastUpdate":"2024-01-01"},
+			{"portID":1,"name":"B","userID":"u","createdAt":"2024-01-01","lastUpdate":"2024-01-01"}]}}`)
+	err = ValidateMessage(duplicate, "")
+	if !errors.As(err, &verrs) || len(verrs) != 1 || verrs[0].Rule != event.RuleUnique {
+		t.Errorf("Expected unique error, got %v", err)
+	}
+
+	if err := ValidateMessage([]byte(`{"payload":{}}`), ""); err == nil {
+		t.Error("Expected error for message without event_type")
+	}
+}
+
+func TestValidateMessage_CloudEvent(t *testing.T) {
+	valid, err := event.NewPortfolioReportEvent(event.CreateSamplePortfolios())
+	if err != nil {
+		t.Fatalf("NewPortfolioReportEvent error: %v", err)
+	}
+	data, err := event.MarshalCloudEvent(valid)
+	if err != nil {
+		t.Fatalf("MarshalCloudEvent error: %v", err)
+	}
+	if err := ValidateMessage(data, ""); err != nil {
+		t.Errorf("Valid CloudEvent rejected: %v", err)
+	}
+
+	invalid := []byte(`{"specversion":"1.0","id":"e1","source":"/s","type":"portfolio.report",
+		"data":{"portfolios":[{"portID":0,"name":"A","userID":"u","createdAt":"2024-01-01","lastUpdate":"2024-01-01"}]}}`)
+	var verrs event.ValidationErrors
+	if err := ValidateMessage(invalid, ""); !errors.As(err, &verrs) || len(verrs) != 1 || verrs[0].Path != "data.portfolios[0].portID" {
+		t.Errorf("Expected a single data.portfolios[0].portID error, got %v", err)
+	}
+
+	missingID := []byte(`{"specversion":"1.0","source":"/s","type":"portfolio.report","data":{}}`)
+	if err := ValidateMessage(missingID, ""); err == nil || !strings.Contains(err.Error(), `"id"`) {
+		t.Errorf("Expected missing id error, got %v", err)
+	}
+}
+
+func TestValidateMessage_Decimals(t *testing.T) {
+	message := func(price string) []byte {
+		return []byte(`{"event_type":"holdings.report","timestamp":"2024-01-01","payload":{"reportingCurrency":"USD",
+			"portfolios":[{"portID":1,"name":"A","userID":"u1","holdings":[
+				{"instrument":"AAPL","assetClass":"equity","quantity":10,"price":` + price + `}]}]}}`)
+	}
+
+	for _, price := range []string{`189.95`, `"189.95"`, `"1e2"`} {
+		if err := ValidateMessage(message(price), ""); err != nil {
+			t.Errorf("price %s: unexpected error %v", price, err)
+		}
+	}
+
+	var verrs event.ValidationErrors
+	if err := ValidateMessage(message(`"abc"`), ""); !errors.As(err, &verrs) || verrs[0].Rule != "pattern" {
+		t.Errorf("Expected a pattern error for a non-numeric price, got %v", err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	srv := httptest.NewServer(Handler())
+	defer srv.Close()
+
+	cases := map[string]int{
+		"/schemas/":                         http.StatusOK,
+		"/schemas/portfolio.report":         http.StatusOK,
+		"/schemas/portfolio.report/payload": http.StatusOK,
+		"/schemas/unknown.event":            http.StatusNotFound,
+	}
+	for path, want := range cases {
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s error: %v", path, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("GET %s = %d; want %d", path, resp.StatusCode, want)
+		}
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDatePattern_MatchesTimestampLayouts(t *testing.T) {
+	re := regexp.MustCompile(DatePattern)
+	accepted := []string{
+		"2026-01-01",
+		"2026-01-01 10:00:00",
+		"2026-01-01 10:00:00.5",
+		"2026-01-01T10:00:00Z",
+		"2026-01-01T10:00:00.123456789+03:00",
+		"2026-12-31T23:59:59-05:00",
+	}
+	for _, sample := range accepted {
+		if !re.MatchString(sample) {
+			t.Errorf("%q: expected the pattern to match", sample)
+		}
+		// Desenin kabul ettiği her değer servis tarafından da okunabilmeli
+		if _, err := event.ParseTimestamp(sample); err != nil {
+			t.Errorf("%q: pattern matches but ParseTimestamp fails: %v", sample, err)
+		}
+	}
+
+	rejected := []string{
+		"2026-01-01T10:00:00",  // saat dilimi yok
+		"2026-01-02 10:00:00Z", // boşlukla ayrılan saatte saat dilimi
+		"2026-01-01T10:00Z",
+		"2026-13-01",
+		"2026-01-01 24:00:00",
+		"2026-01-01T10:00:60Z",
+		"01/02/2026",
+	}
+	for _, sample := range rejected {
+		if re.MatchString(sample) {
+			t.Errorf("%q: expected the pattern not to match", sample)
+		}
+		if _, err := event.ParseTimestamp(sample); err == nil {
+			t.Errorf("%q: ParseTimestamp accepts a value the pattern rejects", sample)
+		}
+	}
+
+	var verrs event.ValidationErrors
+	message := []byte(`{"event_type":"portfolio.report","timestamp":"2026-01-01T10:00:00","payload":{"portfolios":[
+		{"portID":1,"name":"A","userID":"u1","createdAt":"2026-01-01","lastUpdate":"2026-01-01"}]}}`)
+	if err := ValidateMessage(message, ""); !errors.As(err, &verrs) || len(verrs) != 1 || verrs[0].Path != "timestamp" {
+		t.Errorf("Expected a pattern error on the zone-less envelope timestamp, got %v", err)
+	}
+}
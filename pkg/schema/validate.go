@@ -0,0 +1,217 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"time"
+	"unicode/utf8"
+
+	"github.com/burakmike/report-export-service/pkg/event"
+)
+
+// Validate değeri (json.Unmarshal ile çözülmüş) şemaya göre doğrular ve
+// tüm ihlalleri alan yollarıyla birlikte döndürür. Kural adı JSON Schema anahtar kelimesidir.
+func (s *Schema) Validate(value interface{}) event.ValidationErrors {
+	var errs event.ValidationErrors
+	s.validate("", value, &errs)
+	return errs
+}
+
+func (s *Schema) validate(path string, value interface{}, errs *event.ValidationErrors) {
+	fail := func(rule, format string, args ...interface{}) {
+		p := path
+		if p == "" {
+			p = "$"
+		}
+		*errs = append(*errs, event.FieldError{Path: p, Rule: rule, Value: value, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if s.Type != "" && !hasType(value, s.Type) {
+		fail("type", "must be of type %s", s.Type)
+		return
+	}
+	if s.Const != nil && !reflect.DeepEqual(s.Const, value) {
+		fail("const", "must be %v", s.Const)
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				*errs = append(*errs, event.FieldError{Path: join(path, name), Rule: "required", Message: "is required"})
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			child := v[name]
+			if prop, ok := s.Properties[name]; ok {
+				prop.validate(join(path, name), child, errs)
+			} else if s.AdditionalProperties != nil {
+				s.AdditionalProperties.validate(join(path, name), child, errs)
+			}
+		}
+	case []interface{}:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			fail("minItems", "must contain at least %d item(s)", *s.MinItems)
+		}
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, errs)
+			}
+		}
+	case string:
+		if s.MinLength != nil && utf8.RuneCountInString(v) < *s.MinLength {
+			fail("minLength", "must not be shorter than %d character(s)", *s.MinLength)
+		}
+		if s.Pattern != "" {
+			if re, err := regexp.Compile(s.Pattern); err == nil && !re.MatchString(v) {
+				if s.Description != "" {
+					fail("pattern", "must match format: %s", s.Description)
+				} else {
+					fail("pattern", "must match %s", s.Pattern)
+				}
+			}
+		}
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			fail("minimum", "must be >= %v", *s.Minimum)
+		}
+	}
+}
+
+// ValidateMessage bir event mesajını (JSON) event tipinin şemasına göre doğrular.
+// eventType boşsa mesajdaki event_type (CloudEvents'te type) kullanılır. Structured
+// mode CloudEvents'te zarf yerine data alanı payload şemasına göre doğrulanır. Şema kontrolünden geçen
+// payload'lar ayrıca kendi Validate metoduyla denetlenir. Yayıncı tarafındaki bu
+// kontrol, servisin işi durdurmadan kabul ettiği veri kalitesi sorunlarını
+// (ör. tekrarlanan PortID) da hata olarak bildirir.
+func ValidateMessage(data []byte, eventType event.EventType) error {
+	var message interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if err := dec.Decode(&message); err != nil {
+		return fmt.Errorf("message is not valid JSON: %w", err)
+	}
+	if obj, ok := message.(map[string]interface{}); ok {
+		if _, ok := obj["specversion"]; ok {
+			return validateCloudEvent(data, eventType)
+		}
+	}
+
+	if eventType == "" {
+		if obj, ok := message.(map[string]interface{}); ok {
+			if t, ok := obj["event_type"].(string); ok {
+				eventType = event.EventType(t)
+			}
+		}
+		if eventType == "" {
+			return fmt.Errorf("message has no event_type; specify the event type explicitly")
+		}
+	}
+
+	s, err := ForEvent(eventType)
+	if err != nil {
+		return err
+	}
+	if errs := s.Validate(message); len(errs) > 0 {
+		return errs
+	}
+
+	var envelope event.BaseEvent
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return fmt.Errorf("message does not match the event envelope: %w", err)
+	}
+	return validatePayload(eventType, envelope.Payload)
+}
+
+// validateCloudEvent structured mode bir CloudEvent'in özniteliklerini denetler ve
+// data alanını payload şemasına göre doğrular. Hata yolları "data." ile başlar.
+func validateCloudEvent(data []byte, eventType event.EventType) error {
+	evt, err := event.ParseEvent(data)
+	if err != nil {
+		return fmt.Errorf("message is not a valid CloudEvent: %w", err)
+	}
+	if eventType == "" {
+		eventType = evt.EventType
+	}
+
+	s, err := ForPayload(eventType)
+	if err != nil {
+		return err
+	}
+	var payload interface{}
+	if len(evt.Payload) > 0 {
+		if err := json.Unmarshal(evt.Payload, &payload); err != nil {
+			return fmt.Errorf("CloudEvent data is not valid JSON: %w", err)
+		}
+	}
+	var errs event.ValidationErrors
+	s.validate("data", payload, &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return validatePayload(eventType, evt.Payload)
+}
+
+// validatePayload şemadan geçen payload'ı kayıtlı tipine çözer ve tipin kendi
+// doğrulamasını ve veri kalitesi denetimini çalıştırır
+func validatePayload(eventType event.EventType, raw json.RawMessage) error {
+	payloadType, _ := event.PayloadType(eventType)
+	payload := reflect.New(payloadType)
+	if err := json.Unmarshal(raw, payload.Interface()); err != nil {
+		return fmt.Errorf("payload does not match %s: %w", payloadType, err)
+	}
+	if v, ok := payload.Elem().Interface().(event.Validator); ok {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	}
+	if q, ok := payload.Elem().Interface().(event.QualityChecker); ok {
+		if issues := q.CheckQuality(time.Now()); len(issues) > 0 {
+			return event.ValidationErrors(issues)
+		}
+	}
+	return nil
+}
+
+// hasType değerin JSON Schema tipine uyup uymadığını döndürür
+func hasType(value interface{}, typ string) bool {
+	switch typ {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		f, ok := value.(float64)
+		return ok && f == math.Trunc(f)
+	default:
+		return true
+	}
+}
+
+// join alan yolunu nokta ile birleştirir
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
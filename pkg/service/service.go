@@ -5,15 +5,21 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 
+	"github.com/burakmike/report-export-service/pkg/chunk"
 	"github.com/burakmike/report-export-service/pkg/config"
 	"github.com/burakmike/report-export-service/pkg/event"
 	"github.com/burakmike/report-export-service/pkg/handler"
+	"github.com/burakmike/report-export-service/pkg/job"
+	"github.com/burakmike/report-export-service/pkg/portfolio"
 	"github.com/burakmike/report-export-service/pkg/rabbitmq"
 	"github.com/burakmike/report-export-service/pkg/report"
+	"github.com/burakmike/report-export-service/pkg/schema"
+	"github.com/burakmike/report-export-service/pkg/signing"
 	_ "github.com/lib/pq"
 	"github.com/streadway/amqp"
 )
@@ -32,6 +38,7 @@ type Service struct {
 	CancelFunc   context.CancelFunc
 	PDFGenerator *report.PDFGenerator
 	DB           *sql.DB
+	SchemaServer *http.Server
 }
 
 // NewService yeni bir hizmet oluşturur ve bağımlılıkları kurar
@@ -51,6 +58,7 @@ func NewService() *Service {
 		log.Printf("Warning: Failed to initialize PDF generator: %v. PDF reports will not be generated.", err)
 		pdfGenerator = nil
 	} else {
+		pdfGenerator.EmbedSourceData = cfg.EmbedSourceData
 		log.Printf("PDF generator initialized. Reports will be saved to: %s", defaultReportDir)
 	}
 	
@@ -71,7 +79,24 @@ func NewService() *Service {
 func (s *Service) SetupHandlers() {
 	// Portfolio rapor işleyicisi
 	portfolioHandler := handler.NewPortfolioReportHandler(s.DB, s.PDFGenerator)
+	portfolioHandler.StreamThreshold = s.Config.StreamThresholdBytes
+	portfolioHandler.VolumeRows = s.Config.PDFVolumeRows
 	s.Registry.RegisterHandler(portfolioHandler)
+
+	// Portföyleri depodan yükleyen rapor isteği işleyicisi
+	var portfolios portfolio.Repository
+	if s.DB != nil {
+		portfolios = portfolio.NewSQLRepository(s.DB)
+	}
+	handler.NewPortfolioReportRequestHandler(portfolios, portfolioHandler).Register(s.Registry)
+	handler.NewPortfolioGroupReportHandler(s.DB, s.PDFGenerator, portfolios).Register(s.Registry)
+	
+	// İşlem geçmişi rapor işleyicisi
+	handler.NewTransactionReportHandler(s.DB, s.PDFGenerator).Register(s.Registry)
+	handler.NewHoldingsReportHandler(s.DB, s.PDFGenerator).Register(s.Registry)
+	handler.NewPerformanceReportHandler(s.DB, s.PDFGenerator).Register(s.Registry)
+	handler.NewRealizedGainsReportHandler(s.DB, s.PDFGenerator).Register(s.Registry)
+	handler.NewIncomeReportHandler(s.DB, s.PDFGenerator).Register(s.Registry)
 	
 	// İleride başka işleyiciler de buraya eklenebilir
 	
@@ -110,7 +135,48 @@ func (s *Service) Start() error {
 	if _, err := s.DB.Exec(createTableQuery); err != nil {
 		return fmt.Errorf("failed to create reports table: %w", err)
 	}
+
+	// Envelope v2 alanları için sütunları ekle (mevcut tablolar için)
+	migrateTableQuery := `
+	ALTER TABLE reports
+		ADD COLUMN IF NOT EXISTS event_id TEXT,
+		ADD COLUMN IF NOT EXISTS correlation_id TEXT,
+		ADD COLUMN IF NOT EXISTS requested_by TEXT,
+		ADD COLUMN IF NOT EXISTS group_id TEXT;`
+	if _, err := s.DB.Exec(migrateTableQuery); err != nil {
+		return fmt.Errorf("failed to migrate reports table: %w", err)
+	}
 	
+	// İş durumları tablosunu oluştur ve kayıt tutmayı etkinleştir
+	if _, err := s.DB.Exec(job.CreateTableQuery); err != nil {
+		return fmt.Errorf("failed to create report_jobs table: %w", err)
+	}
+	s.Registry.Jobs = job.NewSQLRecorder(s.DB)
+
+	// Parçalı mesajları yeniden başlatmalarda korunacak şekilde veritabanında biriktir
+	if _, err := s.DB.Exec(chunk.CreateTableQuery); err != nil {
+		return fmt.Errorf("failed to create report_chunks table: %w", err)
+	}
+	s.Registry.Chunks = chunk.NewAssembler(chunk.NewSQLStore(s.DB), s.Config.ChunkTimeout)
+	s.Registry.Chunks.Jobs = s.Registry.Jobs
+	// Süresi dolan eksik istekleri her parçada değil arka planda periyodik olarak sil
+	go s.Registry.Chunks.Run(s.Context, chunk.DefaultExpireInterval)
+
+	// Rapor isteklerinin okuduğu portföy referans verisi tablosunu oluştur
+	if _, err := s.DB.Exec(portfolio.CreateTableQuery); err != nil {
+		return fmt.Errorf("failed to create portfolios table: %w", err)
+	}
+
+	// Rapor imzalamayı etkinleştir (yapılandırılmışsa)
+	if s.Config.SigningKeyPath != "" && s.PDFGenerator != nil {
+		signer, err := signing.NewSigner(s.Config.SigningKeyPath)
+		if err != nil {
+			return fmt.Errorf("failed to load report signing key: %w", err)
+		}
+		s.PDFGenerator.Signer = signer
+		log.Printf("Report signing enabled with key %s", signer.KeyID)
+	}
+
 	// Setup event handlers with DB connection
 	s.SetupHandlers()
 
@@ -142,6 +208,11 @@ func (s *Service) Start() error {
 		return err
 	}
 	
+	// Event şemalarını HTTP üzerinden sun (yapılandırılmışsa)
+	if s.Config.SchemaHTTPAddr != "" {
+		s.startSchemaServer()
+	}
+	
 	// Bağlantı kapanışını izleyen kanal
 	closeChan := make(chan *amqp.Error)
 	s.RabbitMQ.Connection.NotifyClose(closeChan)
@@ -154,6 +225,17 @@ func (s *Service) Start() error {
 	return nil
 }
 
+// startSchemaServer event şemalarını sunan HTTP sunucusunu arka planda başlatır
+func (s *Service) startSchemaServer() {
+	s.SchemaServer = &http.Server{Addr: s.Config.SchemaHTTPAddr, Handler: schema.Handler()}
+	go func() {
+		log.Printf("Serving event schemas on %s%s", s.Config.SchemaHTTPAddr, schema.PathPrefix)
+		if err := s.SchemaServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("Warning: schema HTTP server stopped: %v", err)
+		}
+	}()
+}
+
 // monitorConnection RabbitMQ bağlantısını izler, kapanırsa yeniden bağlanmayı dener
 func (s *Service) monitorConnection(closeChan chan *amqp.Error) {
 	for {
@@ -191,6 +273,11 @@ func (s *Service) Stop() {
 		s.RabbitMQ.Close()
 	}
 	
+	// Şema sunucusunu kapat
+	if s.SchemaServer != nil {
+		s.SchemaServer.Close()
+	}
+	
 	log.Println("Service shutdown complete")
 }
 
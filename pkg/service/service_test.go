@@ -25,6 +25,27 @@ func TestSetupHandlers(t *testing.T) {
 	if _, ok := h.(*handler.PortfolioReportHandler); !ok {
 		t.Errorf("Expected *PortfolioReportHandler, got %T", h)
 	}
+	if s.Registry.GetHandler(event.TransactionReport) == nil {
+		t.Errorf("Expected handler for event %v, got nil", event.TransactionReport)
+	}
+	if s.Registry.GetHandler(event.HoldingsReport) == nil {
+		t.Errorf("Expected handler for event %v, got nil", event.HoldingsReport)
+	}
+	if s.Registry.GetHandler(event.PerformanceReport) == nil {
+		t.Errorf("Expected handler for event %v, got nil", event.PerformanceReport)
+	}
+	if s.Registry.GetHandler(event.RealizedGainsReport) == nil {
+		t.Errorf("Expected handler for event %v, got nil", event.RealizedGainsReport)
+	}
+	if s.Registry.GetHandler(event.IncomeReport) == nil {
+		t.Errorf("Expected handler for event %v, got nil", event.IncomeReport)
+	}
+	if s.Registry.GetHandler(event.PortfolioReportRequest) == nil {
+		t.Errorf("Expected handler for event %v, got nil", event.PortfolioReportRequest)
+	}
+	if s.Registry.GetHandler(event.PortfolioGroupReport) == nil {
+		t.Errorf("Expected handler for event %v, got nil", event.PortfolioGroupReport)
+	}
 }
 
 func TestGeneratePortfolioReportPDF_NotInitialized(t *testing.T) {
@@ -43,7 +64,7 @@ func TestGeneratePortfolioReportPDF_Success(t *testing.T) {
 	}
 	s := &Service{PDFGenerator: gen}
 	portfolios := []event.Portfolio{
-		{PortID: 1, Name: "Test", UserID: "user1", CreatedAt: "2023-01-01 00:00:00", LastUpdate: "2023-01-02 00:00:00"},
+		{PortID: 1, Name: "Test", UserID: "user1", CreatedAt: event.MustParseTimestamp("2023-01-01 00:00:00"), LastUpdate: event.MustParseTimestamp("2023-01-02 00:00:00")},
 	}
 	path, err := s.GeneratePortfolioReportPDF(portfolios)
 	if err != nil {
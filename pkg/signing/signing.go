@@ -0,0 +1,252 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Algorithm imzalarda kullanılan algoritma. Ed25519ph dosyayı SHA-512 ile
+// akış halinde özetler, böylece büyük raporlar belleğe alınmadan imzalanabilir.
+const Algorithm = "Ed25519ph"
+
+// SignatureExt ayrık imza dosyalarının uzantısı
+const SignatureExt = ".sig"
+
+// ErrInvalidSignature dosya imzayla eşleşmediğinde döner
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// Signature bir rapor dosyasının yanına yazılan ayrık imza (sidecar) içeriği
+type Signature struct {
+	Algorithm string `json:"algorithm"`
+	KeyID     string `json:"key_id"`
+	File      string `json:"file"`
+	SHA512    string `json:"sha512"`
+	Signature string `json:"signature"`
+	SignedAt  string `json:"signed_at"`
+}
+
+// Signer yerel olarak yapılandırılmış özel anahtar ile dosya imzalar
+type Signer struct {
+	key   ed25519.PrivateKey
+	KeyID string
+}
+
+// NewSigner PEM (PKCS#8) formatındaki özel anahtardan yeni bir imzalayıcı oluşturur
+func NewSigner(privateKeyPath string) (*Signer, error) {
+	key, err := LoadPrivateKey(privateKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Signer{
+		key:   key,
+		KeyID: KeyID(key.Public().(ed25519.PublicKey)),
+	}, nil
+}
+
+// SignaturePath bir dosyanın ayrık imza dosyasının yolunu döndürür
+func SignaturePath(filePath string) string {
+	return filePath + SignatureExt
+}
+
+// Reference rapor içine (ör. PDF meta verisi) gömülecek imza referansını döndürür.
+// İmza dosyanın son haline atıldığı için dosyanın içine imzanın kendisi değil,
+// imza dosyasının adı ve anahtar kimliği yazılır.
+func (s *Signer) Reference(filePath string) string {
+	return fmt.Sprintf("signature=%s; algorithm=%s; key_id=%s",
+		filepath.Base(SignaturePath(filePath)), Algorithm, s.KeyID)
+}
+
+// SignFile dosyayı imzalar ve imzayı <dosya>.sig olarak yanına yazar.
+// İmza yalnızca özeti değil, dosya adı ve imza zamanını da kapsar (bkz. signedMessage).
+func (s *Signer) SignFile(filePath string) (string, error) {
+	digest, err := fileDigest(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	sig := Signature{
+		Algorithm: Algorithm,
+		KeyID:     s.KeyID,
+		File:      filepath.Base(filePath),
+		SHA512:    hex.EncodeToString(digest),
+		SignedAt:  time.Now().UTC().Format(time.RFC3339),
+	}
+	raw, err := s.key.Sign(nil, signedMessage(sig), &ed25519.Options{Hash: crypto.SHA512})
+	if err != nil {
+		return "", fmt.Errorf("failed to sign %s: %w", filePath, err)
+	}
+	sig.Signature = base64.StdEncoding.EncodeToString(raw)
+
+	data, err := json.MarshalIndent(sig, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode signature: %w", err)
+	}
+
+	sigPath := SignaturePath(filePath)
+	if err := os.WriteFile(sigPath, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write signature file: %w", err)
+	}
+	return sigPath, nil
+}
+
+// VerifyFile dosyayı ayrık imzası ve açık anahtar ile doğrular. Dosya adı,
+// içerik özeti ve imza zamanı imzalı mesajın parçası olduğundan yeniden
+// adlandırılmış bir dosya ya da değiştirilmiş bir imza dosyası reddedilir.
+func VerifyFile(filePath, sigPath string, publicKey ed25519.PublicKey) (Signature, error) {
+	var sig Signature
+
+	data, err := os.ReadFile(sigPath)
+	if err != nil {
+		return sig, fmt.Errorf("failed to read signature file: %w", err)
+	}
+	if err := json.Unmarshal(data, &sig); err != nil {
+		return sig, fmt.Errorf("failed to parse signature file: %w", err)
+	}
+
+	if sig.Algorithm != Algorithm {
+		return sig, fmt.Errorf("unsupported signature algorithm %q", sig.Algorithm)
+	}
+	if keyID := KeyID(publicKey); sig.KeyID != keyID {
+		return sig, fmt.Errorf("%w: signed with key %s, but public key is %s", ErrInvalidSignature, sig.KeyID, keyID)
+	}
+	if name := filepath.Base(filePath); sig.File != name {
+		return sig, fmt.Errorf("%w: signature is for %q, not %q", ErrInvalidSignature, sig.File, name)
+	}
+
+	rawSig, err := base64.StdEncoding.DecodeString(sig.Signature)
+	if err != nil {
+		return sig, fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	digest, err := fileDigest(filePath)
+	if err != nil {
+		return sig, err
+	}
+	if hex.EncodeToString(digest) != sig.SHA512 {
+		return sig, fmt.Errorf("%w: file content does not match signature", ErrInvalidSignature)
+	}
+	if err := ed25519.VerifyWithOptions(publicKey, signedMessage(sig), rawSig, &ed25519.Options{Hash: crypto.SHA512}); err != nil {
+		return sig, fmt.Errorf("%w: signature does not match its metadata", ErrInvalidSignature)
+	}
+
+	return sig, nil
+}
+
+// signedMessage imzalanan kanonik mesajın SHA-512 özetini döndürür (Ed25519ph
+// önceden özetlenmiş mesaj bekler). Mesaj algoritma, anahtar kimliği, dosya adı,
+// dosya özeti ve imza zamanını sabit sırada satır satır içerir; dosya adı
+// tırnaklanır, böylece ad içindeki satır sonları alanları kaydıramaz.
+func signedMessage(sig Signature) []byte {
+	msg := fmt.Sprintf("algorithm=%s\nkey_id=%s\nfile=%q\nsha512=%s\nsigned_at=%s\n",
+		sig.Algorithm, sig.KeyID, sig.File, sig.SHA512, sig.SignedAt)
+	sum := sha512.Sum512([]byte(msg))
+	return sum[:]
+}
+
+// KeyID açık anahtarın kısa parmak izini döndürür
+func KeyID(publicKey ed25519.PublicKey) string {
+	sum := sha256.Sum256(publicKey)
+	return hex.EncodeToString(sum[:8])
+}
+
+// GenerateKeyPair yeni bir Ed25519 anahtar çifti oluşturur ve PEM olarak yazar
+func GenerateKeyPair(privateKeyPath, publicKeyPath string) error {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return fmt.Errorf("failed to generate key pair: %w", err)
+	}
+
+	privDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return fmt.Errorf("failed to encode private key: %w", err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return fmt.Errorf("failed to encode public key: %w", err)
+	}
+
+	if err := os.WriteFile(privateKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0600); err != nil {
+		return fmt.Errorf("failed to write private key: %w", err)
+	}
+	if err := os.WriteFile(publicKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0644); err != nil {
+		return fmt.Errorf("failed to write public key: %w", err)
+	}
+	return nil
+}
+
+// LoadPrivateKey PEM (PKCS#8) formatındaki Ed25519 özel anahtarını okur
+func LoadPrivateKey(path string) (ed25519.PrivateKey, error) {
+	der, err := readPEM(path, "PRIVATE KEY")
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key in %s is not an Ed25519 key", path)
+	}
+	return edKey, nil
+}
+
+// LoadPublicKey PEM (PKIX) formatındaki Ed25519 açık anahtarını okur
+func LoadPublicKey(path string) (ed25519.PublicKey, error) {
+	der, err := readPEM(path, "PUBLIC KEY")
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	edKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key in %s is not an Ed25519 key", path)
+	}
+	return edKey, nil
+}
+
+// readPEM dosyadaki beklenen tipteki ilk PEM bloğunu döndürür
+func readPEM(path, blockType string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != blockType {
+		return nil, fmt.Errorf("%s does not contain a PEM %q block", path, blockType)
+	}
+	return block.Bytes, nil
+}
+
+// fileDigest dosyanın SHA-512 özetini akış halinde hesaplar
+func fileDigest(filePath string) ([]byte, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", filePath, err)
+	}
+	defer f.Close()
+
+	h := sha512.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+	return h.Sum(nil), nil
+}
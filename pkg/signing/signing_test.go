@@ -0,0 +1,149 @@
+package signing
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestSigner(t *testing.T) (*Signer, string) {
+	t.Helper()
+	dir := t.TempDir()
+	privPath := filepath.Join(dir, "report.key")
+	pubPath := filepath.Join(dir, "report.pub")
+	if err := GenerateKeyPair(privPath, pubPath); err != nil {
+		t.Fatalf("GenerateKeyPair error: %v", err)
+	}
+	signer, err := NewSigner(privPath)
+	if err != nil {
+		t.Fatalf("NewSigner error: %v", err)
+	}
+	return signer, pubPath
+}
+
+func TestSignAndVerifyFile(t *testing.T) {
+	signer, pubPath := newTestSigner(t)
+	pub, err := LoadPublicKey(pubPath)
+	if err != nil {
+		t.Fatalf("LoadPublicKey error: %v", err)
+	}
+
+	file := filepath.Join(t.TempDir(), "report.pdf")
+	if err := os.WriteFile(file, []byte("report content"), 0644); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+
+	sigPath, err := signer.SignFile(file)
+	if err != nil {
+		t.Fatalf("SignFile error: %v", err)
+	}
+	if sigPath != file+".sig" {
+		t.Errorf("SignFile path = %s; want %s.sig", sigPath, file)
+	}
+
+	sig, err := VerifyFile(file, sigPath, pub)
+	if err != nil {
+		t.Fatalf("VerifyFile error: %v", err)
+	}
+	if sig.KeyID != signer.KeyID || sig.File != "report.pdf" {
+		t.Errorf("Unexpected signature metadata: %+v", sig)
+	}
+
+	if ref := signer.Reference(file); !strings.Contains(ref, "report.pdf.sig") || !strings.Contains(ref, signer.KeyID) {
+		t.Errorf("Reference %q should name the sidecar and key", ref)
+	}
+
+	// Tampered content must fail
+	if err := os.WriteFile(file, []byte("report content!"), 0644); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+	if _, err := VerifyFile(file, sigPath, pub); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("VerifyFile tampered error = %v; want ErrInvalidSignature", err)
+	}
+}
+
+func TestVerifyFile_WrongKey(t *testing.T) {
+	signer, _ := newTestSigner(t)
+	_, otherPubPath := newTestSigner(t)
+	otherPub, err := LoadPublicKey(otherPubPath)
+	if err != nil {
+		t.Fatalf("LoadPublicKey error: %v", err)
+	}
+
+	file := filepath.Join(t.TempDir(), "report.csv")
+	os.WriteFile(file, []byte("a,b\n"), 0644)
+	sigPath, err := signer.SignFile(file)
+	if err != nil {
+		t.Fatalf("SignFile error: %v", err)
+	}
+
+	if _, err := VerifyFile(file, sigPath, otherPub); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("VerifyFile wrong key error = %v; want ErrInvalidSignature", err)
+	}
+}
+
+func TestVerifyFile_SignedMetadata(t *testing.T) {
+	signer, pubPath := newTestSigner(t)
+	pub, err := LoadPublicKey(pubPath)
+	if err != nil {
+		t.Fatalf("LoadPublicKey error: %v", err)
+	}
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "report_a.pdf")
+	os.WriteFile(file, []byte("report content"), 0644)
+	sigPath, err := signer.SignFile(file)
+	if err != nil {
+		t.Fatalf("SignFile error: %v", err)
+	}
+
+	// Yeniden adlandırılmış dosya, imza dosyası da taşınsa bile reddedilmeli
+	renamed := filepath.Join(dir, "report_b.pdf")
+	os.WriteFile(renamed, []byte("report content"), 0644)
+	if _, err := VerifyFile(renamed, sigPath, pub); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("VerifyFile renamed error = %v; want ErrInvalidSignature", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*Signature)
+	}{
+		{"signed_at", func(sig *Signature) { sig.SignedAt = "2020-01-01T00:00:00Z" }},
+		{"file", func(sig *Signature) { sig.File = "report_b.pdf" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := os.ReadFile(sigPath)
+			if err != nil {
+				t.Fatalf("ReadFile error: %v", err)
+			}
+			var sig Signature
+			if err := json.Unmarshal(data, &sig); err != nil {
+				t.Fatalf("Unmarshal error: %v", err)
+			}
+			tt.modify(&sig)
+			data, _ = json.Marshal(sig)
+			forged := filepath.Join(t.TempDir(), "forged.sig")
+			os.WriteFile(forged, data, 0644)
+
+			target := filepath.Join(dir, sig.File)
+			if _, err := VerifyFile(target, forged, pub); !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("VerifyFile with modified %s error = %v; want ErrInvalidSignature", tt.name, err)
+			}
+		})
+	}
+}
+
+func TestLoadKeys_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.pem")
+	os.WriteFile(path, []byte("not a key"), 0600)
+	if _, err := LoadPrivateKey(path); err == nil {
+		t.Error("LoadPrivateKey should fail for invalid PEM")
+	}
+	if _, err := LoadPublicKey(path); err == nil {
+		t.Error("LoadPublicKey should fail for invalid PEM")
+	}
+}